@@ -22,13 +22,15 @@ module "kms" {
   enable_key_rotation     = var.enable_key_rotation
   deletion_window_in_days = var.deletion_window_in_days
   
-  # 테스트 최적화: 복잡한 기능들 모두 비활성화
-  enable_multi_region     = false  # 복제본 생성 비활성화
-  replica_region          = null   # 복제본 리전 비활성화
-  enable_backup           = false  # 백업 볼트 비활성화
-  enable_auto_recovery    = false  # Lambda 자동 복구 비활성화
-  enable_monitoring       = false  # CloudWatch 모니터링 비활성화
-  enable_cloudtrail       = false  # CloudTrail 로깅 비활성화
+  # 복제본은 다른 리전 provider가 필요하므로 예제에서는 비활성화
+  enable_multi_region     = false
+  replica_region          = null
+
+  # 선택 기능은 테스트가 권한을 점검한 뒤 켬 (helpers.KMSTestConfig.EnableAllowedFeatures)
+  enable_backup           = var.enable_backup
+  enable_auto_recovery    = var.enable_auto_recovery
+  enable_monitoring       = var.enable_monitoring
+  enable_cloudtrail       = var.enable_cloudtrail
   
   tags = var.tags
 } 
\ No newline at end of file
@@ -43,6 +43,30 @@ variable "deletion_window_in_days" {
   }
 }
 
+variable "enable_backup" {
+  description = "AWS Backup 볼트/플랜 생성 여부"
+  type        = bool
+  default     = false
+}
+
+variable "enable_auto_recovery" {
+  description = "Lambda/EventBridge 자동 복구 생성 여부"
+  type        = bool
+  default     = false
+}
+
+variable "enable_monitoring" {
+  description = "CloudWatch 경보 생성 여부"
+  type        = bool
+  default     = false
+}
+
+variable "enable_cloudtrail" {
+  description = "CloudTrail, S3 버킷, CloudWatch 로그 그룹 생성 여부"
+  type        = bool
+  default     = false
+}
+
 variable "tags" {
   description = "리소스 태그"
   type        = map(string)
@@ -1,3 +1,16 @@
+locals {
+  # external_id가 있을 때만 Condition 추가 (조건식은 {}와 객체의 타입을 맞추지 못해 for 필터 사용)
+  external_id_condition = {
+    for key, value in {
+      Condition = {
+        StringEquals = {
+          "sts:ExternalId" = var.external_id
+        }
+      }
+    } : key => value if var.external_id != ""
+  }
+}
+
 # Terraform 실행 역할
 resource "aws_iam_role" "terraform_execution_role" {
   name = "Terraform-Execution-Role"
@@ -5,13 +18,13 @@ resource "aws_iam_role" "terraform_execution_role" {
   assume_role_policy = jsonencode({
     Version = "2012-10-17"
     Statement = [
-      {
+      merge({
         Action = "sts:AssumeRole"
         Effect = "Allow"
         Principal = {
           AWS = var.trusted_role_arns
         }
-      }
+      }, local.external_id_condition)
     ]
   })
 
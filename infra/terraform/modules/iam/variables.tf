@@ -29,4 +29,11 @@ variable "kms_key_arn" {
   description = "KMS 키 ARN (EC2 인스턴스 암호화용)"
   type        = string
   default     = ""
-} 
\ No newline at end of file
+}
+
+variable "external_id" {
+  description = "역할 위임 시 요구할 sts:ExternalId (비어 있으면 조건 없음)"
+  type        = string
+  default     = ""
+  sensitive   = true
+}
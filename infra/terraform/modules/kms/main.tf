@@ -23,6 +23,9 @@ locals {
   
   # 사용자 정의 태그와 기본 태그 병합 (사용자 정의 태그 우선)
   tags = merge(var.tags, local.default_tags)
+
+  # 자동 복구/백업 복제본 이름 접두사 (테스트는 unique_id로 병렬 실행 충돌 방지, 없으면 기존 이름 유지)
+  name_prefix = var.unique_id != "" ? "${var.project_name}-${var.environment}-${var.unique_id}" : "${var.project_name}-${var.environment}"
 }
 
 data "aws_caller_identity" "current" {}
@@ -355,7 +358,7 @@ resource "aws_backup_plan" "kms_backup" {
 resource "aws_backup_vault" "kms_backup_replica" {
   count = var.enable_multi_region && var.enable_backup ? 1 : 0
 
-  name        = "${var.project_name}-${var.environment}-kms-backup-replica"
+  name        = "${local.name_prefix}-kms-backup-replica"
   kms_key_arn = var.enable_multi_region ? aws_kms_replica_key.replica[0].arn : aws_kms_key.k8s_key.arn
   tags        = local.tags
 }
@@ -366,7 +369,7 @@ resource "aws_lambda_function" "auto_recovery" {
 
   filename         = data.archive_file.lambda_zip[0].output_path
   source_code_hash = data.archive_file.lambda_zip[0].output_base64sha256
-  function_name    = "${var.project_name}-${var.environment}-kms-auto-recovery"
+  function_name    = "${local.name_prefix}-kms-auto-recovery"
   role            = aws_iam_role.lambda_role[0].arn
   handler         = "auto_recovery.handler"
   runtime         = "python3.9"
@@ -396,7 +399,7 @@ data "archive_file" "lambda_zip" {
 resource "aws_iam_role" "lambda_role" {
   count = var.enable_auto_recovery ? 1 : 0
 
-  name = "${var.project_name}-${var.environment}-lambda-recovery-role"
+  name = "${local.name_prefix}-lambda-recovery-role"
 
   assume_role_policy = jsonencode({
     Version = "2012-10-17"
@@ -418,7 +421,7 @@ resource "aws_iam_role" "lambda_role" {
 resource "aws_iam_role_policy" "lambda_policy" {
   count = var.enable_auto_recovery ? 1 : 0
 
-  name = "${var.project_name}-${var.environment}-lambda-recovery-policy"
+  name = "${local.name_prefix}-lambda-recovery-policy"
   role = aws_iam_role.lambda_role[0].id
 
   policy = jsonencode({
@@ -454,7 +457,7 @@ resource "aws_iam_role_policy" "lambda_policy" {
 resource "aws_cloudwatch_event_rule" "key_state_change" {
   count = var.enable_auto_recovery ? 1 : 0
 
-  name        = "${var.project_name}-${var.environment}-kms-state-change"
+  name        = "${local.name_prefix}-kms-state-change"
   description = "KMS 키 상태 변경 감지"
 
   event_pattern = jsonencode({
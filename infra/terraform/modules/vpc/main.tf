@@ -38,6 +38,7 @@ resource "aws_internet_gateway" "k8s_igw" {
 }
 
 resource "aws_eip" "nat_eip" {
+  count  = var.enable_nat_gateway ? 1 : 0
   domain = "vpc"
   
   tags = merge(var.tags, {
@@ -46,7 +47,8 @@ resource "aws_eip" "nat_eip" {
 }
 
 resource "aws_nat_gateway" "k8s_nat" {
-  allocation_id = aws_eip.nat_eip.id
+  count         = var.enable_nat_gateway ? 1 : 0
+  allocation_id = aws_eip.nat_eip[0].id
   subnet_id     = aws_subnet.public_subnet.id
 
   tags = merge(var.tags, {
@@ -72,9 +74,13 @@ resource "aws_route_table" "public_rt" {
 resource "aws_route_table" "private_rt" {
   vpc_id = aws_vpc.k8s_vpc.id
 
-  route {
-    cidr_block     = "0.0.0.0/0"
-    nat_gateway_id = aws_nat_gateway.k8s_nat.id
+  # NAT 게이트웨이가 없으면 프라이빗 서브넷은 외부로 나가는 경로가 없습니다
+  dynamic "route" {
+    for_each = aws_nat_gateway.k8s_nat
+    content {
+      cidr_block     = "0.0.0.0/0"
+      nat_gateway_id = route.value.id
+    }
   }
 
   tags = merge(var.tags, {
@@ -90,4 +96,15 @@ resource "aws_route_table_association" "public_rta" {
 resource "aws_route_table_association" "private_rta" {
   subnet_id      = aws_subnet.private_subnet.id
   route_table_id = aws_route_table.private_rt.id
-} 
\ No newline at end of file
+}
+
+# NAT 게이트웨이를 조건부로 바꾸기 전 state 주소 호환
+moved {
+  from = aws_eip.nat_eip
+  to   = aws_eip.nat_eip[0]
+}
+
+moved {
+  from = aws_nat_gateway.k8s_nat
+  to   = aws_nat_gateway.k8s_nat[0]
+}
@@ -29,8 +29,8 @@ output "private_subnet_cidr" {
 }
 
 output "nat_gateway_id" {
-  description = "NAT 게이트웨이 ID"
-  value       = aws_nat_gateway.k8s_nat.id
+  description = "NAT 게이트웨이 ID (enable_nat_gateway가 false면 null)"
+  value       = one(aws_nat_gateway.k8s_nat[*].id)
 }
 
 output "internet_gateway_id" {
@@ -33,4 +33,14 @@ output "worker_private_ips" {
 output "security_group_id" {
   description = "생성된 보안 그룹 ID"
   value       = module.security.security_group_id
-} 
\ No newline at end of file
+} 
+
+output "master_instance_id" {
+  description = "Master node instance ID"
+  value       = module.master.instance_id
+}
+
+output "worker_instance_ids" {
+  description = "Worker nodes instance IDs"
+  value       = module.worker.instance_ids
+}
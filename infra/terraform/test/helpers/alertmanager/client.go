@@ -0,0 +1,177 @@
+package alertmanager
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/prometheus/prometheus/promql/parser"
+)
+
+const (
+	// ServiceName 릴리스 이름 prometheus로 설치한 Alertmanager 서비스
+	ServiceName = "prometheus-kube-prometheus-alertmanager"
+	// ServicePort Alertmanager 웹/API 포트
+	ServicePort = 9093
+
+	defaultTimeout = 30 * time.Second
+)
+
+// 알림 상태 (status.state)
+const (
+	StateActive      = "active"
+	StateSuppressed  = "suppressed"
+	StateUnprocessed = "unprocessed"
+)
+
+// Alert /api/v2/alerts 응답의 알림 하나
+type Alert struct {
+	Labels       map[string]string `json:"labels"`
+	Annotations  map[string]string `json:"annotations"`
+	StartsAt     time.Time         `json:"startsAt"`
+	EndsAt       time.Time         `json:"endsAt"`
+	UpdatedAt    time.Time         `json:"updatedAt"`
+	Fingerprint  string            `json:"fingerprint"`
+	GeneratorURL string            `json:"generatorURL"`
+	Status       AlertStatus       `json:"status"`
+	Receivers    []Receiver        `json:"receivers"`
+}
+
+// AlertStatus 알림 처리 상태
+type AlertStatus struct {
+	State       string   `json:"state"`
+	SilencedBy  []string `json:"silencedBy"`
+	InhibitedBy []string `json:"inhibitedBy"`
+}
+
+// Receiver 알림을 받은 수신자
+type Receiver struct {
+	Name string `json:"name"`
+}
+
+// Name alertname 레이블
+func (a Alert) Name() string {
+	return a.Labels["alertname"]
+}
+
+// Matches 모든 매처가 알림 레이블과 일치하는지
+func (a Alert) Matches(matchers []*labels.Matcher) bool {
+	for _, matcher := range matchers {
+		if !matcher.Matches(a.Labels[matcher.Name]) {
+			return false
+		}
+	}
+	return true
+}
+
+func (a Alert) String() string {
+	return fmt.Sprintf("%s %v (%s, 시작 %s)", a.Name(), a.Labels, a.Status.State, a.StartsAt.Format(time.RFC3339))
+}
+
+// ParseMatchers PromQL 셀렉터 형식의 레이블 매처 파싱
+//
+//	ParseMatchers(`alertname="PodRestartLoop"`, `namespace=~"bookinfo|default"`)
+func ParseMatchers(matchers ...string) ([]*labels.Matcher, error) {
+	if len(matchers) == 0 {
+		return nil, nil
+	}
+	parsed, err := parser.ParseMetricSelector("{" + strings.Join(matchers, ",") + "}")
+	if err != nil {
+		return nil, fmt.Errorf("레이블 매처 파싱 실패: %w", err)
+	}
+	return parsed, nil
+}
+
+// AlertsOptions 알림 조회 조건
+//
+// 기본값은 사일런스/억제되지 않은 활성 알림만 조회합니다.
+type AlertsOptions struct {
+	Matchers           []*labels.Matcher
+	IncludeSilenced    bool
+	IncludeInhibited   bool
+	IncludeUnprocessed bool
+	ReceiverNameRegex  string
+}
+
+// Client Alertmanager v2 API 클라이언트
+type Client struct {
+	baseURL    *url.URL
+	httpClient *http.Client
+}
+
+// NewClient Alertmanager 주소(예: http://localhost:9093)로 클라이언트 생성
+//
+// httpClient가 nil이면 30초 타임아웃의 기본 클라이언트를 사용합니다.
+func NewClient(address string, httpClient *http.Client) (*Client, error) {
+	baseURL, err := url.Parse(strings.TrimSuffix(address, "/"))
+	if err != nil {
+		return nil, fmt.Errorf("Alertmanager 주소 파싱 실패: %w", err)
+	}
+	if baseURL.Scheme == "" || baseURL.Host == "" {
+		return nil, fmt.Errorf("Alertmanager 주소에 scheme과 host가 필요합니다: %q", address)
+	}
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: defaultTimeout}
+	}
+	return &Client{baseURL: baseURL, httpClient: httpClient}, nil
+}
+
+// Alerts 조건에 맞는 알림 조회
+//
+// 매처는 filter 파라미터로 서버에 전달하고, 응답도 다시 걸러 서버 버전 차이를 흡수합니다.
+func (c *Client) Alerts(ctx context.Context, options AlertsOptions) ([]Alert, error) {
+	params := url.Values{
+		"active":      {"true"},
+		"silenced":    {strconv.FormatBool(options.IncludeSilenced)},
+		"inhibited":   {strconv.FormatBool(options.IncludeInhibited)},
+		"unprocessed": {strconv.FormatBool(options.IncludeUnprocessed)},
+	}
+	for _, matcher := range options.Matchers {
+		params.Add("filter", matcher.String())
+	}
+	if options.ReceiverNameRegex != "" {
+		params.Set("receiver", options.ReceiverNameRegex)
+	}
+
+	endpoint := *c.baseURL
+	endpoint.Path += "/api/v2/alerts"
+	endpoint.RawQuery = params.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("Alertmanager 알림 조회 실패: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("Alertmanager 응답 읽기 실패: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("Alertmanager 알림 조회 실패 (HTTP %d): %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	var alertList []Alert
+	if err := json.Unmarshal(body, &alertList); err != nil {
+		return nil, fmt.Errorf("Alertmanager 응답 파싱 실패: %w", err)
+	}
+
+	matched := alertList[:0]
+	for _, alert := range alertList {
+		if alert.Matches(options.Matchers) {
+			matched = append(matched, alert)
+		}
+	}
+	return matched, nil
+}
@@ -0,0 +1,119 @@
+package alertmanager
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gruntwork-io/terratest/modules/retry"
+	"github.com/gruntwork-io/terratest/modules/testing"
+	"github.com/prometheus/prometheus/model/labels"
+)
+
+// 렌더링되지 않은 템플릿 흔적
+var unrenderedTemplate = regexp.MustCompile(`\{\{|\}\}|<no value>`)
+
+// WaitForFiring 매처와 일치하는 알림이 발생할 때까지 timeout 동안 대기
+//
+// for 기간이 긴 규칙(PodRestartLoop 10m 등)은 timeout을 for + 평가 주기 + group_wait 이상으로 잡아야 합니다.
+func WaitForFiring(t testing.TestingT, client *Client, matchers []*labels.Matcher, timeout, pollInterval time.Duration) ([]Alert, error) {
+	var firing []Alert
+
+	_, err := retry.DoWithRetryE(t, fmt.Sprintf("알림 발생 대기 %s", formatMatchers(matchers)), retries(timeout, pollInterval), pollInterval, func() (string, error) {
+		alertList, err := client.Alerts(context.Background(), AlertsOptions{Matchers: matchers})
+		if err != nil {
+			return "", err
+		}
+		if len(alertList) == 0 {
+			return "", fmt.Errorf("일치하는 활성 알림 없음")
+		}
+		firing = alertList
+		return fmt.Sprintf("알림 %d개 발생", len(alertList)), nil
+	})
+
+	return firing, err
+}
+
+// WaitForResolved 매처와 일치하는 활성 알림이 모두 사라질 때까지 timeout 동안 대기
+//
+// Alertmanager는 resolve_timeout이 지나야 알림을 내리므로 장애 복구 후 여유를 두어야 합니다.
+func WaitForResolved(t testing.TestingT, client *Client, matchers []*labels.Matcher, timeout, pollInterval time.Duration) error {
+	_, err := retry.DoWithRetryE(t, fmt.Sprintf("알림 해제 대기 %s", formatMatchers(matchers)), retries(timeout, pollInterval), pollInterval, func() (string, error) {
+		alertList, err := client.Alerts(context.Background(), AlertsOptions{Matchers: matchers, IncludeSilenced: true, IncludeInhibited: true})
+		if err != nil {
+			return "", err
+		}
+		if len(alertList) > 0 {
+			names := make([]string, 0, len(alertList))
+			for _, alert := range alertList {
+				names = append(names, alert.String())
+			}
+			return "", fmt.Errorf("아직 활성 알림: %s", strings.Join(names, "; "))
+		}
+		return "알림 해제", nil
+	})
+	return err
+}
+
+// CheckAnnotations 알림 어노테이션 검사
+//
+// expected의 값은 정규식이며 빈 문자열이면 값이 있는지만 확인합니다.
+// 어노테이션 템플릿이 렌더링되지 않은 흔적({{, <no value>)도 문제로 보고합니다.
+func CheckAnnotations(alert Alert, expected map[string]string) []string {
+	keys := make([]string, 0, len(expected))
+	for key := range expected {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var problems []string
+	for _, key := range keys {
+		value, ok := alert.Annotations[key]
+		if !ok || value == "" {
+			problems = append(problems, fmt.Sprintf("%s 알림에 %s 어노테이션이 없습니다", alert.Name(), key))
+			continue
+		}
+		if expected[key] == "" {
+			continue
+		}
+		pattern, err := regexp.Compile(expected[key])
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("%s 어노테이션 기대값 정규식 오류: %v", key, err))
+			continue
+		}
+		if !pattern.MatchString(value) {
+			problems = append(problems, fmt.Sprintf("%s 알림의 %s 어노테이션 %q가 %s와 맞지 않습니다", alert.Name(), key, value, expected[key]))
+		}
+	}
+
+	annotationKeys := make([]string, 0, len(alert.Annotations))
+	for key := range alert.Annotations {
+		annotationKeys = append(annotationKeys, key)
+	}
+	sort.Strings(annotationKeys)
+	for _, key := range annotationKeys {
+		if unrenderedTemplate.MatchString(alert.Annotations[key]) {
+			problems = append(problems, fmt.Sprintf("%s 알림의 %s 어노테이션 템플릿이 렌더링되지 않았습니다: %q", alert.Name(), key, alert.Annotations[key]))
+		}
+	}
+	return problems
+}
+
+// timeout 동안 pollInterval 간격으로 조회하는 재시도 횟수 (첫 시도 제외)
+func retries(timeout, pollInterval time.Duration) int {
+	if pollInterval <= 0 {
+		return 0
+	}
+	return int(timeout / pollInterval)
+}
+
+func formatMatchers(matchers []*labels.Matcher) string {
+	parts := make([]string, 0, len(matchers))
+	for _, matcher := range matchers {
+		parts = append(parts, matcher.String())
+	}
+	return "{" + strings.Join(parts, ", ") + "}"
+}
@@ -0,0 +1,199 @@
+package alerts
+
+import (
+	"bytes"
+	"fmt"
+	"net"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/gruntwork-io/terratest/modules/terraform"
+	"github.com/gruntwork-io/terratest/modules/testing"
+)
+
+// NodeSelectorMode 알림 규칙에서 노드를 고르는 방식
+type NodeSelectorMode string
+
+const (
+	// SelectByRole kube_node_role 레이블로 노드 선택 (클러스터를 재구성해도 그대로 사용 가능)
+	SelectByRole NodeSelectorMode = "role"
+	// SelectByName Terraform 출력의 노드 이름으로 노드 선택
+	SelectByName NodeSelectorMode = "name"
+)
+
+// kubeadm 1.24 이전 클러스터는 master 역할 레이블을 사용
+const controlPlaneRoles = "control-plane|master"
+
+// 템플릿 구분자 (알림 어노테이션의 {{ $labels.* }}와 겹치지 않도록 별도 지정)
+const (
+	templateLeftDelim  = "[["
+	templateRightDelim = "]]"
+)
+
+// NodeTopology 알림 규칙을 렌더링할 노드 구성
+type NodeTopology struct {
+	Mode        NodeSelectorMode
+	MasterNodes []string // 쿠버네티스 노드 이름 (예: ip-10-0-1-34)
+	WorkerNodes []string
+}
+
+// RoleNodeTopology 노드 역할 레이블 기반 구성 (저장소에 커밋되는 기본 규칙)
+func RoleNodeTopology() NodeTopology {
+	return NodeTopology{Mode: SelectByRole}
+}
+
+// NodeTopologyFromAddresses 프라이빗 IP 또는 프라이빗 DNS 이름으로 노드 구성 생성
+func NodeTopologyFromAddresses(master string, workers []string) (NodeTopology, error) {
+	topology := NodeTopology{Mode: SelectByName}
+
+	masterName, err := NodeNameFromAddress(master)
+	if err != nil {
+		return NodeTopology{}, err
+	}
+	topology.MasterNodes = []string{masterName}
+
+	for _, worker := range workers {
+		workerName, err := NodeNameFromAddress(worker)
+		if err != nil {
+			return NodeTopology{}, err
+		}
+		topology.WorkerNodes = append(topology.WorkerNodes, workerName)
+	}
+	return topology, nil
+}
+
+// NodeTopologyFromModules ec2-master / ec2-worker 모듈 출력으로 노드 구성 생성
+func NodeTopologyFromModules(t testing.TestingT, masterOptions, workerOptions *terraform.Options) (NodeTopology, error) {
+	master, err := terraform.OutputE(t, masterOptions, "private_ip")
+	if err != nil {
+		return NodeTopology{}, err
+	}
+	workers, err := terraform.OutputListE(t, workerOptions, "private_ips")
+	if err != nil {
+		return NodeTopology{}, err
+	}
+	return NodeTopologyFromAddresses(master, workers)
+}
+
+// NodeTopologyFromRootStack 루트 스택(infra/terraform) 출력으로 노드 구성 생성
+func NodeTopologyFromRootStack(t testing.TestingT, options *terraform.Options) (NodeTopology, error) {
+	master, err := terraform.OutputE(t, options, "master_private_ip")
+	if err != nil {
+		return NodeTopology{}, err
+	}
+	workers, err := terraform.OutputListE(t, options, "worker_private_ips")
+	if err != nil {
+		return NodeTopology{}, err
+	}
+	return NodeTopologyFromAddresses(master, workers)
+}
+
+// NodeNameFromAddress EC2 프라이빗 IP/DNS를 kubeadm 노드 이름(호스트 이름)으로 변환
+//
+// 10.0.1.34, ip-10-0-1-34.ap-northeast-2.compute.internal 모두 ip-10-0-1-34가 됩니다.
+func NodeNameFromAddress(address string) (string, error) {
+	address = strings.TrimSpace(address)
+	if address == "" {
+		return "", fmt.Errorf("노드 주소가 비어 있습니다")
+	}
+	if ip := net.ParseIP(address); ip != nil {
+		ipv4 := ip.To4()
+		if ipv4 == nil {
+			return "", fmt.Errorf("IPv4 주소만 지원합니다: %s", address)
+		}
+		return fmt.Sprintf("ip-%d-%d-%d-%d", ipv4[0], ipv4[1], ipv4[2], ipv4[3]), nil
+	}
+	return strings.ToLower(strings.SplitN(address, ".", 2)[0]), nil
+}
+
+// ruleTemplateData 템플릿에서 사용하는 노드 필터
+//
+// 모든 값은 "expr and on(<label>) <필터>" 형태로 쓰는 PromQL 벡터입니다.
+type ruleTemplateData struct {
+	MasterInstances string // on(instance) 필터 (node-exporter 메트릭)
+	WorkerInstances string
+	MasterNodes     string // on(node) 필터 (kube-state-metrics 메트릭)
+	WorkerNodes     string
+}
+
+func (topology NodeTopology) templateData() (ruleTemplateData, error) {
+	switch topology.Mode {
+	case SelectByRole:
+		masterRole := fmt.Sprintf(`kube_node_role{role=~"%s"}`, controlPlaneRoles)
+		masterNodename := fmt.Sprintf(`label_replace(%s, "nodename", "$1", "node", "(.*)")`, masterRole)
+		return ruleTemplateData{
+			MasterInstances: fmt.Sprintf("(node_uname_info and on(nodename) %s)", masterNodename),
+			WorkerInstances: fmt.Sprintf("(node_uname_info unless on(nodename) %s)", masterNodename),
+			MasterNodes:     masterRole,
+			WorkerNodes:     fmt.Sprintf("(kube_node_info unless on(node) %s)", masterRole),
+		}, nil
+	case SelectByName:
+		if len(topology.MasterNodes) == 0 {
+			return ruleTemplateData{}, fmt.Errorf("마스터 노드 이름이 없습니다")
+		}
+		if len(topology.WorkerNodes) == 0 {
+			return ruleTemplateData{}, fmt.Errorf("워커 노드 이름이 없습니다")
+		}
+		masters := nodeNamePattern(topology.MasterNodes)
+		workers := nodeNamePattern(topology.WorkerNodes)
+		return ruleTemplateData{
+			MasterInstances: fmt.Sprintf("node_uname_info{nodename=~%s}", masters),
+			WorkerInstances: fmt.Sprintf("node_uname_info{nodename=~%s}", workers),
+			MasterNodes:     fmt.Sprintf("kube_node_info{node=~%s}", masters),
+			WorkerNodes:     fmt.Sprintf("kube_node_info{node=~%s}", workers),
+		}, nil
+	default:
+		return ruleTemplateData{}, fmt.Errorf("알 수 없는 노드 선택 방식: %q", topology.Mode)
+	}
+}
+
+// 노드 이름 목록을 PromQL 문자열 리터럴(정규식)로 변환
+func nodeNamePattern(names []string) string {
+	quoted := make([]string, len(names))
+	for i, name := range names {
+		quoted[i] = regexp.QuoteMeta(name)
+	}
+	return strconv.Quote(strings.Join(quoted, "|"))
+}
+
+// RenderPrometheusRule 템플릿에 노드 필터를 채워 PrometheusRule YAML 생성
+//
+// 렌더링 결과가 PrometheusRule로 파싱되지 않으면 오류를 반환합니다.
+func RenderPrometheusRule(templateText string, topology NodeTopology) ([]byte, error) {
+	data, err := topology.templateData()
+	if err != nil {
+		return nil, err
+	}
+
+	tmpl, err := template.New("alert-rules").
+		Delims(templateLeftDelim, templateRightDelim).
+		Parse(templateText)
+	if err != nil {
+		return nil, fmt.Errorf("알림 규칙 템플릿 파싱 실패: %w", err)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return nil, fmt.Errorf("알림 규칙 템플릿 렌더링 실패: %w", err)
+	}
+	if _, err := ParsePrometheusRule(buf.Bytes()); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// GeneratePrometheusRule 템플릿 파일을 렌더링하여 출력 파일에 기록
+func GeneratePrometheusRule(templatePath, outputPath string, topology NodeTopology) error {
+	templateText, err := os.ReadFile(templatePath)
+	if err != nil {
+		return err
+	}
+	rendered, err := RenderPrometheusRule(string(templateText), topology)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(outputPath, rendered, 0644)
+}
@@ -0,0 +1,273 @@
+package alerts
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/k8s-ec2-observability/test/helpers/promexpr"
+	"github.com/prometheus/common/model"
+	"github.com/prometheus/prometheus/model/timestamp"
+	"github.com/prometheus/prometheus/promql/parser"
+	"github.com/prometheus/prometheus/template"
+)
+
+// Severity 린트 결과 심각도
+type Severity string
+
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+)
+
+// 린트 검사 종류
+const (
+	CheckPromQLParse       = "promql-parse"
+	CheckDuration          = "duration"
+	CheckDuplicateAlert    = "duplicate-alert"
+	CheckMissingLabel      = "missing-label"
+	CheckInvalidSeverity   = "invalid-severity"
+	CheckMissingAnnotation = "missing-annotation"
+	CheckTemplate          = "template"
+	CheckTemplateLabel     = "template-label"
+	CheckHardcodedInstance = "hardcoded-instance"
+	CheckCountOffsetDelta  = "count-offset-delta"
+)
+
+// Finding 린트 결과 항목
+type Finding struct {
+	Group    string
+	Rule     string
+	Line     int
+	Severity Severity
+	Check    string
+	Message  string
+}
+
+func (f Finding) String() string {
+	return fmt.Sprintf("%d: [%s] %s/%s (%s): %s", f.Line, f.Severity, f.Group, f.Rule, f.Check, f.Message)
+}
+
+// LintOptions 린트 규칙 설정
+type LintOptions struct {
+	RequiredLabels      []string
+	RequiredAnnotations []string
+	AllowedSeverities   []string
+}
+
+// DefaultLintOptions custom-alert-rules.yaml에서 사용하는 기본 규칙
+func DefaultLintOptions() LintOptions {
+	return LintOptions{
+		RequiredLabels:      []string{"severity", "component"},
+		RequiredAnnotations: []string{"summary", "description"},
+		AllowedSeverities:   []string{"critical", "warning", "info"},
+	}
+}
+
+var templateLabelReference = regexp.MustCompile(`\$labels\.([a-zA-Z_][a-zA-Z0-9_]*)`)
+
+// Lint PrometheusRule의 모든 규칙 검사
+func Lint(rule *PrometheusRule, options LintOptions) []Finding {
+	var findings []Finding
+	seen := make(map[string]int)
+
+	for _, group := range rule.Spec.Groups {
+		if group.Interval != "" {
+			if _, err := model.ParseDuration(group.Interval); err != nil {
+				findings = append(findings, Finding{
+					Group: group.Name, Severity: SeverityError, Check: CheckDuration,
+					Message: fmt.Sprintf("그룹 interval 형식 오류: %v", err),
+				})
+			}
+		}
+
+		for _, r := range group.Rules {
+			report := func(severity Severity, check, format string, args ...interface{}) {
+				findings = append(findings, Finding{
+					Group: group.Name, Rule: r.Name(), Line: r.Line,
+					Severity: severity, Check: check, Message: fmt.Sprintf(format, args...),
+				})
+			}
+
+			if r.Alert != "" {
+				if line, ok := seen[r.Alert]; ok {
+					report(SeverityError, CheckDuplicateAlert, "알림 이름이 %d번째 줄과 중복됩니다", line)
+				}
+				seen[r.Alert] = r.Line
+			}
+
+			if r.For != "" {
+				if _, err := model.ParseDuration(r.For); err != nil {
+					report(SeverityError, CheckDuration, "for 형식 오류: %v", err)
+				}
+			}
+
+			expr, err := parser.ParseExpr(r.Expr)
+			if err != nil {
+				report(SeverityError, CheckPromQLParse, "PromQL 파싱 실패: %v", err)
+			}
+
+			if r.Alert == "" {
+				continue
+			}
+
+			for _, label := range options.RequiredLabels {
+				if r.Labels[label] == "" {
+					report(SeverityError, CheckMissingLabel, "필수 레이블 %q가 없습니다", label)
+				}
+			}
+			if severity, ok := r.Labels["severity"]; ok && len(options.AllowedSeverities) > 0 && !contains(options.AllowedSeverities, severity) {
+				report(SeverityError, CheckInvalidSeverity, "severity %q는 허용되지 않습니다 (허용: %s)", severity, strings.Join(options.AllowedSeverities, ", "))
+			}
+			for _, annotation := range options.RequiredAnnotations {
+				if r.Annotations[annotation] == "" {
+					report(SeverityError, CheckMissingAnnotation, "필수 어노테이션 %q가 없습니다", annotation)
+				}
+			}
+			for _, key := range sortedKeys(r.Annotations) {
+				if err := parseAlertTemplate(r.Alert, r.Annotations[key]); err != nil {
+					report(SeverityError, CheckTemplate, "어노테이션 %q 템플릿 오류: %v", key, err)
+				}
+			}
+
+			if expr == nil {
+				continue
+			}
+
+			for _, matcher := range promexpr.HardcodedNodeMatchers(expr) {
+				report(SeverityWarning, CheckHardcodedInstance, "노드 이름/IP가 고정된 매처 %s는 클러스터를 재구성할 때마다 깨집니다", matcher)
+			}
+			if delta := countOffsetDelta(expr); delta != "" {
+				report(SeverityWarning, CheckCountOffsetDelta, "%s는 시계열 개수 변화만 비교합니다. 카운터 증가량은 increase()를 사용하세요", delta)
+			}
+			if outputLabels, ok := aggregatedLabels(expr); ok {
+				for _, key := range sortedKeys(r.Annotations) {
+					for _, match := range templateLabelReference.FindAllStringSubmatch(r.Annotations[key], -1) {
+						if !contains(outputLabels, match[1]) {
+							report(SeverityWarning, CheckTemplateLabel, "어노테이션 %q가 참조하는 $labels.%s는 집계 결과에 남지 않습니다", key, match[1])
+						}
+					}
+				}
+			}
+		}
+	}
+
+	return findings
+}
+
+// Errors 심각도가 error인 항목만 반환
+func Errors(findings []Finding) []Finding {
+	return filterFindings(findings, SeverityError)
+}
+
+// Warnings 심각도가 warning인 항목만 반환
+func Warnings(findings []Finding) []Finding {
+	return filterFindings(findings, SeverityWarning)
+}
+
+func filterFindings(findings []Finding, severity Severity) []Finding {
+	var filtered []Finding
+	for _, finding := range findings {
+		if finding.Severity == severity {
+			filtered = append(filtered, finding)
+		}
+	}
+	return filtered
+}
+
+// rulefmt과 같은 방식으로 알림 템플릿 문법 검사
+func parseAlertTemplate(alertName, text string) error {
+	defs := "{{$labels := .Labels}}{{$externalLabels := .ExternalLabels}}{{$externalURL := .ExternalURL}}{{$value := .Value}}"
+	expander := template.NewTemplateExpander(
+		context.TODO(),
+		defs+text,
+		"__alert_"+alertName,
+		template.AlertTemplateData(map[string]string{}, map[string]string{}, "", 0),
+		model.Time(timestamp.FromTime(time.Now())),
+		nil, nil, nil,
+	)
+	return expander.ParseTest()
+}
+
+// count(x) - count(x offset d) 형태 탐지
+func countOffsetDelta(expr parser.Expr) string {
+	var found string
+	parser.Inspect(expr, func(node parser.Node, _ []parser.Node) error {
+		binary, ok := node.(*parser.BinaryExpr)
+		if !ok || binary.Op != parser.SUB || found != "" {
+			return nil
+		}
+		lhs, lhsOffset, lhsOK := countedSelector(binary.LHS)
+		rhs, rhsOffset, rhsOK := countedSelector(binary.RHS)
+		if lhsOK && rhsOK && lhs == rhs && lhsOffset != rhsOffset {
+			found = binary.String()
+		}
+		return nil
+	})
+	return found
+}
+
+func countedSelector(expr parser.Expr) (string, time.Duration, bool) {
+	aggregate, ok := unwrapParens(expr).(*parser.AggregateExpr)
+	if !ok || aggregate.Op != parser.COUNT {
+		return "", 0, false
+	}
+	selector, ok := unwrapParens(aggregate.Expr).(*parser.VectorSelector)
+	if !ok {
+		return "", 0, false
+	}
+	return selector.Name, selector.OriginalOffset, true
+}
+
+// 최상위가 집계식이면 결과에 남는 레이블 목록 반환
+func aggregatedLabels(expr parser.Expr) ([]string, bool) {
+	expr = unwrapParens(expr)
+	// "집계식 > 임계값" 형태의 비교 연산 제거
+	for {
+		binary, ok := expr.(*parser.BinaryExpr)
+		if !ok || !binary.Op.IsComparisonOperator() {
+			break
+		}
+		if _, ok := unwrapParens(binary.RHS).(*parser.NumberLiteral); !ok {
+			return nil, false
+		}
+		expr = unwrapParens(binary.LHS)
+	}
+
+	aggregate, ok := expr.(*parser.AggregateExpr)
+	if !ok || aggregate.Without {
+		return nil, false
+	}
+	return aggregate.Grouping, true
+}
+
+func unwrapParens(expr parser.Expr) parser.Expr {
+	for {
+		paren, ok := expr.(*parser.ParenExpr)
+		if !ok {
+			return expr
+		}
+		expr = paren.Expr
+	}
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,110 @@
+package alerts
+
+import (
+	"fmt"
+	"os"
+
+	"gopkg.in/yaml.v3"
+)
+
+// PrometheusRule monitoring.coreos.com/v1 PrometheusRule 리소스
+type PrometheusRule struct {
+	APIVersion string       `yaml:"apiVersion"`
+	Kind       string       `yaml:"kind"`
+	Metadata   RuleMetadata `yaml:"metadata"`
+	Spec       RuleSpec     `yaml:"spec"`
+}
+
+// RuleMetadata PrometheusRule 메타데이터
+type RuleMetadata struct {
+	Name      string            `yaml:"name"`
+	Namespace string            `yaml:"namespace"`
+	Labels    map[string]string `yaml:"labels"`
+}
+
+// RuleSpec PrometheusRule 스펙
+type RuleSpec struct {
+	Groups []RuleGroup `yaml:"groups"`
+}
+
+// RuleGroup 규칙 그룹
+type RuleGroup struct {
+	Name     string `yaml:"name"`
+	Interval string `yaml:"interval"`
+	Rules    []Rule `yaml:"rules"`
+}
+
+// Rule 알림/기록 규칙
+type Rule struct {
+	Alert       string            `yaml:"alert,omitempty"`
+	Record      string            `yaml:"record,omitempty"`
+	Expr        string            `yaml:"expr"`
+	For         string            `yaml:"for,omitempty"`
+	Labels      map[string]string `yaml:"labels,omitempty"`
+	Annotations map[string]string `yaml:"annotations,omitempty"`
+
+	// Line 원본 YAML에서 규칙이 시작하는 줄 번호
+	Line int `yaml:"-"`
+}
+
+// Name 알림 이름 또는 기록 규칙 이름
+func (r Rule) Name() string {
+	if r.Alert != "" {
+		return r.Alert
+	}
+	return r.Record
+}
+
+// UnmarshalYAML 규칙 위치(줄 번호)를 함께 기록
+func (r *Rule) UnmarshalYAML(node *yaml.Node) error {
+	type plain Rule
+	if err := node.Decode((*plain)(r)); err != nil {
+		return err
+	}
+	r.Line = node.Line
+	return nil
+}
+
+// AlertingRules 모든 그룹의 알림 규칙 목록
+func (p *PrometheusRule) AlertingRules() []Rule {
+	var rules []Rule
+	for _, group := range p.Spec.Groups {
+		for _, rule := range group.Rules {
+			if rule.Alert != "" {
+				rules = append(rules, rule)
+			}
+		}
+	}
+	return rules
+}
+
+// FindAlert 이름으로 알림 규칙 조회
+func (p *PrometheusRule) FindAlert(name string) (Rule, bool) {
+	for _, rule := range p.AlertingRules() {
+		if rule.Alert == name {
+			return rule, true
+		}
+	}
+	return Rule{}, false
+}
+
+// ParsePrometheusRule PrometheusRule YAML 파싱
+func ParsePrometheusRule(data []byte) (*PrometheusRule, error) {
+	var rule PrometheusRule
+	if err := yaml.Unmarshal(data, &rule); err != nil {
+		return nil, fmt.Errorf("PrometheusRule 파싱 실패: %w", err)
+	}
+	if rule.Kind != "PrometheusRule" {
+		return nil, fmt.Errorf("PrometheusRule 리소스가 아닙니다: kind=%q", rule.Kind)
+	}
+	return &rule, nil
+}
+
+// LoadPrometheusRule 파일에서 PrometheusRule 로드
+func LoadPrometheusRule(path string) (*PrometheusRule, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePrometheusRule(data)
+}
@@ -0,0 +1,223 @@
+package alerts
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/prometheus/common/model"
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/prometheus/prometheus/promql"
+	"github.com/prometheus/prometheus/promql/parser"
+	"github.com/prometheus/prometheus/rules"
+	"gopkg.in/yaml.v3"
+)
+
+// RuleTestFile promtool test rules 형식의 테스트 파일 (rule_files 대신 PrometheusRule을 직접 사용)
+type RuleTestFile struct {
+	EvaluationInterval model.Duration `yaml:"evaluation_interval"`
+	Tests              []RuleTest     `yaml:"tests"`
+}
+
+// RuleTest 입력 시계열과 기대 알림 묶음
+type RuleTest struct {
+	Name           string          `yaml:"name"`
+	Interval       model.Duration  `yaml:"interval"`
+	InputSeries    []InputSeries   `yaml:"input_series"`
+	AlertRuleTests []AlertTestCase `yaml:"alert_rule_test"`
+}
+
+// InputSeries 합성 시계열 (promtool 확장 표기법: '0+10x20', '1x30', '_' 등)
+type InputSeries struct {
+	Series string `yaml:"series"`
+	Values string `yaml:"values"`
+}
+
+// AlertTestCase 특정 평가 시점의 기대 알림
+type AlertTestCase struct {
+	EvalTime  model.Duration  `yaml:"eval_time"`
+	Alertname string          `yaml:"alertname"`
+	ExpAlerts []ExpectedAlert `yaml:"exp_alerts"`
+}
+
+// ExpectedAlert 기대 알림 (레이블은 alertname을 제외한 전체, 어노테이션은 지정한 키만 비교)
+type ExpectedAlert struct {
+	ExpLabels      map[string]string `yaml:"exp_labels"`
+	ExpAnnotations map[string]string `yaml:"exp_annotations"`
+}
+
+// LoadRuleTestFile 규칙 테스트 파일 로드
+func LoadRuleTestFile(path string) (*RuleTestFile, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var file RuleTestFile
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("규칙 테스트 파일 파싱 실패: %w", err)
+	}
+	return &file, nil
+}
+
+// RunRuleTests 모든 테스트를 PromQL 엔진으로 평가하고 실패 목록 반환
+func RunRuleTests(t *testing.T, rule *PrometheusRule, file *RuleTestFile) []error {
+	evalInterval := time.Duration(file.EvaluationInterval)
+	if evalInterval == 0 {
+		evalInterval = time.Minute
+	}
+
+	var errs []error
+	for i, test := range file.Tests {
+		name := test.Name
+		if name == "" {
+			name = fmt.Sprintf("tests[%d]", i)
+		}
+		for _, err := range runRuleTest(t, rule, test, evalInterval) {
+			errs = append(errs, fmt.Errorf("%s: %w", name, err))
+		}
+	}
+	return errs
+}
+
+func runRuleTest(t *testing.T, rule *PrometheusRule, test RuleTest, evalInterval time.Duration) []error {
+	alertingRules, err := newAlertingRules(rule)
+	if err != nil {
+		return []error{err}
+	}
+
+	suite, err := promql.NewLazyLoader(t, seriesLoadingString(test), promql.LazyLoaderOpts{
+		EnableAtModifier:     true,
+		EnableNegativeOffset: true,
+	})
+	if err != nil {
+		return []error{fmt.Errorf("입력 시계열 파싱 실패: %w", err)}
+	}
+	defer suite.Close()
+	suite.SubqueryInterval = evalInterval
+
+	queryFunc := rules.EngineQueryFunc(suite.QueryEngine(), suite.Storage())
+
+	casesByTime := make(map[time.Duration][]AlertTestCase)
+	var maxEvalTime time.Duration
+	for _, testCase := range test.AlertRuleTests {
+		if _, ok := alertingRules[testCase.Alertname]; !ok {
+			return []error{fmt.Errorf("알림 규칙 %q가 없습니다", testCase.Alertname)}
+		}
+		evalTime := time.Duration(testCase.EvalTime)
+		casesByTime[evalTime] = append(casesByTime[evalTime], testCase)
+		if evalTime > maxEvalTime {
+			maxEvalTime = evalTime
+		}
+	}
+
+	var errs []error
+	start := time.Unix(0, 0).UTC()
+	for ts := time.Duration(0); ts <= maxEvalTime; ts += evalInterval {
+		evalAt := start.Add(ts)
+		suite.WithSamplesTill(evalAt, func(err error) {
+			if err != nil {
+				errs = append(errs, err)
+				return
+			}
+			for _, alertingRule := range alertingRules {
+				if _, err := alertingRule.Eval(suite.Context(), evalAt, queryFunc, nil, 0); err != nil {
+					errs = append(errs, fmt.Errorf("%s 평가 실패: %w", alertingRule.Name(), err))
+				}
+			}
+		})
+		if len(errs) > 0 {
+			return errs
+		}
+
+		// 평가 간격 사이에 놓인 eval_time은 직전 평가 결과로 판단
+		for caseTime, testCases := range casesByTime {
+			if caseTime < ts || caseTime >= ts+evalInterval {
+				continue
+			}
+			for _, testCase := range testCases {
+				if err := compareAlerts(alertingRules[testCase.Alertname], testCase); err != nil {
+					errs = append(errs, err)
+				}
+			}
+		}
+	}
+
+	return errs
+}
+
+func newAlertingRules(rule *PrometheusRule) (map[string]*rules.AlertingRule, error) {
+	alertingRules := make(map[string]*rules.AlertingRule)
+	for _, r := range rule.AlertingRules() {
+		expr, err := parser.ParseExpr(r.Expr)
+		if err != nil {
+			return nil, fmt.Errorf("%s PromQL 파싱 실패: %w", r.Alert, err)
+		}
+		var hold model.Duration
+		if r.For != "" {
+			if hold, err = model.ParseDuration(r.For); err != nil {
+				return nil, fmt.Errorf("%s for 형식 오류: %w", r.Alert, err)
+			}
+		}
+		alertingRules[r.Alert] = rules.NewAlertingRule(
+			r.Alert, expr, time.Duration(hold), 0,
+			labels.FromMap(r.Labels), labels.FromMap(r.Annotations), labels.EmptyLabels(),
+			"", true, log.NewNopLogger(),
+		)
+	}
+	return alertingRules, nil
+}
+
+func compareAlerts(alertingRule *rules.AlertingRule, testCase AlertTestCase) error {
+	var actual []string
+	annotationsByLabels := make(map[string]labels.Labels)
+	for _, alert := range alertingRule.ActiveAlerts() {
+		if alert.State != rules.StateFiring {
+			continue
+		}
+		key := labels.NewBuilder(alert.Labels).Del(labels.AlertName).Labels().String()
+		actual = append(actual, key)
+		annotationsByLabels[key] = alert.Annotations
+	}
+
+	var expected []string
+	for _, expectedAlert := range testCase.ExpAlerts {
+		expected = append(expected, labels.FromMap(expectedAlert.ExpLabels).String())
+	}
+
+	sort.Strings(actual)
+	sort.Strings(expected)
+	if strings.Join(actual, "\n") != strings.Join(expected, "\n") {
+		return fmt.Errorf("eval_time=%s alertname=%s\n  기대: %v\n  실제: %v", testCase.EvalTime, testCase.Alertname, expected, actual)
+	}
+
+	for _, expectedAlert := range testCase.ExpAlerts {
+		key := labels.FromMap(expectedAlert.ExpLabels).String()
+		annotations := annotationsByLabels[key]
+		for name, value := range expectedAlert.ExpAnnotations {
+			if got := annotations.Get(name); got != value {
+				return fmt.Errorf("eval_time=%s alertname=%s %s 어노테이션 %q\n  기대: %q\n  실제: %q", testCase.EvalTime, testCase.Alertname, key, name, value, got)
+			}
+		}
+	}
+
+	return nil
+}
+
+func seriesLoadingString(test RuleTest) string {
+	interval := time.Duration(test.Interval)
+	if interval == 0 {
+		interval = time.Minute
+	}
+
+	var builder strings.Builder
+	fmt.Fprintf(&builder, "load %s\n", model.Duration(interval))
+	for _, series := range test.InputSeries {
+		fmt.Fprintf(&builder, "  %s %s\n", series.Series, series.Values)
+	}
+	return builder.String()
+}
@@ -0,0 +1,33 @@
+package allowlist
+
+import (
+	"sort"
+	"testing"
+)
+
+// Check 검출된 항목을 알려진 항목 목록(키 → 유지 사유)과 비교
+// found는 항목 키 → 보고할 설명이며, 목록에 없는 새 항목과 더 이상 검출되지 않는 알려진 항목을 모두 실패로 보고합니다.
+// listName은 해결된 항목을 지울 변수 이름입니다.
+func Check(t testing.TB, listName string, known, found map[string]string) {
+	t.Helper()
+
+	for _, key := range sortedKeys(found) {
+		if _, ok := known[key]; !ok {
+			t.Errorf("새로운 항목: %s", found[key])
+		}
+	}
+	for _, key := range sortedKeys(known) {
+		if _, ok := found[key]; !ok {
+			t.Errorf("%s 항목이 해결되었습니다. %s에서 제거하세요", key, listName)
+		}
+	}
+}
+
+func sortedKeys(values map[string]string) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,245 @@
+package helpers
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/credentials/stscreds"
+	"github.com/gruntwork-io/terratest/modules/terraform"
+)
+
+const (
+	// EnvAssumeRoleARN 테스트가 위임받을 역할 ARN (예: modules/iam의 terraform_execution_role)
+	EnvAssumeRoleARN = "TEST_ASSUME_ROLE_ARN"
+	// EnvAssumeRoleExternalID 역할 신뢰 정책의 sts:ExternalId
+	EnvAssumeRoleExternalID = "TEST_ASSUME_ROLE_EXTERNAL_ID"
+
+	// sessionNamePrefix CloudTrail에서 테스트 세션을 찾기 위한 접두사
+	sessionNamePrefix = "terratest-"
+	// maxSessionNameLength STS RoleSessionName 최대 길이
+	maxSessionNameLength = 64
+	// roleProfileDir Terraform provider용 역할 프로필 설정 파일 디렉터리 (os.TempDir 아래, 세션 이름별 파일)
+	roleProfileDir = "terratest-aws"
+)
+
+// STS가 RoleSessionName에 허용하는 문자 외
+var sessionNameInvalid = regexp.MustCompile(`[^\w+=,.@-]`)
+
+// AssumeRoleConfig 테스트 세션이 위임받을 역할
+type AssumeRoleConfig struct {
+	RoleARN    string
+	ExternalID string
+	// SessionName 고유 테스트 ID로 만든 세션 이름 (CloudTrail에서 테스트 실행 추적용)
+	SessionName string
+	// Duration 세션 하나의 유효 시간 (역할 체이닝이면 최대 1시간)
+	Duration time.Duration
+	// MinValidity SDK 호출에 쓸 때 남아 있어야 하는 최소 유효 시간 (남은 시간이 이보다 짧으면 먼저 세션을 갱신)
+	//
+	// Terraform provider는 세션 자격 증명 대신 역할 프로필을 받아 스스로 갱신하므로 이 값과 관계없습니다.
+	MinValidity time.Duration
+}
+
+// NewAssumeRoleConfig 새로운 역할 위임 설정 생성
+func NewAssumeRoleConfig(roleARN, externalID, uniqueID string) *AssumeRoleConfig {
+	return &AssumeRoleConfig{
+		RoleARN:     roleARN,
+		ExternalID:  externalID,
+		SessionName: RoleSessionName(uniqueID),
+		Duration:    time.Hour,
+		MinValidity: 20 * time.Minute,
+	}
+}
+
+// AssumeRoleConfigFromEnv TEST_ASSUME_ROLE_ARN이 있으면 역할 위임 설정 생성 (없으면 nil)
+func AssumeRoleConfigFromEnv(uniqueID string) *AssumeRoleConfig {
+	roleARN := os.Getenv(EnvAssumeRoleARN)
+	if roleARN == "" {
+		return nil
+	}
+	return NewAssumeRoleConfig(roleARN, os.Getenv(EnvAssumeRoleExternalID), uniqueID)
+}
+
+// RoleSessionName 고유 테스트 ID로 STS 세션 이름 생성
+func RoleSessionName(uniqueID string) string {
+	name := sessionNamePrefix + sessionNameInvalid.ReplaceAllString(uniqueID, "-")
+	if len(name) > maxSessionNameLength {
+		name = name[:maxSessionNameLength]
+	}
+	return name
+}
+
+// NewRoleCredentials 역할 세션 자격 증명 생성 (MinValidity만큼 남으면 만료로 보고 다시 위임)
+func NewRoleCredentials(client stscreds.AssumeRoler, role *AssumeRoleConfig) *credentials.Credentials {
+	return stscreds.NewCredentialsWithClient(client, role.RoleARN, func(provider *stscreds.AssumeRoleProvider) {
+		provider.RoleSessionName = role.SessionName
+		provider.Duration = role.Duration
+		provider.ExpiryWindow = role.MinValidity
+		if role.ExternalID != "" {
+			provider.ExternalID = aws.String(role.ExternalID)
+		}
+	})
+}
+
+// TerraformEnvVars Terraform provider가 같은 역할을 직접 위임받도록 넘길 환경 변수 (역할을 쓰지 않으면 nil)
+//
+// 세션 자격 증명을 넘기면 명령 하나가 세션 시간(역할 체이닝이면 최대 1시간)을 넘길 때 만료되므로,
+// role_arn 프로필을 담은 AWS_CONFIG_FILE을 넘겨 provider가 apply 도중에도 세션을 스스로 갱신하게 합니다.
+// 위임할 수 있는지는 먼저 SDK 세션으로 확인합니다.
+func (c *AWSTestClient) TerraformEnvVars() (map[string]string, error) {
+	if c.Credentials == nil {
+		return nil, nil
+	}
+	if _, err := c.Credentials.Get(); err != nil {
+		return nil, fmt.Errorf("역할 %s 위임 실패: %w", c.Role.RoleARN, err)
+	}
+	path, err := writeRoleProfile(c.Role, roleProfilePath(c.Role))
+	if err != nil {
+		return nil, fmt.Errorf("역할 프로필 기록 실패: %w", err)
+	}
+	return map[string]string{
+		"AWS_CONFIG_FILE": path,
+		"AWS_PROFILE":     c.Role.SessionName,
+	}, nil
+}
+
+// RefreshTerraformCredentials options.EnvVars에 역할 프로필을 설정하고 역할을 위임할 수 있는지 확인
+//
+// provider가 세션을 스스로 갱신하므로 한 번만 불러도 되지만, destroy처럼 시간이 지난 뒤 실행하는 명령
+// 앞에서 다시 부르면 위임 실패를 먼저 알 수 있습니다. 역할을 쓰지 않는 클라이언트면 아무것도 하지 않습니다.
+func (c *AWSTestClient) RefreshTerraformCredentials(options *terraform.Options) error {
+	envVars, err := c.TerraformEnvVars()
+	if err != nil || envVars == nil {
+		return err
+	}
+	if options.EnvVars == nil {
+		options.EnvVars = make(map[string]string, len(envVars))
+	}
+	for key, value := range envVars {
+		options.EnvVars[key] = value
+	}
+	return nil
+}
+
+// RemoveRoleProfile TerraformEnvVars가 기록한 역할 프로필 파일 삭제 (역할을 쓰지 않거나 파일이 없으면 무시)
+func (c *AWSTestClient) RemoveRoleProfile() error {
+	if c.Role == nil {
+		return nil
+	}
+	if err := os.Remove(roleProfilePath(c.Role)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("역할 프로필 삭제 실패: %w", err)
+	}
+	return nil
+}
+
+// roleProfilePath 세션 이름별 역할 프로필 파일 경로
+func roleProfilePath(role *AssumeRoleConfig) string {
+	return filepath.Join(os.TempDir(), roleProfileDir, role.SessionName+".config")
+}
+
+// writeRoleProfile 기존 공유 설정에 역할 프로필을 더한 설정 파일을 path에 기록
+//
+// 기존 설정을 함께 담아 source_profile로 지정한 주변 프로필을 찾을 수 있게 합니다.
+func writeRoleProfile(role *AssumeRoleConfig, path string) (string, error) {
+	var b strings.Builder
+	if base, err := os.ReadFile(sharedConfigFile()); err == nil {
+		b.Write(base)
+		b.WriteString("\n")
+	}
+	source, sourceSection := roleSourceCredentials(role)
+	if sourceSection != "" {
+		b.WriteString(sourceSection)
+		b.WriteString("\n")
+	}
+	fmt.Fprintf(&b, "[profile %s]\n", role.SessionName)
+	fmt.Fprintf(&b, "role_arn = %s\n", role.RoleARN)
+	fmt.Fprintf(&b, "role_session_name = %s\n", role.SessionName)
+	fmt.Fprintf(&b, "duration_seconds = %d\n", int64(role.Duration/time.Second))
+	if role.ExternalID != "" {
+		fmt.Fprintf(&b, "external_id = %s\n", role.ExternalID)
+	}
+	b.WriteString(source)
+
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return "", err
+	}
+	// 같은 세션으로 병렬 테스트가 다시 써도 Terraform이 반쯤 쓴 파일을 읽지 않도록 원자적으로 교체
+	tmp, err := os.CreateTemp(dir, role.SessionName+".*.tmp")
+	if err != nil {
+		return "", err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.WriteString(b.String()); err != nil {
+		tmp.Close()
+		return "", err
+	}
+	if err := tmp.Close(); err != nil {
+		return "", err
+	}
+	return path, os.Rename(tmp.Name(), path)
+}
+
+// roleSourceCredentials 역할 프로필이 원래 자격 증명을 얻는 설정과, 필요하면 함께 기록할 원본 프로필
+//
+// 주변 SDK 세션이 자격 증명을 찾는 순서를 따릅니다: 환경 변수 키, AWS_PROFILE, 웹 ID 토큰(EKS IRSA 등),
+// ECS 컨테이너 자격 증명, 공유 파일의 default 프로필, 그 밖에는 EC2 인스턴스 프로필.
+func roleSourceCredentials(role *AssumeRoleConfig) (string, string) {
+	switch {
+	case os.Getenv("AWS_ACCESS_KEY_ID") != "":
+		return "credential_source = Environment\n", ""
+	case os.Getenv("AWS_PROFILE") != "":
+		return fmt.Sprintf("source_profile = %s\n", os.Getenv("AWS_PROFILE")), ""
+	case os.Getenv("AWS_WEB_IDENTITY_TOKEN_FILE") != "" && os.Getenv("AWS_ROLE_ARN") != "":
+		name := role.SessionName + "-source"
+		sessionName := os.Getenv("AWS_ROLE_SESSION_NAME")
+		if sessionName == "" {
+			sessionName = role.SessionName
+		}
+		section := fmt.Sprintf("[profile %s]\nrole_arn = %s\nweb_identity_token_file = %s\nrole_session_name = %s\n",
+			name, os.Getenv("AWS_ROLE_ARN"), os.Getenv("AWS_WEB_IDENTITY_TOKEN_FILE"), sessionName)
+		return fmt.Sprintf("source_profile = %s\n", name), section
+	case os.Getenv("AWS_CONTAINER_CREDENTIALS_RELATIVE_URI") != "" || os.Getenv("AWS_CONTAINER_CREDENTIALS_FULL_URI") != "":
+		return "credential_source = EcsContainer\n", ""
+	case hasDefaultProfile():
+		return "source_profile = default\n", ""
+	}
+	return "credential_source = Ec2InstanceMetadata\n", ""
+}
+
+// 공유 설정/자격 증명 파일의 default 프로필 섹션
+var defaultProfileSection = regexp.MustCompile(`(?m)^\s*\[\s*(?:profile\s+)?default\s*\]`)
+
+// hasDefaultProfile 공유 설정이나 자격 증명 파일에 default 프로필이 있는지
+func hasDefaultProfile() bool {
+	credentialsFile := os.Getenv("AWS_SHARED_CREDENTIALS_FILE")
+	if credentialsFile == "" {
+		if home, err := os.UserHomeDir(); err == nil {
+			credentialsFile = filepath.Join(home, ".aws", "credentials")
+		}
+	}
+	for _, path := range []string{sharedConfigFile(), credentialsFile} {
+		if data, err := os.ReadFile(path); err == nil && defaultProfileSection.Match(data) {
+			return true
+		}
+	}
+	return false
+}
+
+// sharedConfigFile 주변 자격 증명이 쓰는 공유 설정 파일 (AWS_CONFIG_FILE, 없으면 ~/.aws/config)
+func sharedConfigFile() string {
+	if path := os.Getenv("AWS_CONFIG_FILE"); path != "" {
+		return path
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".aws", "config")
+}
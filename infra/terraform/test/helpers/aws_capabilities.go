@@ -0,0 +1,219 @@
+package helpers
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/iam"
+	"github.com/aws/aws-sdk-go/service/iam/iamiface"
+	"github.com/aws/aws-sdk-go/service/sts"
+	"github.com/aws/aws-sdk-go/service/sts/stsiface"
+)
+
+// Capability 현재 자격 증명으로 만들고 지울 수 있는 기능 단위
+type Capability string
+
+const (
+	CapabilityCloudWatch  Capability = "cloudwatch"
+	CapabilityLogs        Capability = "logs"
+	CapabilityCloudTrail  Capability = "cloudtrail"
+	CapabilityBackup      Capability = "backup"
+	CapabilityLambda      Capability = "lambda"
+	CapabilityEventBridge Capability = "eventbridge"
+)
+
+// capabilityActions 기능별로 리소스 생성부터 destroy까지 필요한 액션
+//
+// CloudTrail과 Lambda는 modules/kms가 함께 만드는 S3 버킷과 서비스 역할 권한까지 포함합니다.
+var capabilityActions = map[Capability][]string{
+	CapabilityCloudWatch: {
+		"cloudwatch:PutMetricAlarm", "cloudwatch:DescribeAlarms", "cloudwatch:DeleteAlarms",
+	},
+	CapabilityLogs: {
+		"logs:CreateLogGroup", "logs:PutRetentionPolicy", "logs:DescribeLogGroups", "logs:DeleteLogGroup",
+	},
+	CapabilityCloudTrail: {
+		"cloudtrail:CreateTrail", "cloudtrail:StartLogging", "cloudtrail:DescribeTrails", "cloudtrail:DeleteTrail",
+		"s3:CreateBucket", "s3:PutBucketPolicy", "s3:PutBucketVersioning", "s3:PutEncryptionConfiguration",
+		"s3:PutBucketPublicAccessBlock", "s3:DeleteBucket",
+		"iam:CreateRole", "iam:PutRolePolicy", "iam:PassRole", "iam:DeleteRole",
+	},
+	CapabilityBackup: {
+		"backup:CreateBackupVault", "backup:CreateBackupPlan", "backup:DescribeBackupVault",
+		"backup:DeleteBackupPlan", "backup:DeleteBackupVault",
+	},
+	CapabilityLambda: {
+		"lambda:CreateFunction", "lambda:AddPermission", "lambda:GetFunction", "lambda:DeleteFunction",
+		"iam:CreateRole", "iam:PutRolePolicy", "iam:PassRole", "iam:DeleteRole",
+	},
+	CapabilityEventBridge: {
+		"events:PutRule", "events:PutTargets", "events:DescribeRule", "events:RemoveTargets", "events:DeleteRule",
+	},
+}
+
+// Capabilities 점검 대상 기능 전체 (정렬됨)
+func Capabilities() []Capability {
+	capabilities := make([]Capability, 0, len(capabilityActions))
+	for capability := range capabilityActions {
+		capabilities = append(capabilities, capability)
+	}
+	sort.Slice(capabilities, func(i, j int) bool { return capabilities[i] < capabilities[j] })
+	return capabilities
+}
+
+// CapabilitySet 권한 점검 결과
+type CapabilitySet struct {
+	// CallerARN GetCallerIdentity가 돌려준 ARN
+	CallerARN string
+	// PrincipalARN 권한 시뮬레이션에 쓴 IAM 주체 (역할 세션이면 역할 ARN)
+	PrincipalARN string
+	// reasons 사용할 수 없는 기능 → 사유 (없으면 사용 가능)
+	reasons map[Capability]string
+}
+
+// Has 기능을 모두 사용할 수 있는지
+func (s *CapabilitySet) Has(capabilities ...Capability) bool {
+	return s.Reason(capabilities...) == ""
+}
+
+// Reason 사용할 수 없는 기능의 사유 (모두 사용 가능하면 빈 문자열)
+func (s *CapabilitySet) Reason(capabilities ...Capability) string {
+	var reasons []string
+	for _, capability := range capabilities {
+		if reason, ok := s.reasons[capability]; ok {
+			reasons = append(reasons, fmt.Sprintf("%s: %s", capability, reason))
+		}
+	}
+	return strings.Join(reasons, "; ")
+}
+
+// Require 기능을 하나라도 사용할 수 없으면 사유와 함께 테스트 건너뛰기
+func (s *CapabilitySet) Require(t testing.TB, capabilities ...Capability) {
+	if reason := s.Reason(capabilities...); reason != "" {
+		t.Helper()
+		t.Skipf("%s 권한 부족으로 건너뜀 - %s", s.CallerARN, reason)
+	}
+}
+
+// ProbeCapabilities 현재 자격 증명으로 사용할 수 있는 기능 점검
+func (c *AWSTestClient) ProbeCapabilities() (*CapabilitySet, error) {
+	return ProbeCapabilities(c.STS, c.IAM)
+}
+
+// ProbeCapabilities 호출자를 확인하고 기능별 필요 액션을 iam:SimulatePrincipalPolicy로 점검
+//
+// 시뮬레이션은 호출자의 자격 증명 기반 정책만 평가하므로 세션 정책과 SCP는 반영되지 않습니다.
+// 루트 계정은 모든 기능을 허용하고, 시뮬레이션할 수 없는 주체(페더레이션 사용자)나 시뮬레이션 권한이
+// 없으면 모든 기능을 사유와 함께 사용 불가로 표시합니다. 호출자 확인 자체가 실패할 때만 오류를 돌려줍니다.
+func ProbeCapabilities(stsClient stsiface.STSAPI, iamClient iamiface.IAMAPI) (*CapabilitySet, error) {
+	identity, err := stsClient.GetCallerIdentity(&sts.GetCallerIdentityInput{})
+	if err != nil {
+		return nil, fmt.Errorf("호출자 확인 실패: %w", err)
+	}
+	set := &CapabilitySet{
+		CallerARN: aws.StringValue(identity.Arn),
+		reasons:   make(map[Capability]string),
+	}
+
+	if strings.HasSuffix(set.CallerARN, ":root") {
+		set.PrincipalARN = set.CallerARN
+		return set, nil
+	}
+	set.PrincipalARN, err = simulationPrincipal(iamClient, set.CallerARN)
+	if err != nil {
+		set.denyAll(err.Error())
+		return set, nil
+	}
+
+	decisions, err := simulateActions(iamClient, set.PrincipalARN, requiredActions())
+	if err != nil {
+		set.denyAll(fmt.Sprintf("권한 시뮬레이션 실패: %v", err))
+		return set, nil
+	}
+	for _, capability := range Capabilities() {
+		var denied []string
+		for _, action := range capabilityActions[capability] {
+			if decision := decisions[action]; decision != iam.PolicyEvaluationDecisionTypeAllowed {
+				denied = append(denied, fmt.Sprintf("%s(%s)", action, decisionOrUnknown(decision)))
+			}
+		}
+		if len(denied) > 0 {
+			set.reasons[capability] = "허용되지 않은 액션 " + strings.Join(denied, ", ")
+		}
+	}
+	return set, nil
+}
+
+func (s *CapabilitySet) denyAll(reason string) {
+	for capability := range capabilityActions {
+		s.reasons[capability] = reason
+	}
+}
+
+// simulationPrincipal 호출자 ARN을 시뮬레이션할 수 있는 IAM 주체 ARN으로 변환
+//
+// 역할 세션(arn:aws:sts::<계정>:assumed-role/<역할>/<세션>)은 경로가 포함된 역할 ARN을 조회하고,
+// 조회 권한이 없으면 경로 없는 역할 ARN을 씁니다.
+func simulationPrincipal(iamClient iamiface.IAMAPI, callerARN string) (string, error) {
+	parts := strings.SplitN(callerARN, ":", 6)
+	if len(parts) != 6 {
+		return "", fmt.Errorf("호출자 ARN 형식이 아닙니다: %s", callerARN)
+	}
+	resource := parts[5]
+	switch {
+	case parts[2] == "iam" && strings.HasPrefix(resource, "user/"):
+		return callerARN, nil
+	case parts[2] == "sts" && strings.HasPrefix(resource, "assumed-role/"):
+		roleName := strings.SplitN(strings.TrimPrefix(resource, "assumed-role/"), "/", 2)[0]
+		role, err := iamClient.GetRole(&iam.GetRoleInput{RoleName: aws.String(roleName)})
+		if err == nil && role.Role != nil {
+			return aws.StringValue(role.Role.Arn), nil
+		}
+		return fmt.Sprintf("arn:%s:iam::%s:role/%s", parts[1], parts[4], roleName), nil
+	default:
+		return "", fmt.Errorf("권한을 시뮬레이션할 수 없는 주체입니다: %s", callerARN)
+	}
+}
+
+// requiredActions 모든 기능의 필요 액션 (중복 제거, 정렬됨)
+func requiredActions() []string {
+	seen := make(map[string]bool)
+	var actions []string
+	for _, capability := range Capabilities() {
+		for _, action := range capabilityActions[capability] {
+			if !seen[action] {
+				seen[action] = true
+				actions = append(actions, action)
+			}
+		}
+	}
+	sort.Strings(actions)
+	return actions
+}
+
+func simulateActions(iamClient iamiface.IAMAPI, principalARN string, actions []string) (map[string]string, error) {
+	decisions := make(map[string]string, len(actions))
+	err := iamClient.SimulatePrincipalPolicyPages(&iam.SimulatePrincipalPolicyInput{
+		PolicySourceArn: aws.String(principalARN),
+		ActionNames:     aws.StringSlice(actions),
+	}, func(page *iam.SimulatePolicyResponse, lastPage bool) bool {
+		for _, result := range page.EvaluationResults {
+			decisions[aws.StringValue(result.EvalActionName)] = aws.StringValue(result.EvalDecision)
+		}
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	return decisions, nil
+}
+
+func decisionOrUnknown(decision string) string {
+	if decision == "" {
+		return "결과 없음"
+	}
+	return decision
+}
@@ -3,14 +3,17 @@ package helpers
 import (
 	"testing"
 
-	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/cloudtrail"
 	"github.com/aws/aws-sdk-go/service/cloudwatch"
 	"github.com/aws/aws-sdk-go/service/cloudwatchlogs"
 	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/iam"
 	"github.com/aws/aws-sdk-go/service/kms"
 	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/ssm"
+	"github.com/aws/aws-sdk-go/service/sts"
 )
 
 // AWSTestClient AWS 테스트에 사용되는 클라이언트 구조체
@@ -18,25 +21,76 @@ type AWSTestClient struct {
 	Region         string
 	KMS            *kms.KMS
 	EC2            *ec2.EC2
+	IAM            *iam.IAM
 	CloudWatch     *cloudwatch.CloudWatch
 	CloudWatchLogs *cloudwatchlogs.CloudWatchLogs
 	CloudTrail     *cloudtrail.CloudTrail
 	S3             *s3.S3
+	SSM            *ssm.SSM
+	STS            *sts.STS
+	// Role 위임받은 역할 (주변 자격 증명을 그대로 쓰면 nil)
+	Role *AssumeRoleConfig
+	// Credentials SDK 호출과 Terraform provider가 함께 쓰는 역할 세션 자격 증명 (Role이 nil이면 nil)
+	Credentials *credentials.Credentials
 }
 
 // NewAWSTestClient 새로운 AWS 테스트 클라이언트 생성
+//
+// 모든 클라이언트는 프로세스 전체가 공유하는 DefaultRateGovernor와 스로틀링 백오프 재시도를 사용하므로
+// 병렬 테스트가 같은 서비스를 동시에 호출해도 계정 한도를 넘지 않습니다.
 func NewAWSTestClient(t *testing.T, region string) *AWSTestClient {
-	sess := session.Must(session.NewSession(&aws.Config{
-		Region: aws.String(region),
-	}))
+	return newAWSTestClient(region, newGovernedSession(region, nil))
+}
+
+// NewAWSTestClientWithRole 역할을 위임받은 세션으로 AWS 테스트 클라이언트 생성
+//
+// role이 nil이면 NewAWSTestClient와 같습니다. 역할 세션은 만료 전에 자동으로 갱신됩니다.
+func NewAWSTestClientWithRole(t *testing.T, region string, role *AssumeRoleConfig) *AWSTestClient {
+	if role == nil {
+		return NewAWSTestClient(t, region)
+	}
+	client := newRoleClient(region, role)
+	t.Logf("🔑 역할 위임: %s (세션 %s)", role.RoleARN, role.SessionName)
+	// 먼저 등록하므로 같은 테스트에서 등록한 destroy가 모두 끝난 뒤에 지워집니다
+	t.Cleanup(func() {
+		if err := client.RemoveRoleProfile(); err != nil {
+			t.Errorf("%v", err)
+		}
+	})
+	return client
+}
+
+// newRoleClient 역할 세션 클라이언트 (*testing.T가 없는 저널 정리에서도 사용)
+func newRoleClient(region string, role *AssumeRoleConfig) *AWSTestClient {
+	creds := NewRoleCredentials(sts.New(newGovernedSession(region, nil)), role)
+	client := newAWSTestClient(region, newGovernedSession(region, creds))
+	client.Role = role
+	client.Credentials = creds
+	return client
+}
+
+// newGovernedSession 속도 조절과 재시도 정책을 연결한 세션 (creds가 nil이면 주변 자격 증명)
+func newGovernedSession(region string, creds *credentials.Credentials) *session.Session {
+	config := governedConfig(region)
+	if creds != nil {
+		config.Credentials = creds
+	}
+	sess := session.Must(session.NewSession(config))
+	DefaultRateGovernor().Attach(&sess.Handlers)
+	return sess
+}
 
+func newAWSTestClient(region string, sess *session.Session) *AWSTestClient {
 	return &AWSTestClient{
 		Region:         region,
 		KMS:            kms.New(sess),
 		EC2:            ec2.New(sess),
+		IAM:            iam.New(sess),
 		CloudWatch:     cloudwatch.New(sess),
 		CloudWatchLogs: cloudwatchlogs.New(sess),
 		CloudTrail:     cloudtrail.New(sess),
 		S3:             s3.New(sess),
+		SSM:            ssm.New(sess),
+		STS:            sts.New(sess),
 	}
 }
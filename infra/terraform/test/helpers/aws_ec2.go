@@ -55,3 +55,65 @@ func (c *AWSTestClient) ValidateSecurityGroup(groupID string) (*ec2.SecurityGrou
 
 	return nil, nil
 }
+
+// ValidateSubnet 서브넷 검증
+func (c *AWSTestClient) ValidateSubnet(subnetID string) (*ec2.Subnet, error) {
+	input := &ec2.DescribeSubnetsInput{
+		SubnetIds: []*string{aws.String(subnetID)},
+	}
+
+	result, err := c.EC2.DescribeSubnets(input)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(result.Subnets) > 0 {
+		return result.Subnets[0], nil
+	}
+
+	return nil, nil
+}
+
+// FindEC2InstancesByTags 태그가 모두 일치하는 종료되지 않은 인스턴스 조회
+func (c *AWSTestClient) FindEC2InstancesByTags(tags map[string]string) ([]*ec2.Instance, error) {
+	filters := []*ec2.Filter{{
+		Name:   aws.String("instance-state-name"),
+		Values: aws.StringSlice([]string{"pending", "running", "stopping", "stopped"}),
+	}}
+	for key, value := range tags {
+		filters = append(filters, &ec2.Filter{
+			Name:   aws.String("tag:" + key),
+			Values: []*string{aws.String(value)},
+		})
+	}
+
+	var instances []*ec2.Instance
+	err := c.EC2.DescribeInstancesPages(&ec2.DescribeInstancesInput{Filters: filters}, func(page *ec2.DescribeInstancesOutput, lastPage bool) bool {
+		for _, reservation := range page.Reservations {
+			instances = append(instances, reservation.Instances...)
+		}
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return instances, nil
+}
+
+// ImportEC2KeyPair 공개 키를 EC2 키 페어로 등록 (클라이언트의 자격 증명 사용)
+func (c *AWSTestClient) ImportEC2KeyPair(name, publicKey string) error {
+	_, err := c.EC2.ImportKeyPair(&ec2.ImportKeyPairInput{
+		KeyName:           aws.String(name),
+		PublicKeyMaterial: []byte(publicKey),
+	})
+	return err
+}
+
+// DeleteEC2KeyPair EC2 키 페어 삭제
+func (c *AWSTestClient) DeleteEC2KeyPair(name string) error {
+	_, err := c.EC2.DeleteKeyPair(&ec2.DeleteKeyPairInput{
+		KeyName: aws.String(name),
+	})
+	return err
+}
@@ -0,0 +1,107 @@
+package helpers
+
+import (
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/iam"
+	"github.com/aws/aws-sdk-go/service/iam/iamiface"
+	"github.com/k8s-ec2-observability/test/helpers/iampolicy"
+)
+
+// RolePolicies IAM 역할의 신뢰 정책과 권한 정책
+type RolePolicies struct {
+	RoleName string
+	RoleARN  string
+	Trust    *iampolicy.Document
+	// Attached 연결된 관리형 정책 (이름은 정책 ARN)
+	Attached []*iampolicy.Document
+	// Inline 인라인 정책 (이름은 정책 이름)
+	Inline []*iampolicy.Document
+}
+
+// Policies 연결된 정책과 인라인 정책 전체
+func (p *RolePolicies) Policies() []*iampolicy.Document {
+	return append(append([]*iampolicy.Document{}, p.Attached...), p.Inline...)
+}
+
+// Evaluate 역할의 권한 정책으로 요청을 오프라인 평가
+func (p *RolePolicies) Evaluate(action, resource string, context map[string][]string) (iampolicy.Result, error) {
+	return iampolicy.Evaluate(iampolicy.Request{Action: action, Resource: resource, Context: context}, p.Policies()...)
+}
+
+// GetRolePolicies 역할의 신뢰 정책과 권한 정책 조회
+func (c *AWSTestClient) GetRolePolicies(roleName string) (*RolePolicies, error) {
+	return FetchRolePolicies(c.IAM, roleName)
+}
+
+// FetchRolePolicies 역할의 신뢰 정책, 연결된 관리형 정책의 기본 버전, 인라인 정책 조회
+func FetchRolePolicies(client iamiface.IAMAPI, roleName string) (*RolePolicies, error) {
+	role, err := client.GetRole(&iam.GetRoleInput{RoleName: aws.String(roleName)})
+	if err != nil {
+		return nil, err
+	}
+	policies := &RolePolicies{
+		RoleName: roleName,
+		RoleARN:  aws.StringValue(role.Role.Arn),
+	}
+	policies.Trust, err = iampolicy.ParseURLEncoded(policies.RoleARN, aws.StringValue(role.Role.AssumeRolePolicyDocument))
+	if err != nil {
+		return nil, err
+	}
+
+	var attachedARNs []string
+	err = client.ListAttachedRolePoliciesPages(&iam.ListAttachedRolePoliciesInput{RoleName: aws.String(roleName)},
+		func(page *iam.ListAttachedRolePoliciesOutput, lastPage bool) bool {
+			for _, attached := range page.AttachedPolicies {
+				attachedARNs = append(attachedARNs, aws.StringValue(attached.PolicyArn))
+			}
+			return true
+		})
+	if err != nil {
+		return nil, err
+	}
+	for _, arn := range attachedARNs {
+		document, err := fetchManagedPolicy(client, arn)
+		if err != nil {
+			return nil, err
+		}
+		policies.Attached = append(policies.Attached, document)
+	}
+
+	var inlineNames []string
+	err = client.ListRolePoliciesPages(&iam.ListRolePoliciesInput{RoleName: aws.String(roleName)},
+		func(page *iam.ListRolePoliciesOutput, lastPage bool) bool {
+			inlineNames = append(inlineNames, aws.StringValueSlice(page.PolicyNames)...)
+			return true
+		})
+	if err != nil {
+		return nil, err
+	}
+	for _, name := range inlineNames {
+		inline, err := client.GetRolePolicy(&iam.GetRolePolicyInput{RoleName: aws.String(roleName), PolicyName: aws.String(name)})
+		if err != nil {
+			return nil, err
+		}
+		document, err := iampolicy.ParseURLEncoded(name, aws.StringValue(inline.PolicyDocument))
+		if err != nil {
+			return nil, err
+		}
+		policies.Inline = append(policies.Inline, document)
+	}
+
+	return policies, nil
+}
+
+func fetchManagedPolicy(client iamiface.IAMAPI, arn string) (*iampolicy.Document, error) {
+	policy, err := client.GetPolicy(&iam.GetPolicyInput{PolicyArn: aws.String(arn)})
+	if err != nil {
+		return nil, err
+	}
+	version, err := client.GetPolicyVersion(&iam.GetPolicyVersionInput{
+		PolicyArn: aws.String(arn),
+		VersionId: policy.Policy.DefaultVersionId,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return iampolicy.ParseURLEncoded(arn, aws.StringValue(version.PolicyVersion.Document))
+}
@@ -0,0 +1,217 @@
+package helpers
+
+import (
+	"math"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/client"
+	"github.com/aws/aws-sdk-go/aws/request"
+)
+
+// RateLimit 서비스별 초당 요청 수와 버스트
+type RateLimit struct {
+	RequestsPerSecond float64
+	Burst             int
+}
+
+const (
+	// rateGovernorHandler 세션 핸들러 이름 (중복 등록 방지)
+	rateGovernorHandler = "k8sobs.RateGovernor"
+	// minRateFraction 스로틀링이 이어져도 설정 속도의 이 비율 밑으로는 줄이지 않음
+	minRateFraction = 1.0 / 16
+	// recoveryFraction 성공한 요청마다 설정 속도의 이 비율만큼 속도를 회복
+	recoveryFraction = 1.0 / 20
+)
+
+// defaultServiceRateLimits 서비스별 기본 한도 (클라이언트 ServiceName 기준)
+//
+// 계정 한도보다 낮게 잡아 같은 계정을 쓰는 Terraform provider와 다른 CI 작업에 여유를 둡니다.
+var defaultServiceRateLimits = map[string]RateLimit{
+	"kms":        {RequestsPerSecond: 20, Burst: 20},
+	"ec2":        {RequestsPerSecond: 10, Burst: 20},
+	"s3":         {RequestsPerSecond: 20, Burst: 20},
+	"ssm":        {RequestsPerSecond: 5, Burst: 10},
+	"iam":        {RequestsPerSecond: 5, Burst: 5},
+	"sts":        {RequestsPerSecond: 5, Burst: 5},
+	"logs":       {RequestsPerSecond: 5, Burst: 5},
+	"monitoring": {RequestsPerSecond: 5, Burst: 5},
+	"cloudtrail": {RequestsPerSecond: 2, Burst: 2},
+}
+
+var defaultRateLimit = RateLimit{RequestsPerSecond: 5, Burst: 5}
+
+// defaultRetryer 스로틀링은 길게, 그 밖의 일시 오류는 짧게 지수 백오프
+var defaultRetryer = client.DefaultRetryer{
+	NumMaxRetries:    10,
+	MinRetryDelay:    200 * time.Millisecond,
+	MaxRetryDelay:    5 * time.Second,
+	MinThrottleDelay: time.Second,
+	MaxThrottleDelay: 30 * time.Second,
+}
+
+var (
+	defaultGovernorOnce sync.Once
+	defaultGovernor     *RateGovernor
+)
+
+// DefaultRateGovernor 프로세스 전체가 공유하는 속도 조절기
+func DefaultRateGovernor() *RateGovernor {
+	defaultGovernorOnce.Do(func() {
+		defaultGovernor = NewRateGovernor(defaultServiceRateLimits, defaultRateLimit)
+	})
+	return defaultGovernor
+}
+
+// RateGovernor 서비스별 토큰 버킷으로 AWS API 호출 속도를 조절
+//
+// 스로틀링 응답을 받으면 그 서비스의 속도를 절반으로 줄이고, 성공할 때마다 설정 속도까지 조금씩 회복합니다.
+type RateGovernor struct {
+	mu       sync.Mutex
+	limits   map[string]RateLimit
+	fallback RateLimit
+	buckets  map[string]*tokenBucket
+	now      func() time.Time
+}
+
+// NewRateGovernor 새로운 속도 조절기 생성 (limits에 없는 서비스는 fallback 사용)
+func NewRateGovernor(limits map[string]RateLimit, fallback RateLimit) *RateGovernor {
+	return &RateGovernor{
+		limits:   limits,
+		fallback: fallback,
+		buckets:  make(map[string]*tokenBucket),
+		now:      time.Now,
+	}
+}
+
+// Reserve 서비스 요청 하나를 예약하고 보내기 전에 기다려야 할 시간을 돌려줌
+func (g *RateGovernor) Reserve(service string) time.Duration {
+	return g.bucket(service).reserve(g.now())
+}
+
+// Wait 서비스 요청을 보낼 수 있을 때까지 대기 (컨텍스트가 끝나면 오류)
+func (g *RateGovernor) Wait(ctx aws.Context, service string) error {
+	delay := g.Reserve(service)
+	if delay <= 0 {
+		return nil
+	}
+	return aws.SleepWithContext(ctx, delay)
+}
+
+// Throttled 스로틀링 응답을 받은 서비스의 속도를 절반으로 줄임
+func (g *RateGovernor) Throttled(service string) {
+	g.bucket(service).throttled()
+}
+
+// Succeeded 성공한 요청만큼 서비스 속도를 회복
+func (g *RateGovernor) Succeeded(service string) {
+	g.bucket(service).succeeded()
+}
+
+// Rate 서비스의 현재 초당 요청 수
+func (g *RateGovernor) Rate(service string) float64 {
+	return g.bucket(service).currentRate()
+}
+
+// Attach 요청 핸들러에 속도 조절을 연결
+//
+// 서명 직전(재시도 포함 매 시도)에 토큰을 기다리고, 스로틀링 오류와 성공을 버킷에 반영합니다.
+func (g *RateGovernor) Attach(handlers *request.Handlers) {
+	handlers.Sign.PushFrontNamed(request.NamedHandler{
+		Name: rateGovernorHandler,
+		Fn: func(r *request.Request) {
+			if err := g.Wait(r.Context(), r.ClientInfo.ServiceName); err != nil {
+				r.Error = err
+			}
+		},
+	})
+	handlers.Retry.PushFrontNamed(request.NamedHandler{
+		Name: rateGovernorHandler,
+		Fn: func(r *request.Request) {
+			if request.IsErrorThrottle(r.Error) {
+				g.Throttled(r.ClientInfo.ServiceName)
+			}
+		},
+	})
+	handlers.Complete.PushBackNamed(request.NamedHandler{
+		Name: rateGovernorHandler,
+		Fn: func(r *request.Request) {
+			if r.Error == nil {
+				g.Succeeded(r.ClientInfo.ServiceName)
+			}
+		},
+	})
+}
+
+// governedConfig 기본 재시도 정책을 쓰는 세션 설정
+func governedConfig(region string) *aws.Config {
+	return request.WithRetryer(aws.NewConfig().WithRegion(region), defaultRetryer)
+}
+
+func (g *RateGovernor) bucket(service string) *tokenBucket {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	bucket, ok := g.buckets[service]
+	if !ok {
+		limit, ok := g.limits[service]
+		if !ok {
+			limit = g.fallback
+		}
+		bucket = newTokenBucket(limit, g.now())
+		g.buckets[service] = bucket
+	}
+	return bucket
+}
+
+// tokenBucket 예약 방식 토큰 버킷 (토큰이 모자라면 음수로 빌려 쓰고 그만큼 대기)
+type tokenBucket struct {
+	mu     sync.Mutex
+	limit  RateLimit
+	rate   float64
+	tokens float64
+	last   time.Time
+}
+
+func newTokenBucket(limit RateLimit, now time.Time) *tokenBucket {
+	return &tokenBucket{
+		limit:  limit,
+		rate:   limit.RequestsPerSecond,
+		tokens: float64(limit.Burst),
+		last:   now,
+	}
+}
+
+func (b *tokenBucket) reserve(now time.Time) time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if elapsed := now.Sub(b.last).Seconds(); elapsed > 0 {
+		b.tokens = math.Min(float64(b.limit.Burst), b.tokens+elapsed*b.rate)
+		b.last = now
+	}
+	b.tokens--
+	if b.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-b.tokens / b.rate * float64(time.Second))
+}
+
+func (b *tokenBucket) throttled() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.rate = math.Max(b.rate/2, b.limit.RequestsPerSecond*minRateFraction)
+	// 남은 버스트로 곧바로 다시 몰리지 않도록 비움
+	b.tokens = math.Min(b.tokens, 0)
+}
+
+func (b *tokenBucket) succeeded() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.rate = math.Min(b.limit.RequestsPerSecond, b.rate+b.limit.RequestsPerSecond*recoveryFraction)
+}
+
+func (b *tokenBucket) currentRate() float64 {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.rate
+}
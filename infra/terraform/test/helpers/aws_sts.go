@@ -0,0 +1,10 @@
+package helpers
+
+import (
+	"github.com/aws/aws-sdk-go/service/sts"
+)
+
+// GetCallerIdentity 현재 자격 증명의 계정 ID와 ARN 조회
+func (c *AWSTestClient) GetCallerIdentity() (*sts.GetCallerIdentityOutput, error) {
+	return c.STS.GetCallerIdentity(&sts.GetCallerIdentityInput{})
+}
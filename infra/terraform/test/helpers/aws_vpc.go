@@ -0,0 +1,324 @@
+package helpers
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
+)
+
+// DefaultRouteCIDR 기본 경로 대상 CIDR
+const DefaultRouteCIDR = "0.0.0.0/0"
+
+// RouteTargetType 경로 대상 종류
+type RouteTargetType string
+
+const (
+	// RouteTargetNone 경로 없음
+	RouteTargetNone RouteTargetType = ""
+	// RouteTargetLocal VPC 내부 (local)
+	RouteTargetLocal RouteTargetType = "local"
+	// RouteTargetInternetGateway 인터넷 게이트웨이 (igw-)
+	RouteTargetInternetGateway RouteTargetType = "internet-gateway"
+	// RouteTargetNATGateway NAT 게이트웨이 (nat-)
+	RouteTargetNATGateway RouteTargetType = "nat-gateway"
+	// RouteTargetOther 인스턴스, ENI, 피어링, TGW 등 그 밖의 대상
+	RouteTargetOther RouteTargetType = "other"
+)
+
+// RouteTarget 라우트 테이블 항목의 대상
+type RouteTarget struct {
+	Type RouteTargetType
+	ID   string
+	// State active 또는 blackhole
+	State string
+}
+
+// SubnetTopology 서브넷과 연결된 라우트 테이블 정보
+type SubnetTopology struct {
+	ID                  string
+	CIDR                string
+	AvailabilityZone    string
+	MapPublicIPOnLaunch bool
+	RouteTableID        string
+	// MainRouteTable 명시적 연결 없이 VPC의 main 라우트 테이블을 사용하는지
+	MainRouteTable bool
+	DefaultRoute   RouteTarget
+}
+
+// NATGatewayTopology NAT 게이트웨이와 EIP 정보
+type NATGatewayTopology struct {
+	ID           string
+	SubnetID     string
+	State        string
+	AllocationID string
+	PublicIP     string
+}
+
+// VPCTopology VPC의 서브넷, 게이트웨이, 라우팅 구성
+type VPCTopology struct {
+	VPCID string
+	CIDR  string
+	// Subnets 서브넷 ID별 토폴로지
+	Subnets map[string]*SubnetTopology
+	// InternetGatewayID VPC에 연결된 인터넷 게이트웨이 (없으면 빈 값)
+	InternetGatewayID       string
+	InternetGatewayAttached bool
+	// NATGateways 삭제되지 않은 NAT 게이트웨이
+	NATGateways []NATGatewayTopology
+}
+
+// SubnetIDs 서브넷 ID 목록 (정렬)
+func (topology *VPCTopology) SubnetIDs() []string {
+	ids := make([]string, 0, len(topology.Subnets))
+	for id := range topology.Subnets {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// NATGateway ID로 NAT 게이트웨이 조회
+func (topology *VPCTopology) NATGateway(id string) (NATGatewayTopology, bool) {
+	for _, gateway := range topology.NATGateways {
+		if gateway.ID == id {
+			return gateway, true
+		}
+	}
+	return NATGatewayTopology{}, false
+}
+
+// GetVPCTopology VPC 토폴로지 조회
+func (c *AWSTestClient) GetVPCTopology(vpcID string) (*VPCTopology, error) {
+	return DescribeVPCTopology(c.EC2, vpcID)
+}
+
+// DescribeVPCTopology VPC, 서브넷, 인터넷/NAT 게이트웨이, 라우트 테이블을 조회해 토폴로지 구성
+func DescribeVPCTopology(client ec2iface.EC2API, vpcID string) (*VPCTopology, error) {
+	vpcs, err := client.DescribeVpcs(&ec2.DescribeVpcsInput{VpcIds: []*string{aws.String(vpcID)}})
+	if err != nil {
+		return nil, err
+	}
+	if len(vpcs.Vpcs) == 0 {
+		return nil, fmt.Errorf("VPC %s를 찾을 수 없습니다", vpcID)
+	}
+	topology := &VPCTopology{
+		VPCID:   vpcID,
+		CIDR:    aws.StringValue(vpcs.Vpcs[0].CidrBlock),
+		Subnets: make(map[string]*SubnetTopology),
+	}
+	byVPC := []*ec2.Filter{{Name: aws.String("vpc-id"), Values: []*string{aws.String(vpcID)}}}
+
+	subnets, err := client.DescribeSubnets(&ec2.DescribeSubnetsInput{Filters: byVPC})
+	if err != nil {
+		return nil, err
+	}
+	for _, subnet := range subnets.Subnets {
+		topology.Subnets[aws.StringValue(subnet.SubnetId)] = &SubnetTopology{
+			ID:                  aws.StringValue(subnet.SubnetId),
+			CIDR:                aws.StringValue(subnet.CidrBlock),
+			AvailabilityZone:    aws.StringValue(subnet.AvailabilityZone),
+			MapPublicIPOnLaunch: aws.BoolValue(subnet.MapPublicIpOnLaunch),
+		}
+	}
+
+	gateways, err := client.DescribeInternetGateways(&ec2.DescribeInternetGatewaysInput{
+		Filters: []*ec2.Filter{{Name: aws.String("attachment.vpc-id"), Values: []*string{aws.String(vpcID)}}},
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, gateway := range gateways.InternetGateways {
+		for _, attachment := range gateway.Attachments {
+			if aws.StringValue(attachment.VpcId) != vpcID {
+				continue
+			}
+			// 인터넷 게이트웨이는 연결되면 attached가 아니라 available로 표시됨
+			topology.InternetGatewayID = aws.StringValue(gateway.InternetGatewayId)
+			topology.InternetGatewayAttached = aws.StringValue(attachment.State) == ec2.AttachmentStatusAttached ||
+				aws.StringValue(attachment.State) == "available"
+		}
+	}
+
+	natGateways, err := client.DescribeNatGateways(&ec2.DescribeNatGatewaysInput{Filter: byVPC})
+	if err != nil {
+		return nil, err
+	}
+	for _, gateway := range natGateways.NatGateways {
+		state := aws.StringValue(gateway.State)
+		if state == ec2.NatGatewayStateDeleted || state == ec2.NatGatewayStateDeleting {
+			continue
+		}
+		nat := NATGatewayTopology{
+			ID:       aws.StringValue(gateway.NatGatewayId),
+			SubnetID: aws.StringValue(gateway.SubnetId),
+			State:    state,
+		}
+		if len(gateway.NatGatewayAddresses) > 0 {
+			nat.AllocationID = aws.StringValue(gateway.NatGatewayAddresses[0].AllocationId)
+			nat.PublicIP = aws.StringValue(gateway.NatGatewayAddresses[0].PublicIp)
+		}
+		topology.NATGateways = append(topology.NATGateways, nat)
+	}
+
+	routeTables, err := client.DescribeRouteTables(&ec2.DescribeRouteTablesInput{Filters: byVPC})
+	if err != nil {
+		return nil, err
+	}
+	var main *ec2.RouteTable
+	for _, table := range routeTables.RouteTables {
+		for _, association := range table.Associations {
+			if aws.BoolValue(association.Main) {
+				main = table
+				continue
+			}
+			if subnet, ok := topology.Subnets[aws.StringValue(association.SubnetId)]; ok {
+				subnet.RouteTableID = aws.StringValue(table.RouteTableId)
+				subnet.DefaultRoute = defaultRoute(table)
+			}
+		}
+	}
+	for _, subnet := range topology.Subnets {
+		if subnet.RouteTableID == "" && main != nil {
+			subnet.RouteTableID = aws.StringValue(main.RouteTableId)
+			subnet.MainRouteTable = true
+			subnet.DefaultRoute = defaultRoute(main)
+		}
+	}
+
+	return topology, nil
+}
+
+func defaultRoute(table *ec2.RouteTable) RouteTarget {
+	for _, route := range table.Routes {
+		if aws.StringValue(route.DestinationCidrBlock) == DefaultRouteCIDR {
+			return routeTarget(route)
+		}
+	}
+	return RouteTarget{}
+}
+
+func routeTarget(route *ec2.Route) RouteTarget {
+	target := RouteTarget{State: aws.StringValue(route.State)}
+	switch {
+	case aws.StringValue(route.NatGatewayId) != "":
+		target.Type, target.ID = RouteTargetNATGateway, aws.StringValue(route.NatGatewayId)
+	case aws.StringValue(route.GatewayId) == "local":
+		target.Type, target.ID = RouteTargetLocal, "local"
+	case aws.StringValue(route.GatewayId) != "":
+		// GatewayId에는 VGW(vgw-)도 올 수 있음
+		target.Type, target.ID = RouteTargetOther, aws.StringValue(route.GatewayId)
+		if strings.HasPrefix(target.ID, "igw-") {
+			target.Type = RouteTargetInternetGateway
+		}
+	default:
+		target.Type = RouteTargetOther
+		for _, id := range []*string{route.InstanceId, route.NetworkInterfaceId, route.TransitGatewayId, route.VpcPeeringConnectionId} {
+			if aws.StringValue(id) != "" {
+				target.ID = aws.StringValue(id)
+				break
+			}
+		}
+	}
+	return target
+}
+
+// CheckInternetGatewayAttached VPC에 인터넷 게이트웨이가 연결되어 있는지 검사
+func CheckInternetGatewayAttached(topology *VPCTopology) []string {
+	if topology.InternetGatewayID == "" {
+		return []string{fmt.Sprintf("VPC %s에 인터넷 게이트웨이가 없습니다", topology.VPCID)}
+	}
+	if !topology.InternetGatewayAttached {
+		return []string{fmt.Sprintf("인터넷 게이트웨이 %s가 VPC %s에 연결된 상태가 아닙니다", topology.InternetGatewayID, topology.VPCID)}
+	}
+	return nil
+}
+
+// CheckPublicSubnet 퍼블릭 서브넷이 0.0.0.0/0을 VPC의 인터넷 게이트웨이로 보내는지 검사
+func CheckPublicSubnet(topology *VPCTopology, subnetID string) []string {
+	subnet, ok := topology.Subnets[subnetID]
+	if !ok {
+		return []string{fmt.Sprintf("서브넷 %s가 VPC %s에 없습니다", subnetID, topology.VPCID)}
+	}
+	problems := CheckInternetGatewayAttached(topology)
+	route := subnet.DefaultRoute
+	switch {
+	case route.Type != RouteTargetInternetGateway:
+		problems = append(problems, fmt.Sprintf("퍼블릭 서브넷 %s의 %s 경로가 인터넷 게이트웨이가 아닙니다 (%s)", subnetID, DefaultRouteCIDR, describeTarget(route)))
+	case route.ID != topology.InternetGatewayID:
+		problems = append(problems, fmt.Sprintf("퍼블릭 서브넷 %s의 %s 경로가 VPC의 인터넷 게이트웨이 %s가 아닌 %s를 가리킵니다", subnetID, DefaultRouteCIDR, topology.InternetGatewayID, route.ID))
+	case route.State != ec2.RouteStateActive:
+		problems = append(problems, fmt.Sprintf("퍼블릭 서브넷 %s의 %s 경로가 %s 상태입니다", subnetID, DefaultRouteCIDR, route.State))
+	}
+	if !subnet.MapPublicIPOnLaunch {
+		problems = append(problems, fmt.Sprintf("퍼블릭 서브넷 %s에 map_public_ip_on_launch가 꺼져 있습니다", subnetID))
+	}
+	return problems
+}
+
+// CheckPrivateSubnetEgressViaNAT 프라이빗 서브넷이 퍼블릭 서브넷의 NAT 게이트웨이로 외부에 나가는지 검사
+func CheckPrivateSubnetEgressViaNAT(topology *VPCTopology, subnetID string) []string {
+	subnet, ok := topology.Subnets[subnetID]
+	if !ok {
+		return []string{fmt.Sprintf("서브넷 %s가 VPC %s에 없습니다", subnetID, topology.VPCID)}
+	}
+	route := subnet.DefaultRoute
+	if route.Type != RouteTargetNATGateway {
+		return []string{fmt.Sprintf("프라이빗 서브넷 %s의 %s 경로가 NAT 게이트웨이가 아닙니다 (%s)", subnetID, DefaultRouteCIDR, describeTarget(route))}
+	}
+	nat, ok := topology.NATGateway(route.ID)
+	if !ok {
+		return []string{fmt.Sprintf("프라이빗 서브넷 %s의 경로가 VPC에 없는 NAT 게이트웨이 %s를 가리킵니다", subnetID, route.ID)}
+	}
+
+	var problems []string
+	if route.State != ec2.RouteStateActive {
+		problems = append(problems, fmt.Sprintf("프라이빗 서브넷 %s의 %s 경로가 %s 상태입니다", subnetID, DefaultRouteCIDR, route.State))
+	}
+	if nat.State != ec2.NatGatewayStateAvailable {
+		problems = append(problems, fmt.Sprintf("NAT 게이트웨이 %s가 %s 상태입니다", nat.ID, nat.State))
+	}
+	if natSubnet, ok := topology.Subnets[nat.SubnetID]; !ok || natSubnet.DefaultRoute.Type != RouteTargetInternetGateway {
+		problems = append(problems, fmt.Sprintf("NAT 게이트웨이 %s의 서브넷 %s가 인터넷 게이트웨이로 라우팅되지 않습니다", nat.ID, nat.SubnetID))
+	}
+	if nat.PublicIP == "" {
+		problems = append(problems, fmt.Sprintf("NAT 게이트웨이 %s에 EIP가 없습니다", nat.ID))
+	}
+	return problems
+}
+
+// CheckNATGateway enable_nat_gateway에 따라 NAT 게이트웨이 존재 여부 검사
+//
+// 비활성화면 NAT 게이트웨이가 없고 어떤 서브넷도 NAT로 라우팅하지 않아야 합니다.
+func CheckNATGateway(topology *VPCTopology, enabled bool) []string {
+	if enabled {
+		if len(topology.NATGateways) == 0 {
+			return []string{fmt.Sprintf("enable_nat_gateway가 true인데 VPC %s에 NAT 게이트웨이가 없습니다", topology.VPCID)}
+		}
+		return nil
+	}
+
+	var problems []string
+	for _, gateway := range topology.NATGateways {
+		problems = append(problems, fmt.Sprintf("enable_nat_gateway가 false인데 NAT 게이트웨이 %s가 있습니다", gateway.ID))
+	}
+	for _, id := range topology.SubnetIDs() {
+		if route := topology.Subnets[id].DefaultRoute; route.Type == RouteTargetNATGateway {
+			problems = append(problems, fmt.Sprintf("enable_nat_gateway가 false인데 서브넷 %s가 NAT 게이트웨이 %s로 라우팅됩니다", id, route.ID))
+		}
+	}
+	return problems
+}
+
+func describeTarget(route RouteTarget) string {
+	if route.Type == RouteTargetNone {
+		return "경로 없음"
+	}
+	if route.ID == "" {
+		return string(route.Type)
+	}
+	return fmt.Sprintf("%s %s", route.Type, route.ID)
+}
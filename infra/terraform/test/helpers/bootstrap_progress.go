@@ -0,0 +1,333 @@
+package helpers
+
+import (
+	"encoding/base64"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/gruntwork-io/terratest/modules/retry"
+	"github.com/gruntwork-io/terratest/modules/testing"
+)
+
+// BootstrapStep combined_settings.sh / system_settings.sh의 설치 단계
+type BootstrapStep string
+
+const (
+	StepSystemSettings     BootstrapStep = "system_settings"
+	StepVerifyRequirements BootstrapStep = "verify_system_requirements"
+	StepSetupNetwork       BootstrapStep = "setup_network"
+	StepInstallDocker      BootstrapStep = "install_docker"
+	StepInstallKubernetes  BootstrapStep = "install_kubernetes"
+	StepInitializeMaster   BootstrapStep = "initialize_master"
+	StepInstallCNI         BootstrapStep = "install_cni"
+	StepGenerateJoin       BootstrapStep = "generate_join_command"
+)
+
+// MasterBootstrapSteps 마스터 노드에서 완료되어야 하는 단계
+var MasterBootstrapSteps = []BootstrapStep{
+	StepInstallDocker, StepInstallKubernetes, StepInitializeMaster, StepInstallCNI, StepGenerateJoin,
+}
+
+// WorkerBootstrapSteps 워커 노드에서 완료되어야 하는 단계
+var WorkerBootstrapSteps = []BootstrapStep{
+	StepInstallDocker, StepInstallKubernetes,
+}
+
+// BootstrapStepStatus 단계 진행 상태
+type BootstrapStepStatus string
+
+const (
+	StepPending   BootstrapStepStatus = "pending"
+	StepRunning   BootstrapStepStatus = "running"
+	StepCompleted BootstrapStepStatus = "completed"
+	StepFailed    BootstrapStepStatus = "failed"
+)
+
+// 각 단계의 log() 메시지 (스크립트 문구와 정확히 일치해야 함)
+var bootstrapStepMarkers = []struct {
+	step     BootstrapStep
+	start    string
+	done     []string
+	failures []string
+}{
+	{StepSystemSettings, "시스템 기본 설정 시작", []string{"시스템 기본 설정 완료"}, nil},
+	{StepVerifyRequirements, "시스템 요구사항 검증 시작", []string{"시스템 요구사항 검증 완료"}, nil},
+	{StepSetupNetwork, "네트워크 설정 시작", []string{"네트워크 설정 완료"}, nil},
+	{StepInstallDocker, "Docker 및 Containerd 설치 시작", []string{"Docker 및 Containerd 설치 완료"}, nil},
+	{StepInstallKubernetes, "쿠버네티스 설치 시작", []string{"쿠버네티스 설치 완료"}, nil},
+	{StepInitializeMaster, "마스터 노드 초기화 시작", []string{"마스터 노드 초기화 완료"}, []string{"마스터 노드 초기화 최종 실패", "CNI 설치 실패"}},
+	{StepInstallCNI, "Calico CNI 설치 시작", []string{"Calico CNI 설치 및 검증 완료"}, []string{"Calico CNI 설치 실패"}},
+	{StepGenerateJoin, "Join 명령어 생성 시작", []string{"Join 명령어 생성 완료"}, nil},
+}
+
+const (
+	bootstrapScriptStarted   = "설치 스크립트 시작"
+	bootstrapScriptCompleted = "설치 스크립트 완료"
+	bootstrapErrorPrefix     = "오류: "
+	bootstrapTimeLayout      = "2006-01-02 15:04:05"
+	bootstrapExcerptLines    = 20
+)
+
+// 여러 함수에서 공통으로 쓰는 실패 메시지 (현재 실행 중인 단계의 실패로 간주)
+//
+// verify_cni의 "CNI POD가 정상적으로 실행되지 않았습니다"는 install_cni가 재시도하므로 실패로 보지 않습니다.
+var bootstrapFailureMessages = []string{
+	"Join 명령어 파일 생성 실패",
+}
+
+// 콘솔 출력에는 cloud-init 접두어나 커널 메시지가 섞일 수 있으므로 줄 어디에서든 마커를 찾음
+var bootstrapLogLine = regexp.MustCompile(`\[(K8S-SETUP|SYSTEM-SETUP)\] (\d{4}-\d{2}-\d{2} \d{2}:\d{2}:\d{2}) - (.*)$`)
+
+// BootstrapStepRecord 단계별 진행 기록
+type BootstrapStepRecord struct {
+	Step       BootstrapStep
+	Status     BootstrapStepStatus
+	StartedAt  time.Time // 스크립트 로그의 시각 (노드 시간대 기준)
+	FinishedAt time.Time
+	Lines      []string // 단계 실행 중 출력된 콘솔 줄
+}
+
+// Duration 단계 소요 시간 (완료되지 않았으면 0)
+func (r *BootstrapStepRecord) Duration() time.Duration {
+	if r.StartedAt.IsZero() || r.FinishedAt.IsZero() {
+		return 0
+	}
+	return r.FinishedAt.Sub(r.StartedAt)
+}
+
+// BootstrapTimeline 부트스트랩 진행 타임라인
+type BootstrapTimeline struct {
+	Steps     []*BootstrapStepRecord // 시작된 순서
+	Started   bool
+	Completed bool // "설치 스크립트 완료" 로그 확인 여부
+	Failure   *BootstrapError
+}
+
+// Step 단계 기록 조회 (시작되지 않았으면 nil)
+func (tl *BootstrapTimeline) Step(step BootstrapStep) *BootstrapStepRecord {
+	for _, record := range tl.Steps {
+		if record.Step == step {
+			return record
+		}
+	}
+	return nil
+}
+
+// StepStatus 단계 상태 조회
+func (tl *BootstrapTimeline) StepStatus(step BootstrapStep) BootstrapStepStatus {
+	if record := tl.Step(step); record != nil {
+		return record.Status
+	}
+	return StepPending
+}
+
+// HasCompleted 주어진 단계가 모두 완료되고 스크립트가 끝났는지 여부
+func (tl *BootstrapTimeline) HasCompleted(steps []BootstrapStep) bool {
+	for _, step := range steps {
+		if tl.StepStatus(step) != StepCompleted {
+			return false
+		}
+	}
+	return tl.Completed
+}
+
+// String 사람이 읽을 수 있는 타임라인 요약
+func (tl *BootstrapTimeline) String() string {
+	var builder strings.Builder
+	for _, record := range tl.Steps {
+		fmt.Fprintf(&builder, "%-28s %-10s", record.Step, record.Status)
+		if duration := record.Duration(); duration > 0 {
+			fmt.Fprintf(&builder, " %s", duration)
+		}
+		builder.WriteString("\n")
+	}
+	return builder.String()
+}
+
+// BootstrapError 부트스트랩 단계 실패 정보
+type BootstrapError struct {
+	Step    BootstrapStep
+	Message string
+	Excerpt []string // 실패한 단계의 마지막 로그
+}
+
+func (e *BootstrapError) Error() string {
+	return fmt.Sprintf("부트스트랩 단계 %s 실패: %s\n%s", e.Step, e.Message, strings.Join(e.Excerpt, "\n"))
+}
+
+// ParseBootstrapLog 콘솔 출력에서 부트스트랩 단계 마커를 파싱
+func ParseBootstrapLog(output string) *BootstrapTimeline {
+	timeline := &BootstrapTimeline{}
+	var running []*BootstrapStepRecord
+
+	for _, line := range strings.Split(strings.ReplaceAll(output, "\r\n", "\n"), "\n") {
+		for _, record := range running {
+			record.Lines = append(record.Lines, line)
+		}
+
+		match := bootstrapLogLine.FindStringSubmatch(line)
+		if match == nil {
+			continue
+		}
+		at, _ := time.Parse(bootstrapTimeLayout, match[2])
+		message := strings.TrimSpace(match[3])
+
+		switch message {
+		case bootstrapScriptStarted:
+			timeline.Started = true
+			continue
+		case bootstrapScriptCompleted:
+			timeline.Completed = true
+			continue
+		}
+
+		if step, ok := stepStartedBy(message); ok {
+			record := timeline.Step(step)
+			if record == nil {
+				record = &BootstrapStepRecord{Step: step}
+				timeline.Steps = append(timeline.Steps, record)
+			}
+			record.Status = StepRunning
+			record.StartedAt = at
+			record.Lines = []string{line}
+			running = append(running, record)
+			continue
+		}
+
+		if len(running) == 0 {
+			continue
+		}
+		current := running[len(running)-1]
+
+		if isStepDone(current.Step, message) {
+			current.Status = StepCompleted
+			current.FinishedAt = at
+			running = running[:len(running)-1]
+			continue
+		}
+
+		if isStepFailure(current.Step, message) {
+			current.Status = StepFailed
+			current.FinishedAt = at
+			running = running[:len(running)-1]
+			if timeline.Failure == nil {
+				timeline.Failure = &BootstrapError{
+					Step:    current.Step,
+					Message: strings.TrimPrefix(message, bootstrapErrorPrefix),
+					Excerpt: lastLines(current.Lines, bootstrapExcerptLines),
+				}
+			}
+		}
+	}
+
+	return timeline
+}
+
+// GetConsoleOutput EC2 인스턴스 콘솔 출력 조회
+func (c *AWSTestClient) GetConsoleOutput(instanceID string) (string, error) {
+	result, err := c.EC2.GetConsoleOutput(&ec2.GetConsoleOutputInput{
+		InstanceId: aws.String(instanceID),
+		Latest:     aws.Bool(true),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	decoded, err := base64.StdEncoding.DecodeString(aws.StringValue(result.Output))
+	if err != nil {
+		return "", fmt.Errorf("콘솔 출력 디코딩 실패: %w", err)
+	}
+	return string(decoded), nil
+}
+
+// WaitForInstanceBootstrap 인스턴스 콘솔 출력을 폴링하여 부트스트랩 완료 대기
+//
+// system_settings.sh는 user data(cloud-init)로, combined_settings.sh는 remote-exec로 실행됩니다.
+// remote-exec의 stdout은 콘솔에 남지 않으므로 combined_settings.sh의 log()가 /dev/console에도 기록합니다.
+func (c *AWSTestClient) WaitForInstanceBootstrap(t testing.TestingT, instanceID string, steps []BootstrapStep, maxRetries int, sleepBetweenRetries time.Duration) (*BootstrapTimeline, error) {
+	return WaitForBootstrap(t, func() (string, error) {
+		return c.GetConsoleOutput(instanceID)
+	}, steps, maxRetries, sleepBetweenRetries)
+}
+
+// WaitForBootstrap 출력 소스를 폴링하여 부트스트랩 완료 대기
+//
+// 단계 실패가 확인되면 재시도하지 않고 즉시 *BootstrapError를 반환합니다.
+func WaitForBootstrap(t testing.TestingT, fetchOutput func() (string, error), steps []BootstrapStep, maxRetries int, sleepBetweenRetries time.Duration) (*BootstrapTimeline, error) {
+	var timeline *BootstrapTimeline
+
+	_, err := retry.DoWithRetryE(t, "노드 부트스트랩 대기", maxRetries, sleepBetweenRetries, func() (string, error) {
+		output, err := fetchOutput()
+		if err != nil {
+			return "", err
+		}
+
+		timeline = ParseBootstrapLog(output)
+		if timeline.Failure != nil {
+			return "", retry.FatalError{Underlying: timeline.Failure}
+		}
+		if !timeline.HasCompleted(steps) {
+			return "", fmt.Errorf("부트스트랩 진행 중:\n%s", timeline)
+		}
+		return timeline.String(), nil
+	})
+
+	if fatal, ok := err.(retry.FatalError); ok {
+		return timeline, fatal.Underlying
+	}
+	return timeline, err
+}
+
+func stepStartedBy(message string) (BootstrapStep, bool) {
+	for _, marker := range bootstrapStepMarkers {
+		if marker.start == message {
+			return marker.step, true
+		}
+	}
+	return "", false
+}
+
+func isStepDone(step BootstrapStep, message string) bool {
+	for _, marker := range bootstrapStepMarkers {
+		if marker.step == step {
+			for _, done := range marker.done {
+				if done == message {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+func isStepFailure(step BootstrapStep, message string) bool {
+	if strings.HasPrefix(message, bootstrapErrorPrefix) {
+		return true
+	}
+	for _, failure := range bootstrapFailureMessages {
+		if failure == message {
+			return true
+		}
+	}
+	for _, marker := range bootstrapStepMarkers {
+		if marker.step == step {
+			for _, failure := range marker.failures {
+				if failure == message {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+func lastLines(lines []string, n int) []string {
+	if len(lines) <= n {
+		return append([]string(nil), lines...)
+	}
+	return append([]string(nil), lines[len(lines)-n:]...)
+}
@@ -0,0 +1,75 @@
+// alertcoverage 알림 규칙과 Grafana 대시보드의 커버리지 행렬 출력
+//
+//	go run ./cmd/alertcoverage -format markdown > coverage.md
+//	go run ./cmd/alertcoverage -format json -dashboard a.json -dashboard b.json
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/k8s-ec2-observability/test/helpers/alerts"
+	"github.com/k8s-ec2-observability/test/helpers/coverage"
+	"github.com/k8s-ec2-observability/test/helpers/grafana"
+)
+
+const (
+	defaultRulesPath     = "../../../../manifests/observability/custom-alert-rules.yaml"
+	defaultDashboardPath = "../../../../manifests/grafana/k8s-cluster-dashboard.json"
+)
+
+// 반복 지정 가능한 플래그
+type pathList []string
+
+func (p *pathList) String() string     { return strings.Join(*p, ",") }
+func (p *pathList) Set(v string) error { *p = append(*p, v); return nil }
+
+func main() {
+	rulesPath := flag.String("rules", defaultRulesPath, "PrometheusRule 경로")
+	format := flag.String("format", "markdown", "출력 형식 (markdown, json)")
+	var dashboardPaths pathList
+	flag.Var(&dashboardPaths, "dashboard", "Grafana 대시보드 JSON 경로 (반복 지정 가능)")
+	flag.Parse()
+
+	if len(dashboardPaths) == 0 {
+		dashboardPaths = pathList{defaultDashboardPath}
+	}
+
+	rule, err := alerts.LoadPrometheusRule(*rulesPath)
+	if err != nil {
+		log.Fatal(err)
+	}
+	var dashboards []*grafana.Dashboard
+	for _, path := range dashboardPaths {
+		dashboard, err := grafana.LoadDashboard(path)
+		if err != nil {
+			log.Fatalf("%s: %v", path, err)
+		}
+		dashboards = append(dashboards, dashboard)
+	}
+
+	report, err := coverage.BuildReport(rule, dashboards...)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	switch *format {
+	case "markdown":
+		fmt.Print(report.Markdown())
+	case "json":
+		data, err := report.JSON()
+		if err != nil {
+			log.Fatal(err)
+		}
+		os.Stdout.Write(append(data, '\n'))
+	default:
+		log.Fatalf("알 수 없는 출력 형식: %s", *format)
+	}
+
+	if uncovered := report.UncoveredAlerts(); len(uncovered) > 0 {
+		fmt.Fprintf(os.Stderr, "패널이 없는 알림 %d개\n", len(uncovered))
+	}
+}
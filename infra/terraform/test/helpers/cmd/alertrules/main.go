@@ -0,0 +1,67 @@
+// alertrules custom-alert-rules.yaml.tmpl을 렌더링하여 PrometheusRule 생성
+//
+// 기본값은 노드 역할 레이블 기반 규칙이며, -terraform-dir 또는 -master/-workers를 지정하면
+// 해당 클러스터의 노드 이름으로 마스터/워커를 선택하는 규칙을 생성합니다.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/gruntwork-io/terratest/modules/terraform"
+	"github.com/k8s-ec2-observability/test/helpers/alerts"
+)
+
+const defaultRulesPath = "../../../../manifests/observability/custom-alert-rules.yaml"
+
+func main() {
+	templatePath := flag.String("template", defaultRulesPath+".tmpl", "알림 규칙 템플릿 경로")
+	outputPath := flag.String("out", defaultRulesPath, "생성할 PrometheusRule 경로")
+	terraformDir := flag.String("terraform-dir", "", "master_private_ip / worker_private_ips 출력을 읽을 루트 스택 경로")
+	master := flag.String("master", "", "마스터 노드 프라이빗 IP 또는 DNS 이름")
+	workers := flag.String("workers", "", "워커 노드 프라이빗 IP 또는 DNS 이름 (쉼표로 구분)")
+	flag.Parse()
+
+	topology, err := resolveTopology(*terraformDir, *master, *workers)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := alerts.GeneratePrometheusRule(*templatePath, *outputPath, topology); err != nil {
+		log.Fatal(err)
+	}
+	fmt.Printf("%s 생성 완료 (노드 선택 방식: %s)\n", *outputPath, topology.Mode)
+}
+
+func resolveTopology(terraformDir, master, workers string) (alerts.NodeTopology, error) {
+	switch {
+	case terraformDir != "":
+		return alerts.NodeTopologyFromRootStack(cliT{}, &terraform.Options{TerraformDir: terraformDir, NoColor: true})
+	case master != "":
+		return alerts.NodeTopologyFromAddresses(master, splitList(workers))
+	default:
+		return alerts.RoleNodeTopology(), nil
+	}
+}
+
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// cliT 테스트 밖에서 terratest 함수를 호출하기 위한 testing.TestingT 구현
+type cliT struct{}
+
+func (cliT) Fail()                                     {}
+func (cliT) FailNow()                                  { log.Fatal("중단") }
+func (cliT) Fatal(args ...interface{})                 { log.Fatal(args...) }
+func (cliT) Fatalf(format string, args ...interface{}) { log.Fatalf(format, args...) }
+func (cliT) Error(args ...interface{})                 { log.Print(args...) }
+func (cliT) Errorf(format string, args ...interface{}) { log.Printf(format, args...) }
+func (cliT) Name() string                              { return "alertrules" }
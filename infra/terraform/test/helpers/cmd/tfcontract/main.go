@@ -0,0 +1,46 @@
+// tfcontract 모듈 호출 인자와 피호출 모듈의 변수/출력 계약을 apply 없이 검사
+//
+//	go run ./cmd/tfcontract
+//	go run ./cmd/tfcontract -graph | dot -Tsvg > modules.svg
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/k8s-ec2-observability/test/helpers/tfcontract"
+)
+
+const defaultRootDir = "../.."
+
+func main() {
+	rootDir := flag.String("root", defaultRootDir, "루트 Terraform 스택 경로")
+	graph := flag.Bool("graph", false, "검사 대신 module 의존 그래프를 DOT 형식으로 출력")
+	flag.Parse()
+
+	tree, err := tfcontract.LoadTree(*rootDir)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if *graph {
+		fmt.Print(tfcontract.BuildGraph(tree.Root).DOT())
+		return
+	}
+
+	order, err := tfcontract.BuildGraph(tree.Root).Order()
+	if err == nil {
+		fmt.Printf("생성 순서: %s\n", strings.Join(order, " → "))
+	}
+	findings := tfcontract.Check(tree)
+	for _, finding := range findings {
+		fmt.Println(finding)
+	}
+	if errors := tfcontract.Errors(findings); len(errors) > 0 {
+		fmt.Fprintf(os.Stderr, "계약 위반 %d개\n", len(errors))
+		os.Exit(1)
+	}
+}
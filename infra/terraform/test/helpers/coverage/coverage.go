@@ -0,0 +1,331 @@
+package coverage
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/k8s-ec2-observability/test/helpers/alerts"
+	"github.com/k8s-ec2-observability/test/helpers/grafana"
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/prometheus/prometheus/promql/parser"
+)
+
+// Signal 쿼리가 조회하는 메트릭과 레이블 선택자
+type Signal struct {
+	Metric   string
+	Matchers []*labels.Matcher // __name__ 제외
+}
+
+// String PromQL 선택자 형식
+func (s Signal) String() string {
+	if len(s.Matchers) == 0 {
+		return s.Metric
+	}
+	matchers := make([]string, len(s.Matchers))
+	for i, matcher := range s.Matchers {
+		matchers[i] = matcher.String()
+	}
+	return fmt.Sprintf("%s{%s}", s.Metric, strings.Join(matchers, ","))
+}
+
+// Overlaps 두 선택자가 같은 시계열을 선택할 수 있는지 여부
+//
+// 같은 레이블에 대한 매처가 서로 배타적인 경우(예: phase="Pending"과 phase="Running")만 겹치지 않는 것으로 봅니다.
+func (s Signal) Overlaps(other Signal) bool {
+	if s.Metric != other.Metric {
+		return false
+	}
+	for _, a := range s.Matchers {
+		for _, b := range other.Matchers {
+			if a.Name == b.Name && !matchersOverlap(a, b) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func matchersOverlap(a, b *labels.Matcher) bool {
+	switch {
+	case a.Type == labels.MatchEqual:
+		return b.Matches(a.Value)
+	case b.Type == labels.MatchEqual:
+		return a.Matches(b.Value)
+	default:
+		// 정규식/부정 매처끼리는 교집합을 계산할 수 없으므로 겹친다고 봄
+		return true
+	}
+}
+
+// PanelRef 대시보드 패널 참조
+type PanelRef struct {
+	Dashboard string `json:"dashboard"`
+	PanelID   int    `json:"panelId"`
+	Title     string `json:"title"`
+}
+
+func (p PanelRef) String() string {
+	return fmt.Sprintf("%s #%d %s", p.Dashboard, p.PanelID, p.Title)
+}
+
+// AlertCoverage 알림별 시각화 현황
+type AlertCoverage struct {
+	Group    string     `json:"group"`
+	Alert    string     `json:"alert"`
+	Severity string     `json:"severity"`
+	Signals  []string   `json:"signals"`
+	Panels   []PanelRef `json:"panels"`
+
+	signals []Signal
+}
+
+// Covered 알림 신호를 보여주는 패널이 있는지 여부
+func (a AlertCoverage) Covered() bool {
+	return len(a.Panels) > 0
+}
+
+// PanelCoverage 패널별 알림 현황
+type PanelCoverage struct {
+	PanelRef
+	Signals []string `json:"signals"`
+	Alerts  []string `json:"alerts"`
+
+	signals []Signal
+}
+
+// Report 알림-대시보드 커버리지 보고서
+type Report struct {
+	Alerts []AlertCoverage `json:"alerts"`
+	Panels []PanelCoverage `json:"panels"`
+}
+
+// BuildReport PrometheusRule 알림과 대시보드 패널을 메트릭/선택자 기준으로 대조
+func BuildReport(rule *alerts.PrometheusRule, dashboards ...*grafana.Dashboard) (*Report, error) {
+	report := &Report{}
+
+	for _, dashboard := range dashboards {
+		panelSignals := make(map[int][]Signal)
+		var panelOrder []PanelRef
+		for _, query := range dashboard.Queries() {
+			if query.Kind != grafana.QueryPanel {
+				continue
+			}
+			expr, err := parser.ParseExpr(query.Expanded)
+			if err != nil {
+				return nil, fmt.Errorf("%s 패널 %q PromQL 파싱 실패: %w", dashboard.Title, query.Panel, err)
+			}
+			if _, ok := panelSignals[query.PanelID]; !ok {
+				panelOrder = append(panelOrder, PanelRef{Dashboard: dashboard.Title, PanelID: query.PanelID, Title: query.Panel})
+			}
+			panelSignals[query.PanelID] = append(panelSignals[query.PanelID], ExtractSignals(expr)...)
+		}
+		for _, panel := range panelOrder {
+			signals := panelSignals[panel.PanelID]
+			report.Panels = append(report.Panels, PanelCoverage{
+				PanelRef: panel,
+				Signals:  signalStrings(signals),
+				signals:  signals,
+			})
+		}
+	}
+
+	for _, group := range rule.Spec.Groups {
+		for _, r := range group.Rules {
+			if r.Alert == "" {
+				continue
+			}
+			expr, err := parser.ParseExpr(r.Expr)
+			if err != nil {
+				return nil, fmt.Errorf("%s PromQL 파싱 실패: %w", r.Alert, err)
+			}
+			signals := ExtractSignals(expr)
+			report.Alerts = append(report.Alerts, AlertCoverage{
+				Group: group.Name, Alert: r.Alert, Severity: r.Labels["severity"],
+				Signals: signalStrings(signals), signals: signals,
+			})
+		}
+	}
+
+	for i := range report.Alerts {
+		alert := &report.Alerts[i]
+		for j := range report.Panels {
+			panel := &report.Panels[j]
+			if signalsOverlap(alert.signals, panel.signals) {
+				alert.Panels = append(alert.Panels, panel.PanelRef)
+				panel.Alerts = append(panel.Alerts, alert.Alert)
+			}
+		}
+	}
+
+	return report, nil
+}
+
+// UncoveredAlerts 신호를 보여주는 패널이 없는 알림
+func (r *Report) UncoveredAlerts() []AlertCoverage {
+	var uncovered []AlertCoverage
+	for _, alert := range r.Alerts {
+		if !alert.Covered() {
+			uncovered = append(uncovered, alert)
+		}
+	}
+	return uncovered
+}
+
+// PanelsWithoutAlerts 연결된 알림이 없는 패널
+func (r *Report) PanelsWithoutAlerts() []PanelCoverage {
+	var panels []PanelCoverage
+	for _, panel := range r.Panels {
+		if len(panel.Alerts) == 0 {
+			panels = append(panels, panel)
+		}
+	}
+	return panels
+}
+
+// JSON 보고서 JSON 직렬화
+func (r *Report) JSON() ([]byte, error) {
+	return json.MarshalIndent(r, "", "  ")
+}
+
+// Markdown 알림 x 패널 커버리지 행렬
+func (r *Report) Markdown() string {
+	var builder strings.Builder
+
+	covered := len(r.Alerts) - len(r.UncoveredAlerts())
+	fmt.Fprintf(&builder, "# 알림-대시보드 커버리지\n\n")
+	fmt.Fprintf(&builder, "- 패널이 있는 알림: %d/%d\n", covered, len(r.Alerts))
+	fmt.Fprintf(&builder, "- 알림이 있는 패널: %d/%d\n\n", len(r.Panels)-len(r.PanelsWithoutAlerts()), len(r.Panels))
+
+	builder.WriteString("| 그룹 | 알림 | 심각도 |")
+	for i := range r.Panels {
+		fmt.Fprintf(&builder, " P%d |", i+1)
+	}
+	builder.WriteString("\n|---|---|---|")
+	for range r.Panels {
+		builder.WriteString(":-:|")
+	}
+	builder.WriteString("\n")
+	for _, alert := range r.Alerts {
+		fmt.Fprintf(&builder, "| %s | %s | %s |", alert.Group, alert.Alert, alert.Severity)
+		for _, panel := range r.Panels {
+			mark := " "
+			if containsString(panel.Alerts, alert.Alert) {
+				mark = "✓"
+			}
+			fmt.Fprintf(&builder, " %s |", mark)
+		}
+		builder.WriteString("\n")
+	}
+
+	builder.WriteString("\n| 열 | 대시보드 | 패널 | 신호 |\n|---|---|---|---|\n")
+	for i, panel := range r.Panels {
+		fmt.Fprintf(&builder, "| P%d | %s | #%d %s | %s |\n", i+1, panel.Dashboard, panel.PanelID, panel.Title, markdownCode(panel.Signals))
+	}
+
+	if uncovered := r.UncoveredAlerts(); len(uncovered) > 0 {
+		builder.WriteString("\n## 패널이 없는 알림\n\n")
+		for _, alert := range uncovered {
+			fmt.Fprintf(&builder, "- %s (%s): %s\n", alert.Alert, alert.Severity, markdownCode(alert.Signals))
+		}
+	}
+	if panels := r.PanelsWithoutAlerts(); len(panels) > 0 {
+		builder.WriteString("\n## 알림이 없는 패널\n\n")
+		for _, panel := range panels {
+			fmt.Fprintf(&builder, "- %s: %s\n", panel.PanelRef, markdownCode(panel.Signals))
+		}
+	}
+
+	return builder.String()
+}
+
+// ExtractSignals 식이 조회하는 선택자 목록
+//
+// "expr and on(...) filter", "expr unless on(...) filter"의 오른쪽 식은 노드 선택 등 필터 용도이므로 제외합니다.
+func ExtractSignals(expr parser.Expr) []Signal {
+	var signals []Signal
+	parser.Inspect(expr, func(node parser.Node, path []parser.Node) error {
+		selector, ok := node.(*parser.VectorSelector)
+		if !ok || isFilterOperand(node, path) {
+			return nil
+		}
+
+		signal := Signal{Metric: selector.Name}
+		for _, matcher := range selector.LabelMatchers {
+			if matcher.Name == labels.MetricName {
+				if signal.Metric == "" && matcher.Type == labels.MatchEqual {
+					signal.Metric = matcher.Value
+				}
+				continue
+			}
+			if strings.Contains(matcher.Value, grafana.VariablePlaceholder) {
+				continue // 대시보드 변수는 모든 값을 허용하는 것으로 봄
+			}
+			signal.Matchers = append(signal.Matchers, matcher)
+		}
+		if signal.Metric != "" {
+			signals = append(signals, signal)
+		}
+		return nil
+	})
+	return signals
+}
+
+func isFilterOperand(node parser.Node, path []parser.Node) bool {
+	for i, ancestor := range path {
+		binary, ok := ancestor.(*parser.BinaryExpr)
+		if !ok || (binary.Op != parser.LAND && binary.Op != parser.LUNLESS) {
+			continue
+		}
+		child := node
+		if i+1 < len(path) {
+			child = path[i+1]
+		}
+		if child == parser.Node(binary.RHS) {
+			return true
+		}
+	}
+	return false
+}
+
+func signalsOverlap(a, b []Signal) bool {
+	for _, x := range a {
+		for _, y := range b {
+			if x.Overlaps(y) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func signalStrings(signals []Signal) []string {
+	seen := make(map[string]bool)
+	var result []string
+	for _, signal := range signals {
+		if s := signal.String(); !seen[s] {
+			seen[s] = true
+			result = append(result, s)
+		}
+	}
+	sort.Strings(result)
+	return result
+}
+
+func markdownCode(values []string) string {
+	quoted := make([]string, len(values))
+	for i, value := range values {
+		quoted[i] = "`" + strings.ReplaceAll(value, "|", `\|`) + "`"
+	}
+	return strings.Join(quoted, ", ")
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,247 @@
+package helpers
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"runtime"
+	"strings"
+	"sync"
+	"testing"
+
+	terratesting "github.com/gruntwork-io/terratest/modules/testing"
+)
+
+// TestingT terratest TestingT에 Logf를 더한 인터페이스
+//
+// *testing.T와 TestMain 픽스처가 모두 구현하므로 Terraform 헬퍼를 테스트 밖에서도 쓸 수 있습니다.
+type TestingT interface {
+	terratesting.TestingT
+	Logf(format string, args ...interface{})
+}
+
+// SharedFixture 패키지의 여러 테스트가 함께 쓰는 리소스 (참조 카운트 방식)
+//
+// 처음 Acquire할 때 만들고, RunTests(TestMain)와 테스트들이 잡은 참조가 모두 반납되면 한 번 정리합니다.
+// 만든 뒤에는 DefaultTeardownRegistry에 정리 함수를 등록하므로 테스트가 panic으로 끝나거나
+// SIGINT/SIGTERM을 받으면 남은 참조와 관계없이 바로 정리합니다.
+type SharedFixture[V any] struct {
+	name     string
+	setup    func(t TestingT) (V, error)
+	teardown func(t TestingT, value V) error
+
+	mu       sync.Mutex
+	refs     int
+	ready    bool
+	value    V
+	setupErr error
+	// unregister 레지스트리에 등록한 중단 정리 함수 해제
+	unregister func()
+	// closed panic이나 신호로 정리된 뒤에는 다시 만들지 않음
+	closed bool
+}
+
+// NewSharedFixture 새로운 공유 픽스처 생성
+//
+// setup이 실패하면 만들다 만 리소스는 setup이 직접 정리해야 합니다. 실패 결과는 기억해 두고
+// 이후 Acquire에서도 같은 오류로 테스트를 중단합니다.
+func NewSharedFixture[V any](name string, setup func(t TestingT) (V, error), teardown func(t TestingT, value V) error) *SharedFixture[V] {
+	return &SharedFixture[V]{name: name, setup: setup, teardown: teardown}
+}
+
+// RunTests TestMain에서 helpers.RunTests로 테스트를 실행하고 종료 코드 반환
+//
+// 실행 중에는 TestMain이 참조 하나를 잡고 있어 순차 테스트 사이에 정리되지 않습니다.
+// 정리가 실패하면 테스트가 모두 통과해도 실패 코드를 돌려줍니다.
+//
+//	func TestMain(m *testing.M) {
+//		os.Exit(kmsKey.RunTests(m))
+//	}
+func (f *SharedFixture[V]) RunTests(m *testing.M) int {
+	f.mu.Lock()
+	f.refs++
+	f.mu.Unlock()
+
+	code := RunTests(m)
+	if err := f.release(); err != nil {
+		fmt.Fprintf(os.Stderr, "❌ %s 픽스처 정리 실패: %v\n", f.name, err)
+		if code == 0 {
+			code = 1
+		}
+	}
+	return code
+}
+
+// Acquire 픽스처 값을 돌려주고 테스트가 끝나면 참조 반납 (준비에 실패하면 테스트 중단)
+func (f *SharedFixture[V]) Acquire(t *testing.T) V {
+	t.Helper()
+	value, err := f.acquire()
+	if err != nil {
+		t.Fatalf("%s 픽스처 준비 실패: %v", f.name, err)
+	}
+	t.Cleanup(func() {
+		if panicking() {
+			// 프로세스가 곧 끝나므로 다른 테스트의 리소스까지 모두 정리
+			_ = DefaultTeardownRegistry().TeardownAll(t.Name() + " panic")
+			return
+		}
+		if err := f.release(); err != nil {
+			t.Errorf("%s 픽스처 정리 실패: %v", f.name, err)
+		}
+	})
+	return value
+}
+
+func (f *SharedFixture[V]) acquire() (V, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var zero V
+	switch {
+	case f.closed:
+		return zero, errors.New("이미 정리된 픽스처입니다")
+	case f.setupErr != nil:
+		return zero, f.setupErr
+	case !f.ready:
+		t := &fixtureT{name: f.name}
+		var value V
+		err := t.run(func() (err error) {
+			value, err = f.setup(t)
+			return err
+		})
+		if err != nil {
+			f.setupErr = err
+			return zero, err
+		}
+		f.value, f.ready = value, true
+		f.unregister = DefaultTeardownRegistry().OnInterrupt(f.name+" 픽스처", f.teardownNow)
+	}
+	f.refs++
+	return f.value, nil
+}
+
+// release 참조 하나를 반납하고 마지막 참조면 정리 (TestMain 없이 쓰면 테스트가 끝날 때마다 정리)
+func (f *SharedFixture[V]) release() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.refs--
+	if f.refs > 0 || !f.ready {
+		return nil
+	}
+	return f.teardownLocked()
+}
+
+// teardownNow 남은 참조와 관계없이 정리하고 이후 Acquire를 막음 (중단 정리 함수)
+func (f *SharedFixture[V]) teardownNow() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.closed = true
+	if !f.ready {
+		return
+	}
+	fmt.Fprintf(os.Stderr, "🧹 %s 픽스처를 바로 정리합니다\n", f.name)
+	if err := f.teardownLocked(); err != nil {
+		fmt.Fprintf(os.Stderr, "❌ %s 픽스처 정리 실패: %v\n", f.name, err)
+	}
+}
+
+func (f *SharedFixture[V]) teardownLocked() error {
+	t := &fixtureT{name: f.name}
+	err := t.run(func() error {
+		return f.teardown(t, f.value)
+	})
+	var zero V
+	f.value, f.ready = zero, false
+	if f.unregister != nil {
+		f.unregister()
+		f.unregister = nil
+	}
+	return err
+}
+
+// panicking 현재 고루틴이 panic을 처리하는 중인지 (panic으로 끝난 테스트의 Cleanup에서 true)
+func panicking() bool {
+	pcs := make([]uintptr, 64)
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(2, pcs)])
+	for {
+		frame, more := frames.Next()
+		if frame.Function == "runtime.gopanic" {
+			return true
+		}
+		if !more {
+			return false
+		}
+	}
+}
+
+// fixtureAbort fixtureT의 FailNow가 setup/teardown을 중단할 때 쓰는 panic 값
+type fixtureAbort struct{}
+
+// fixtureT *testing.T 없이 픽스처를 만들고 지울 때 쓰는 TestingT
+//
+// 실패는 기록해 두었다가 오류로 돌려주고, FailNow/Fatal은 setup/teardown만 중단합니다.
+type fixtureT struct {
+	name     string
+	mu       sync.Mutex
+	failures []string
+}
+
+func (f *fixtureT) Name() string {
+	return f.name
+}
+
+func (f *fixtureT) Logf(format string, args ...interface{}) {
+	fmt.Printf("%s: %s\n", f.name, fmt.Sprintf(format, args...))
+}
+
+func (f *fixtureT) Fail() {
+	f.record("실패")
+}
+
+func (f *fixtureT) FailNow() {
+	f.Fail()
+	panic(fixtureAbort{})
+}
+
+func (f *fixtureT) Error(args ...interface{}) {
+	f.record(fmt.Sprint(args...))
+}
+
+func (f *fixtureT) Errorf(format string, args ...interface{}) {
+	f.record(fmt.Sprintf(format, args...))
+}
+
+func (f *fixtureT) Fatal(args ...interface{}) {
+	f.Error(args...)
+	panic(fixtureAbort{})
+}
+
+func (f *fixtureT) Fatalf(format string, args ...interface{}) {
+	f.Errorf(format, args...)
+	panic(fixtureAbort{})
+}
+
+func (f *fixtureT) record(message string) {
+	f.Logf("%s", message)
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.failures = append(f.failures, message)
+}
+
+// run fn을 실행하고 fn의 오류 또는 기록된 실패를 돌려줌
+func (f *fixtureT) run(fn func() error) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			if _, ok := r.(fixtureAbort); !ok {
+				panic(r)
+			}
+		}
+		if err == nil {
+			f.mu.Lock()
+			defer f.mu.Unlock()
+			if len(f.failures) > 0 {
+				err = errors.New(strings.Join(f.failures, "; "))
+			}
+		}
+	}()
+	return fn()
+}
@@ -0,0 +1,177 @@
+package grafana
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// Dashboard Grafana 대시보드 모델 (검증에 필요한 필드만 디코딩)
+type Dashboard struct {
+	UID           string      `json:"uid"`
+	Title         string      `json:"title"`
+	SchemaVersion int         `json:"schemaVersion"`
+	Panels        []Panel     `json:"panels"`
+	Templating    Templating  `json:"templating"`
+	Annotations   Annotations `json:"annotations"`
+}
+
+// Panel 대시보드 패널 (row 패널은 하위 패널을 가질 수 있음)
+type Panel struct {
+	ID         int            `json:"id"`
+	Title      string         `json:"title"`
+	Type       string         `json:"type"`
+	Datasource *DatasourceRef `json:"datasource"`
+	GridPos    GridPos        `json:"gridPos"`
+	Targets    []Target       `json:"targets"`
+	Panels     []Panel        `json:"panels"`
+}
+
+// GridPos 패널 위치
+type GridPos struct {
+	H int `json:"h"`
+	W int `json:"w"`
+	X int `json:"x"`
+	Y int `json:"y"`
+}
+
+// Target 패널 쿼리
+type Target struct {
+	RefID        string         `json:"refId"`
+	Expr         string         `json:"expr"`
+	LegendFormat string         `json:"legendFormat"`
+	Format       string         `json:"format"`
+	Instant      bool           `json:"instant"`
+	Hide         bool           `json:"hide"`
+	Datasource   *DatasourceRef `json:"datasource"`
+}
+
+// Templating 대시보드 변수 목록
+type Templating struct {
+	List []Variable `json:"list"`
+}
+
+// Variable 대시보드 변수
+type Variable struct {
+	Name       string         `json:"name"`
+	Type       string         `json:"type"`
+	Datasource *DatasourceRef `json:"datasource"`
+	Query      VariableQuery  `json:"query"`
+}
+
+// VariableQuery 변수 쿼리 (스키마 버전에 따라 문자열 또는 {"query": ...} 객체)
+type VariableQuery string
+
+// UnmarshalJSON 문자열/객체 형식을 모두 허용
+func (q *VariableQuery) UnmarshalJSON(data []byte) error {
+	var text string
+	if err := json.Unmarshal(data, &text); err == nil {
+		*q = VariableQuery(text)
+		return nil
+	}
+	var object struct {
+		Query string `json:"query"`
+	}
+	if err := json.Unmarshal(data, &object); err != nil {
+		return fmt.Errorf("변수 쿼리 형식 오류: %w", err)
+	}
+	*q = VariableQuery(object.Query)
+	return nil
+}
+
+// Annotations 대시보드 어노테이션 목록
+type Annotations struct {
+	List []Annotation `json:"list"`
+}
+
+// Annotation 어노테이션 쿼리
+type Annotation struct {
+	Name       string         `json:"name"`
+	Datasource *DatasourceRef `json:"datasource"`
+	Expr       string         `json:"expr"`
+	Enable     bool           `json:"enable"`
+	BuiltIn    int            `json:"builtIn"`
+}
+
+// DatasourceRef 데이터소스 참조
+//
+// 스키마 27 이전에는 데이터소스 이름 문자열, 이후에는 {"type", "uid"} 객체를 사용합니다.
+type DatasourceRef struct {
+	Name string
+	Type string
+	UID  string
+}
+
+// UnmarshalJSON 문자열/객체 형식을 모두 허용
+func (d *DatasourceRef) UnmarshalJSON(data []byte) error {
+	var name string
+	if err := json.Unmarshal(data, &name); err == nil {
+		d.Name = name
+		return nil
+	}
+	var object struct {
+		Type string `json:"type"`
+		UID  string `json:"uid"`
+	}
+	if err := json.Unmarshal(data, &object); err != nil {
+		return fmt.Errorf("데이터소스 참조 형식 오류: %w", err)
+	}
+	d.Type = object.Type
+	d.UID = object.UID
+	return nil
+}
+
+// String 참조를 사람이 읽을 수 있는 형태로 표시
+func (d *DatasourceRef) String() string {
+	if d == nil {
+		return "(기본 데이터소스)"
+	}
+	if d.Name != "" {
+		return d.Name
+	}
+	return fmt.Sprintf("%s/%s", d.Type, d.UID)
+}
+
+// AllPanels row 하위 패널까지 포함한 전체 패널 목록
+func (d *Dashboard) AllPanels() []Panel {
+	var panels []Panel
+	var walk func([]Panel)
+	walk = func(list []Panel) {
+		for _, panel := range list {
+			panels = append(panels, panel)
+			walk(panel.Panels)
+		}
+	}
+	walk(d.Panels)
+	return panels
+}
+
+// ParseDashboard 대시보드 JSON 디코딩
+//
+// Grafana API 내보내기 형식({"dashboard": {...}})도 허용합니다.
+func ParseDashboard(data []byte) (*Dashboard, error) {
+	var wrapper struct {
+		Dashboard *json.RawMessage `json:"dashboard"`
+	}
+	if err := json.Unmarshal(data, &wrapper); err != nil {
+		return nil, fmt.Errorf("대시보드 JSON 파싱 실패: %w", err)
+	}
+	if wrapper.Dashboard != nil {
+		data = *wrapper.Dashboard
+	}
+
+	var dashboard Dashboard
+	if err := json.Unmarshal(data, &dashboard); err != nil {
+		return nil, fmt.Errorf("대시보드 JSON 파싱 실패: %w", err)
+	}
+	return &dashboard, nil
+}
+
+// LoadDashboard 파일에서 대시보드 로드
+func LoadDashboard(path string) (*Dashboard, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return ParseDashboard(data)
+}
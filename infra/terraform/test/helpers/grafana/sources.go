@@ -0,0 +1,117 @@
+package grafana
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// MetricSource 메트릭을 노출하는 구성 요소
+type MetricSource struct {
+	Name     string
+	Prefixes []string // 메트릭 이름 접두어
+}
+
+// Provides 메트릭 이름이 이 소스에서 나오는지 여부
+func (s MetricSource) Provides(metric string) bool {
+	for _, prefix := range s.Prefixes {
+		if strings.HasPrefix(metric, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// kube-prometheus-stack 구성 요소별 메트릭 접두어
+var (
+	NodeExporterSource     = MetricSource{Name: "node-exporter", Prefixes: []string{"node_"}}
+	KubeStateMetricsSource = MetricSource{Name: "kube-state-metrics", Prefixes: []string{"kube_"}}
+	PrometheusSelfSource   = MetricSource{Name: "prometheus", Prefixes: []string{"prometheus_"}}
+	AlertmanagerSelfSource = MetricSource{Name: "alertmanager", Prefixes: []string{"alertmanager_"}}
+)
+
+// Prometheus가 스크레이프/규칙 평가 시 직접 생성하는 시계열 (values 설정과 무관)
+var builtinMetrics = map[string]bool{
+	"up":                                    true,
+	"scrape_duration_seconds":               true,
+	"scrape_samples_scraped":                true,
+	"scrape_samples_post_metric_relabeling": true,
+	"scrape_series_added":                   true,
+	"ALERTS":                                true,
+	"ALERTS_FOR_STATE":                      true,
+}
+
+// MetricSources 활성화된 메트릭 소스 목록
+type MetricSources []MetricSource
+
+// SourceOf 메트릭을 노출하는 소스 이름 (없으면 false)
+func (sources MetricSources) SourceOf(metric string) (string, bool) {
+	if builtinMetrics[metric] {
+		return "prometheus", true
+	}
+	for _, source := range sources {
+		if source.Provides(metric) {
+			return source.Name, true
+		}
+	}
+	return "", false
+}
+
+// Names 소스 이름 목록
+func (sources MetricSources) Names() []string {
+	names := make([]string, len(sources))
+	for i, source := range sources {
+		names[i] = source.Name
+	}
+	return names
+}
+
+// MetricSourcesFromValues kube-prometheus-stack values 파일에서 활성화된 메트릭 소스 결정
+//
+// 값이 없으면 차트 기본값(모두 활성화)을 따릅니다.
+func MetricSourcesFromValues(path string) (MetricSources, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var values map[string]interface{}
+	if err := yaml.Unmarshal(data, &values); err != nil {
+		return nil, fmt.Errorf("values 파일 파싱 실패: %w", err)
+	}
+
+	var sources MetricSources
+	if boolValue(values, true, "nodeExporter", "enabled") {
+		sources = append(sources, NodeExporterSource)
+	}
+	if boolValue(values, true, "kubeStateMetrics", "enabled") {
+		sources = append(sources, KubeStateMetricsSource)
+	}
+	if boolValue(values, true, "prometheus", "enabled") && boolValue(values, true, "prometheus", "serviceMonitor", "selfMonitor") {
+		sources = append(sources, PrometheusSelfSource)
+	}
+	if boolValue(values, true, "alertmanager", "enabled") && boolValue(values, true, "alertmanager", "serviceMonitor", "selfMonitor") {
+		sources = append(sources, AlertmanagerSelfSource)
+	}
+	return sources, nil
+}
+
+// 중첩된 키의 bool 값 조회 (없으면 기본값)
+func boolValue(values map[string]interface{}, defaultValue bool, keys ...string) bool {
+	var current interface{} = values
+	for _, key := range keys {
+		m, ok := current.(map[string]interface{})
+		if !ok {
+			return defaultValue
+		}
+		if current, ok = m[key]; !ok {
+			return defaultValue
+		}
+	}
+	if value, ok := current.(bool); ok {
+		return value
+	}
+	return defaultValue
+}
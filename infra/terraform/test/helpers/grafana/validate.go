@@ -0,0 +1,368 @@
+package grafana
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/k8s-ec2-observability/test/helpers/promexpr"
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/prometheus/prometheus/promql/parser"
+)
+
+// Severity 검증 결과 심각도
+type Severity string
+
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+)
+
+// 검증 항목 종류
+const (
+	CheckDuplicatePanelID  = "duplicate-panel-id"
+	CheckDuplicateRefID    = "duplicate-ref-id"
+	CheckDatasource        = "datasource"
+	CheckEmptyQuery        = "empty-query"
+	CheckPromQLParse       = "promql-parse"
+	CheckUndefinedVariable = "undefined-variable"
+	CheckUnknownMetric     = "unknown-metric"
+	CheckHardcodedNode     = "hardcoded-node"
+)
+
+// Grafana 내장 데이터소스
+const (
+	grafanaDatasource = "-- Grafana --"
+	mixedDatasource   = "-- Mixed --"
+)
+
+// Finding 검증 결과 항목
+type Finding struct {
+	PanelID  int
+	Panel    string // 패널 제목 (변수/어노테이션이면 "$이름", "어노테이션 이름")
+	RefID    string
+	Severity Severity
+	Check    string
+	Message  string
+}
+
+func (f Finding) String() string {
+	location := f.Panel
+	if f.RefID != "" {
+		location += "/" + f.RefID
+	}
+	return fmt.Sprintf("[%s] %s (%s): %s", f.Severity, location, f.Check, f.Message)
+}
+
+// ValidateOptions 검증 설정
+type ValidateOptions struct {
+	Datasources  []string      // 허용되는 Prometheus 데이터소스 이름 또는 UID
+	Sources      MetricSources // 메트릭을 노출하는 구성 요소
+	ExtraMetrics []string      // 기록 규칙 등 소스 밖에서 만들어지는 메트릭
+}
+
+// DefaultValidateOptions kube-prometheus-stack이 프로비저닝하는 Prometheus 데이터소스 기준 설정
+func DefaultValidateOptions(sources MetricSources) ValidateOptions {
+	return ValidateOptions{
+		Datasources: []string{"Prometheus", "prometheus"},
+		Sources:     sources,
+	}
+}
+
+// QueryKind 쿼리 위치
+type QueryKind string
+
+const (
+	QueryPanel      QueryKind = "panel"
+	QueryVariable   QueryKind = "variable"
+	QueryAnnotation QueryKind = "annotation"
+)
+
+// Query 대시보드에서 추출한 PromQL 쿼리
+type Query struct {
+	Kind     QueryKind
+	PanelID  int
+	Panel    string
+	RefID    string
+	Expr     string // 원본 식
+	Expanded string // 대시보드 변수를 치환한 식
+}
+
+// Queries 패널 타깃, 변수, 어노테이션의 PromQL 쿼리 목록
+func (d *Dashboard) Queries() []Query {
+	var queries []Query
+	for _, panel := range d.AllPanels() {
+		for _, target := range panel.Targets {
+			if target.Hide || target.Expr == "" {
+				continue
+			}
+			queries = append(queries, Query{
+				Kind: QueryPanel, PanelID: panel.ID, Panel: panel.Title, RefID: target.RefID,
+				Expr: target.Expr, Expanded: d.expandVariables(target.Expr),
+			})
+		}
+	}
+	for _, variable := range d.Templating.List {
+		if variable.Type != "query" {
+			continue
+		}
+		if expr := variableQueryExpr(string(variable.Query)); expr != "" {
+			queries = append(queries, Query{Kind: QueryVariable, Panel: "$" + variable.Name, Expr: expr, Expanded: d.expandVariables(expr)})
+		}
+	}
+	for _, annotation := range d.Annotations.List {
+		if annotation.BuiltIn == 0 && annotation.Expr != "" {
+			queries = append(queries, Query{Kind: QueryAnnotation, Panel: "어노테이션 " + annotation.Name, Expr: annotation.Expr, Expanded: d.expandVariables(annotation.Expr)})
+		}
+	}
+	return queries
+}
+
+// Validate 대시보드 구조, 데이터소스 참조, PromQL 쿼리 검증
+func Validate(dashboard *Dashboard, options ValidateOptions) []Finding {
+	var findings []Finding
+
+	panelIDs := make(map[int]string)
+	for _, panel := range dashboard.AllPanels() {
+		report := func(refID string, check, format string, args ...interface{}) {
+			findings = append(findings, Finding{
+				PanelID: panel.ID, Panel: panel.Title, RefID: refID,
+				Severity: SeverityError, Check: check, Message: fmt.Sprintf(format, args...),
+			})
+		}
+
+		if title, ok := panelIDs[panel.ID]; ok {
+			report("", CheckDuplicatePanelID, "패널 id %d가 %q와 중복됩니다", panel.ID, title)
+		}
+		panelIDs[panel.ID] = panel.Title
+
+		if panel.Type == "row" {
+			continue
+		}
+		if err := dashboard.checkDatasource(panel.Datasource, options); err != nil {
+			report("", CheckDatasource, "%v", err)
+		}
+
+		refIDs := make(map[string]bool)
+		for _, target := range panel.Targets {
+			if refIDs[target.RefID] {
+				report(target.RefID, CheckDuplicateRefID, "refId %q가 중복됩니다", target.RefID)
+			}
+			refIDs[target.RefID] = true
+
+			if target.Datasource != nil {
+				if err := dashboard.checkDatasource(target.Datasource, options); err != nil {
+					report(target.RefID, CheckDatasource, "%v", err)
+				}
+			} else if panel.Datasource != nil && panel.Datasource.Name == mixedDatasource {
+				report(target.RefID, CheckDatasource, "Mixed 패널의 타깃은 데이터소스를 지정해야 합니다")
+			}
+			if !target.Hide && strings.TrimSpace(target.Expr) == "" {
+				report(target.RefID, CheckEmptyQuery, "쿼리가 비어 있습니다")
+			}
+		}
+	}
+
+	for _, variable := range dashboard.Templating.List {
+		if variable.Type == "query" {
+			if err := dashboard.checkDatasource(variable.Datasource, options); err != nil {
+				findings = append(findings, Finding{Panel: "$" + variable.Name, Severity: SeverityError, Check: CheckDatasource, Message: err.Error()})
+			}
+		}
+	}
+
+	for _, query := range dashboard.Queries() {
+		report := func(check, format string, args ...interface{}) {
+			findings = append(findings, Finding{
+				PanelID: query.PanelID, Panel: query.Panel, RefID: query.RefID,
+				Severity: SeverityError, Check: check, Message: fmt.Sprintf(format, args...),
+			})
+		}
+
+		for _, name := range dashboard.undefinedVariables(query.Expr) {
+			report(CheckUndefinedVariable, "정의되지 않은 변수 $%s", name)
+		}
+
+		expr, err := parser.ParseExpr(query.Expanded)
+		if err != nil {
+			report(CheckPromQLParse, "PromQL 파싱 실패: %v", err)
+			continue
+		}
+		for _, matcher := range HardcodedNodeMatchers(expr) {
+			report(CheckHardcodedNode, "노드를 %s로 고정했습니다 (클러스터를 재구성하면 바뀌므로 변수나 kube_node_role 조인 사용)", matcher)
+		}
+		for _, metric := range MetricNames(expr) {
+			if contains(options.ExtraMetrics, metric) {
+				continue
+			}
+			if _, ok := options.Sources.SourceOf(metric); !ok {
+				report(CheckUnknownMetric, "메트릭 %s를 노출하는 구성 요소가 없습니다 (활성화: %s)", metric, strings.Join(options.Sources.Names(), ", "))
+			}
+		}
+	}
+
+	return findings
+}
+
+// Errors 심각도가 error인 항목만 반환
+func Errors(findings []Finding) []Finding {
+	var errs []Finding
+	for _, finding := range findings {
+		if finding.Severity == SeverityError {
+			errs = append(errs, finding)
+		}
+	}
+	return errs
+}
+
+// MetricNames PromQL 식이 참조하는 메트릭 이름 목록 (정렬, 중복 제거)
+func MetricNames(expr parser.Expr) []string {
+	seen := make(map[string]bool)
+	parser.Inspect(expr, func(node parser.Node, _ []parser.Node) error {
+		selector, ok := node.(*parser.VectorSelector)
+		if !ok {
+			return nil
+		}
+		if selector.Name != "" {
+			seen[selector.Name] = true
+			return nil
+		}
+		// {__name__="..."} 형태
+		for _, matcher := range selector.LabelMatchers {
+			if matcher.Name == labels.MetricName && matcher.Type == labels.MatchEqual {
+				seen[matcher.Value] = true
+			}
+		}
+		return nil
+	})
+
+	names := make([]string, 0, len(seen))
+	for name := range seen {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// 노드를 가리키는 레이블
+var nodeLabels = []string{"instance", "node", "nodename", "hostname"}
+
+// HardcodedNodeMatchers 특정 호스트 이름이나 IP로 노드를 고정한 레이블 매처 목록
+func HardcodedNodeMatchers(expr parser.Expr) []string {
+	return promexpr.HardcodedNodeMatchers(expr, nodeLabels...)
+}
+
+func (d *Dashboard) checkDatasource(ref *DatasourceRef, options ValidateOptions) error {
+	if ref == nil {
+		return nil
+	}
+	if ref.Name != "" {
+		switch {
+		case ref.Name == grafanaDatasource || ref.Name == mixedDatasource:
+			return nil
+		case strings.HasPrefix(ref.Name, "$"):
+			name := strings.Trim(strings.TrimPrefix(ref.Name, "$"), "{}")
+			if variable, ok := d.variable(name); ok && variable.Type == "datasource" {
+				return nil
+			}
+			return fmt.Errorf("데이터소스 변수 %s가 정의되지 않았습니다", ref.Name)
+		case contains(options.Datasources, ref.Name):
+			return nil
+		}
+		return fmt.Errorf("알 수 없는 데이터소스 %q (허용: %s)", ref.Name, strings.Join(options.Datasources, ", "))
+	}
+
+	if ref.Type != "" && ref.Type != "prometheus" && ref.Type != "datasource" && ref.Type != "grafana" {
+		return fmt.Errorf("Prometheus 데이터소스가 아닙니다: %s", ref)
+	}
+	if strings.HasPrefix(ref.UID, "$") {
+		return d.checkDatasource(&DatasourceRef{Name: ref.UID}, options)
+	}
+	if ref.UID == "" || ref.Type == "datasource" || ref.Type == "grafana" || contains(options.Datasources, ref.UID) {
+		return nil
+	}
+	return fmt.Errorf("알 수 없는 데이터소스 UID %q (허용: %s)", ref.UID, strings.Join(options.Datasources, ", "))
+}
+
+func (d *Dashboard) variable(name string) (Variable, bool) {
+	for _, variable := range d.Templating.List {
+		if variable.Name == name {
+			return variable, true
+		}
+	}
+	return Variable{}, false
+}
+
+// VariablePlaceholder 변수를 치환할 때 쓰는 대표값 (레이블 값/정규식/식별자 어디서든 유효)
+const VariablePlaceholder = "grafana_variable"
+
+// $var, ${var}, ${var:format}, [[var]] 형식의 변수 참조
+var variableReference = regexp.MustCompile(`\$\{([a-zA-Z_]\w*)(?::[^}]*)?\}|\$([a-zA-Z_]\w*)|\[\[([a-zA-Z_]\w*)(?::[^\]]*)?\]\]`)
+
+func variableName(match []string) string {
+	for _, group := range match[1:] {
+		if group != "" {
+			return group
+		}
+	}
+	return ""
+}
+
+// PromQL 파싱이 가능하도록 변수를 대표값으로 치환
+//
+// 기간 변수는 5m, 나머지는 VariablePlaceholder로 바꿉니다.
+func (d *Dashboard) expandVariables(expr string) string {
+	return variableReference.ReplaceAllStringFunc(expr, func(reference string) string {
+		name := variableName(variableReference.FindStringSubmatch(reference))
+		if strings.HasPrefix(name, "__") {
+			if strings.HasSuffix(name, "_ms") || strings.HasSuffix(name, "_s") {
+				return "300"
+			}
+			return "5m"
+		}
+		if variable, ok := d.variable(name); ok && variable.Type == "interval" {
+			return "5m"
+		}
+		return VariablePlaceholder
+	})
+}
+
+func (d *Dashboard) undefinedVariables(expr string) []string {
+	var undefined []string
+	for _, match := range variableReference.FindAllStringSubmatch(expr, -1) {
+		name := variableName(match)
+		if strings.HasPrefix(name, "__") {
+			continue
+		}
+		if _, ok := d.variable(name); !ok && !contains(undefined, name) {
+			undefined = append(undefined, name)
+		}
+	}
+	return undefined
+}
+
+var (
+	labelValuesQuery = regexp.MustCompile(`^\s*label_values\(\s*(.+)\s*,\s*[a-zA-Z_]\w*\s*\)\s*$`)
+	queryResultQuery = regexp.MustCompile(`^\s*query_result\((.+)\)\s*$`)
+)
+
+// 변수 쿼리에서 PromQL 식 추출 (label_values(selector, label), query_result(expr))
+func variableQueryExpr(query string) string {
+	if match := labelValuesQuery.FindStringSubmatch(query); match != nil {
+		return match[1]
+	}
+	if match := queryResultQuery.FindStringSubmatch(query); match != nil {
+		return match[1]
+	}
+	return ""
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,99 @@
+package helmvalues
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+)
+
+//go:embed kube-prometheus-stack.schema.json
+var kubePrometheusStackSchema []byte
+
+// Schema JSON 스키마 (draft-07) 중 values 검증에 필요한 부분
+//
+// 지원 키워드: type, properties, additionalProperties, items, enum,
+// minimum, maximum, pattern, format, $ref(#/definitions/...)
+type Schema struct {
+	Ref                  string             `json:"$ref,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Description          string             `json:"description,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	AdditionalProperties *bool              `json:"additionalProperties,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	Enum                 []interface{}      `json:"enum,omitempty"`
+	Minimum              *float64           `json:"minimum,omitempty"`
+	Maximum              *float64           `json:"maximum,omitempty"`
+	Pattern              string             `json:"pattern,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Definitions          map[string]*Schema `json:"definitions,omitempty"`
+}
+
+// ParseSchema JSON 스키마를 파싱하고 $ref를 해석
+func ParseSchema(data []byte) (*Schema, error) {
+	var schema Schema
+	if err := json.Unmarshal(data, &schema); err != nil {
+		return nil, fmt.Errorf("스키마 파싱 실패: %w", err)
+	}
+	if err := schema.resolve(schema.Definitions, nil); err != nil {
+		return nil, err
+	}
+	return &schema, nil
+}
+
+// LoadSchema 파일에서 JSON 스키마 로드
+func LoadSchema(path string) (*Schema, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return ParseSchema(data)
+}
+
+// KubePrometheusStackSchema 저장소에서 사용하는 kube-prometheus-stack 키의 내장 스키마
+func KubePrometheusStackSchema() *Schema {
+	schema, err := ParseSchema(kubePrometheusStackSchema)
+	if err != nil {
+		panic(err)
+	}
+	return schema
+}
+
+// 순환 참조를 막기 위해 해석 중인 참조를 추적
+func (s *Schema) resolve(definitions map[string]*Schema, resolving []string) error {
+	if s.Ref != "" {
+		name := strings.TrimPrefix(s.Ref, "#/definitions/")
+		if name == s.Ref {
+			return fmt.Errorf("지원하지 않는 $ref: %s", s.Ref)
+		}
+		for _, r := range resolving {
+			if r == name {
+				return fmt.Errorf("순환 $ref: %s", s.Ref)
+			}
+		}
+		target, ok := definitions[name]
+		if !ok {
+			return fmt.Errorf("정의되지 않은 $ref: %s", s.Ref)
+		}
+		if err := target.resolve(definitions, append(resolving, name)); err != nil {
+			return err
+		}
+		*s = *target
+		return nil
+	}
+	for _, property := range s.Properties {
+		if err := property.resolve(definitions, resolving); err != nil {
+			return err
+		}
+	}
+	if s.Items != nil {
+		return s.Items.resolve(definitions, resolving)
+	}
+	return nil
+}
+
+// 알 수 없는 키를 허용하지 않는 객체인지
+func (s *Schema) closed() bool {
+	return s.AdditionalProperties != nil && !*s.AdditionalProperties
+}
@@ -0,0 +1,317 @@
+package helmvalues
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/agext/levenshtein"
+	"gopkg.in/yaml.v3"
+)
+
+// Severity 검증 결과 심각도
+type Severity string
+
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+)
+
+// 검증 항목 종류
+const (
+	CheckParse           = "parse"
+	CheckUnknownKey      = "unknown-key"
+	CheckType            = "type"
+	CheckEnum            = "enum"
+	CheckPattern         = "pattern"
+	CheckRange           = "range"
+	CheckNodePortRange   = "nodeport-range"
+	CheckInsecureDefault = "insecure-default"
+	CheckNoPersistence   = "no-persistence"
+)
+
+// 차트가 기본으로 제공하는 Grafana 관리자 비밀번호
+const chartDefaultAdminPassword = "prom-operator"
+
+// Finding 검증 결과 항목
+type Finding struct {
+	Path     string // values 파일 경로
+	Line     int
+	Key      string // 점으로 구분한 키 경로 (예: grafana.service.nodePort)
+	Severity Severity
+	Check    string
+	Message  string
+}
+
+func (f Finding) String() string {
+	return fmt.Sprintf("%s:%d: [%s] %s (%s): %s", f.Path, f.Line, f.Severity, f.Key, f.Check, f.Message)
+}
+
+// ValidateFile values 파일을 스키마와 보안 기본값 규칙으로 검증
+func ValidateFile(path string, schema *Schema) ([]Finding, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return Validate(path, data, schema), nil
+}
+
+// Validate values YAML을 스키마와 보안 기본값 규칙으로 검증
+//
+// YAML 파싱에 실패하면 parse 항목 하나만 반환합니다.
+func Validate(path string, data []byte, schema *Schema) []Finding {
+	var document yaml.Node
+	if err := yaml.Unmarshal(data, &document); err != nil {
+		return []Finding{{Path: path, Line: parseErrorLine(err), Severity: SeverityError, Check: CheckParse, Message: err.Error()}}
+	}
+	if len(document.Content) == 0 {
+		return nil
+	}
+	root := document.Content[0]
+
+	v := &validator{path: path}
+	v.walk(root, schema, "")
+	v.checkInsecureDefaults(root)
+
+	sort.SliceStable(v.findings, func(i, j int) bool { return v.findings[i].Line < v.findings[j].Line })
+	return v.findings
+}
+
+// Errors 심각도가 error인 항목만 반환
+func Errors(findings []Finding) []Finding {
+	var errs []Finding
+	for _, finding := range findings {
+		if finding.Severity == SeverityError {
+			errs = append(errs, finding)
+		}
+	}
+	return errs
+}
+
+type validator struct {
+	path     string
+	findings []Finding
+}
+
+func (v *validator) report(node *yaml.Node, key string, severity Severity, check, format string, args ...interface{}) {
+	v.findings = append(v.findings, Finding{
+		Path: v.path, Line: node.Line, Key: key,
+		Severity: severity, Check: check, Message: fmt.Sprintf(format, args...),
+	})
+}
+
+func (v *validator) walk(node *yaml.Node, schema *Schema, key string) {
+	if node.Kind == yaml.AliasNode {
+		node = node.Alias
+	}
+	// null은 차트 기본값을 지우는 용도로 어디서나 허용
+	if node.Kind == yaml.ScalarNode && node.Tag == "!!null" {
+		return
+	}
+	if schema.Type != "" && !matchesType(node, schema.Type) {
+		v.report(node, key, SeverityError, CheckType, "%s 타입이어야 하지만 %s입니다", schema.Type, describeNode(node))
+		return
+	}
+
+	switch node.Kind {
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			keyNode, valueNode := node.Content[i], node.Content[i+1]
+			childKey := joinKey(key, keyNode.Value)
+			child, ok := schema.Properties[keyNode.Value]
+			if !ok {
+				if schema.closed() {
+					message := "스키마에 없는 키입니다"
+					if suggestion := closestKey(keyNode.Value, schema.Properties); suggestion != "" {
+						message += fmt.Sprintf(" (%s의 오타?)", joinKey(key, suggestion))
+					}
+					v.report(keyNode, childKey, SeverityError, CheckUnknownKey, "%s", message)
+				}
+				continue
+			}
+			v.walk(valueNode, child, childKey)
+		}
+	case yaml.SequenceNode:
+		if schema.Items != nil {
+			for i, item := range node.Content {
+				v.walk(item, schema.Items, fmt.Sprintf("%s[%d]", key, i))
+			}
+		}
+	case yaml.ScalarNode:
+		v.checkScalar(node, schema, key)
+	}
+}
+
+func (v *validator) checkScalar(node *yaml.Node, schema *Schema, key string) {
+	if len(schema.Enum) > 0 {
+		var allowed []string
+		found := false
+		for _, value := range schema.Enum {
+			allowed = append(allowed, fmt.Sprint(value))
+			if fmt.Sprint(value) == node.Value {
+				found = true
+			}
+		}
+		if !found {
+			v.report(node, key, SeverityError, CheckEnum, "%q는 허용되지 않습니다 (허용: %s)", node.Value, strings.Join(allowed, ", "))
+		}
+	}
+
+	if schema.Pattern != "" {
+		pattern, err := regexp.Compile(schema.Pattern)
+		if err != nil {
+			v.report(node, key, SeverityError, CheckPattern, "스키마 pattern 오류: %v", err)
+		} else if !pattern.MatchString(node.Value) {
+			v.report(node, key, SeverityError, CheckPattern, "%q가 형식 %s와 맞지 않습니다", node.Value, schema.Pattern)
+		}
+	}
+
+	if schema.Minimum == nil && schema.Maximum == nil {
+		return
+	}
+	number, err := strconv.ParseFloat(node.Value, 64)
+	if err != nil {
+		return
+	}
+	if (schema.Minimum != nil && number < *schema.Minimum) || (schema.Maximum != nil && number > *schema.Maximum) {
+		check := CheckRange
+		if schema.Format == "nodeport" {
+			check = CheckNodePortRange
+		}
+		v.report(node, key, SeverityError, check, "%s가 허용 범위(%s)를 벗어났습니다", node.Value, describeRange(schema))
+	}
+}
+
+// 평문 관리자 비밀번호와 영속 스토리지 누락 검사
+func (v *validator) checkInsecureDefaults(root *yaml.Node) {
+	if password, ok := lookup(root, "grafana", "adminPassword"); ok && password.Value != "" {
+		existingSecret, _ := lookup(root, "grafana", "admin", "existingSecret")
+		if existingSecret == nil || existingSecret.Value == "" {
+			if password.Value == chartDefaultAdminPassword {
+				v.report(password, "grafana.adminPassword", SeverityError, CheckInsecureDefault,
+					"차트 기본 비밀번호(%s)를 그대로 사용합니다. grafana.admin.existingSecret을 사용하세요", chartDefaultAdminPassword)
+			} else {
+				v.report(password, "grafana.adminPassword", SeverityError, CheckInsecureDefault,
+					"관리자 비밀번호가 평문으로 저장되어 있습니다. grafana.admin.existingSecret을 사용하세요")
+			}
+		}
+	}
+
+	storages := []struct {
+		path    []string
+		message string
+	}{
+		{[]string{"prometheus", "prometheusSpec", "storageSpec"}, "Prometheus 메트릭이 Pod 재시작 시 사라집니다"},
+		{[]string{"alertmanager", "alertmanagerSpec", "storage"}, "Alertmanager 사일런스/알림 상태가 Pod 재시작 시 사라집니다"},
+	}
+	for _, storage := range storages {
+		node, ok := lookup(root, storage.path...)
+		if !ok {
+			node = root
+		}
+		if !ok || (node.Kind == yaml.MappingNode && len(node.Content) == 0) {
+			v.report(node, strings.Join(storage.path, "."), SeverityWarning, CheckNoPersistence, "영속 스토리지가 없습니다: %s", storage.message)
+		}
+	}
+
+	if enabled, ok := lookup(root, "grafana", "persistence", "enabled"); !ok || enabled.Value != "true" {
+		node := enabled
+		if !ok {
+			node = root
+		}
+		v.report(node, "grafana.persistence.enabled", SeverityWarning, CheckNoPersistence, "영속 스토리지가 없습니다: UI에서 만든 대시보드와 설정이 Pod 재시작 시 사라집니다")
+	}
+}
+
+// 매핑 노드에서 키 경로를 따라 값 노드 조회
+func lookup(node *yaml.Node, path ...string) (*yaml.Node, bool) {
+	for _, key := range path {
+		if node.Kind != yaml.MappingNode {
+			return nil, false
+		}
+		var next *yaml.Node
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if node.Content[i].Value == key {
+				next = node.Content[i+1]
+			}
+		}
+		if next == nil {
+			return nil, false
+		}
+		node = next
+	}
+	return node, true
+}
+
+func matchesType(node *yaml.Node, want string) bool {
+	switch want {
+	case "object":
+		return node.Kind == yaml.MappingNode
+	case "array":
+		return node.Kind == yaml.SequenceNode
+	case "string":
+		return node.Kind == yaml.ScalarNode && node.Tag == "!!str"
+	case "integer":
+		return node.Kind == yaml.ScalarNode && node.Tag == "!!int"
+	case "number":
+		return node.Kind == yaml.ScalarNode && (node.Tag == "!!int" || node.Tag == "!!float")
+	case "boolean":
+		return node.Kind == yaml.ScalarNode && node.Tag == "!!bool"
+	}
+	return true
+}
+
+func describeNode(node *yaml.Node) string {
+	switch node.Kind {
+	case yaml.MappingNode:
+		return "object"
+	case yaml.SequenceNode:
+		return "array"
+	}
+	return fmt.Sprintf("%s(%q)", strings.TrimPrefix(node.Tag, "!!"), node.Value)
+}
+
+func describeRange(schema *Schema) string {
+	format := func(p *float64) string {
+		if p == nil {
+			return ""
+		}
+		return strconv.FormatFloat(*p, 'f', -1, 64)
+	}
+	return format(schema.Minimum) + "-" + format(schema.Maximum)
+}
+
+func joinKey(prefix, key string) string {
+	if prefix == "" {
+		return key
+	}
+	return prefix + "." + key
+}
+
+// 편집 거리가 가장 가까운 키 (오타로 볼 수 있을 때만)
+func closestKey(key string, properties map[string]*Schema) string {
+	best, bestDistance := "", -1
+	for candidate := range properties {
+		distance := levenshtein.Distance(strings.ToLower(key), strings.ToLower(candidate), nil)
+		if bestDistance < 0 || distance < bestDistance || (distance == bestDistance && candidate < best) {
+			best, bestDistance = candidate, distance
+		}
+	}
+	if bestDistance < 0 || bestDistance > len(key)/3+1 {
+		return ""
+	}
+	return best
+}
+
+var yamlErrorLine = regexp.MustCompile(`line (\d+)`)
+
+func parseErrorLine(err error) int {
+	if match := yamlErrorLine.FindStringSubmatch(err.Error()); match != nil {
+		line, _ := strconv.Atoi(match[1])
+		return line
+	}
+	return 0
+}
@@ -0,0 +1,142 @@
+package iampolicy
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Severity 감사 항목 심각도
+type Severity string
+
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+)
+
+// 감사 항목
+const (
+	// CheckAdminAccess 모든 리소스에 모든 작업 허용
+	CheckAdminAccess = "admin-access"
+	// CheckServiceWildcard 모든 리소스에 서비스 전체 작업(svc:*) 허용
+	CheckServiceWildcard = "service-wildcard"
+	// CheckNotActionAllow Allow + NotAction (나열한 것 외 전부 허용)
+	CheckNotActionAllow = "not-action-allow"
+	// CheckWildcardPrincipal 조건 없이 누구나 역할을 위임받을 수 있음
+	CheckWildcardPrincipal = "wildcard-principal"
+	// CheckEmptyPrincipal 아무도 위임받을 수 없는 신뢰 정책
+	CheckEmptyPrincipal = "empty-principal"
+)
+
+// Finding 감사 항목
+type Finding struct {
+	Policy   string
+	Sid      string
+	Check    string
+	Severity Severity
+	Message  string
+}
+
+func (f Finding) String() string {
+	sid := ""
+	if f.Sid != "" {
+		sid = " (" + f.Sid + ")"
+	}
+	return fmt.Sprintf("%s%s: [%s] %s: %s", f.Policy, sid, f.Severity, f.Check, f.Message)
+}
+
+// Errors 오류 심각도 항목만
+func Errors(findings []Finding) []Finding {
+	var errors []Finding
+	for _, finding := range findings {
+		if finding.Severity == SeverityError {
+			errors = append(errors, finding)
+		}
+	}
+	return errors
+}
+
+// Audit 권한 정책에서 최소 권한 원칙을 벗어나는 Allow 문장 검사
+func Audit(document *Document) []Finding {
+	var findings []Finding
+	add := func(statement Statement, check string, severity Severity, format string, args ...interface{}) {
+		findings = append(findings, Finding{
+			Policy:   document.Name,
+			Sid:      statement.Sid,
+			Check:    check,
+			Severity: severity,
+			Message:  fmt.Sprintf(format, args...),
+		})
+	}
+
+	for _, statement := range document.Statement {
+		if statement.Effect != EffectAllow || statement.Principal != nil {
+			continue
+		}
+		allResources := len(statement.NotResource) == 0 && contains(statement.Resource, "*")
+		if len(statement.NotAction) > 0 {
+			add(statement, CheckNotActionAllow, SeverityWarning, "NotAction %s 외의 모든 작업을 허용합니다", strings.Join(statement.NotAction, ", "))
+			continue
+		}
+		if !allResources || len(statement.Condition) > 0 {
+			continue
+		}
+		for _, action := range statement.Action {
+			switch {
+			case action == "*":
+				add(statement, CheckAdminAccess, SeverityError, "모든 리소스에 모든 작업(*)을 허용합니다")
+			case strings.HasSuffix(action, ":*"):
+				add(statement, CheckServiceWildcard, SeverityWarning, "모든 리소스에 %s를 허용합니다", action)
+			}
+		}
+	}
+	return findings
+}
+
+// AuditTrust 신뢰 정책에서 위임 대상이 너무 넓거나 비어 있는 문장 검사
+func AuditTrust(document *Document) []Finding {
+	var findings []Finding
+	for _, statement := range document.Statement {
+		if statement.Effect != EffectAllow {
+			continue
+		}
+		finding := Finding{Policy: document.Name, Sid: statement.Sid}
+		switch {
+		case principalIsWildcard(statement.Principal) && len(statement.Condition) == 0:
+			finding.Check, finding.Severity = CheckWildcardPrincipal, SeverityError
+			finding.Message = "조건 없이 모든 주체(*)가 역할을 위임받을 수 있습니다"
+		case principalCount(statement.Principal) == 0 && statement.NotPrincipal == nil:
+			finding.Check, finding.Severity = CheckEmptyPrincipal, SeverityWarning
+			finding.Message = "Principal이 비어 있어 아무도 역할을 위임받을 수 없습니다"
+		default:
+			continue
+		}
+		findings = append(findings, finding)
+	}
+	return findings
+}
+
+func principalIsWildcard(principals Principals) bool {
+	for principalType, values := range principals {
+		if principalType == "*" || (principalType == "AWS" && contains(values, "*")) {
+			return true
+		}
+	}
+	return false
+}
+
+func principalCount(principals Principals) int {
+	count := 0
+	for _, values := range principals {
+		count += len(values)
+	}
+	return count
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,253 @@
+package iampolicy
+
+import (
+	"fmt"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// conditionOperator ForAnyValue:/ForAllValues: 접두사와 IfExists 접미사를 분리한 조건 연산자
+type conditionOperator struct {
+	name      string
+	set       string // "", "ForAnyValue", "ForAllValues"
+	ifExists  bool
+	negated   bool
+	matchFunc func(policyValue, contextValue string) (bool, error)
+}
+
+// 긍정 연산자 → 비교 함수 (부정 연산자는 negatedOperators로 매핑)
+var conditionMatchers = map[string]func(policyValue, contextValue string) (bool, error){
+	"StringEquals":             func(p, c string) (bool, error) { return p == c, nil },
+	"StringEqualsIgnoreCase":   func(p, c string) (bool, error) { return strings.EqualFold(p, c), nil },
+	"StringLike":               func(p, c string) (bool, error) { return matchWildcard(p, c, false), nil },
+	"ArnEquals":                func(p, c string) (bool, error) { return matchWildcard(p, c, false), nil },
+	"ArnLike":                  func(p, c string) (bool, error) { return matchWildcard(p, c, false), nil },
+	"Bool":                     func(p, c string) (bool, error) { return strings.EqualFold(p, c), nil },
+	"BinaryEquals":             func(p, c string) (bool, error) { return p == c, nil },
+	"IpAddress":                ipMatches,
+	"NumericEquals":            numericMatcher(func(p, c float64) bool { return c == p }),
+	"NumericLessThan":          numericMatcher(func(p, c float64) bool { return c < p }),
+	"NumericLessThanEquals":    numericMatcher(func(p, c float64) bool { return c <= p }),
+	"NumericGreaterThan":       numericMatcher(func(p, c float64) bool { return c > p }),
+	"NumericGreaterThanEquals": numericMatcher(func(p, c float64) bool { return c >= p }),
+	"DateEquals":               dateMatcher(func(p, c time.Time) bool { return c.Equal(p) }),
+	"DateLessThan":             dateMatcher(func(p, c time.Time) bool { return c.Before(p) }),
+	"DateLessThanEquals":       dateMatcher(func(p, c time.Time) bool { return !c.After(p) }),
+	"DateGreaterThan":          dateMatcher(func(p, c time.Time) bool { return c.After(p) }),
+	"DateGreaterThanEquals":    dateMatcher(func(p, c time.Time) bool { return !c.Before(p) }),
+}
+
+// 부정 연산자 → 긍정 연산자
+var negatedOperators = map[string]string{
+	"StringNotEquals":           "StringEquals",
+	"StringNotEqualsIgnoreCase": "StringEqualsIgnoreCase",
+	"StringNotLike":             "StringLike",
+	"ArnNotEquals":              "ArnEquals",
+	"ArnNotLike":                "ArnLike",
+	"NotIpAddress":              "IpAddress",
+	"NumericNotEquals":          "NumericEquals",
+	"DateNotEquals":             "DateEquals",
+}
+
+func parseConditionOperator(raw string) (conditionOperator, error) {
+	operator := conditionOperator{name: raw}
+	name := raw
+	if set, rest, ok := strings.Cut(name, ":"); ok {
+		if set != "ForAnyValue" && set != "ForAllValues" {
+			return operator, fmt.Errorf("지원하지 않는 조건 집합 연산자: %s", raw)
+		}
+		operator.set, name = set, rest
+	}
+	if trimmed := strings.TrimSuffix(name, "IfExists"); trimmed != name {
+		operator.ifExists, name = true, trimmed
+	}
+	if positive, ok := negatedOperators[name]; ok {
+		operator.negated, name = true, positive
+	}
+	matcher, ok := conditionMatchers[name]
+	if !ok {
+		return operator, fmt.Errorf("지원하지 않는 조건 연산자: %s", raw)
+	}
+	operator.matchFunc = matcher
+	return operator, nil
+}
+
+// 연산자끼리, 키끼리는 AND, 한 키의 값끼리는 OR
+func conditionsMatch(conditions Conditions, context map[string][]string) (bool, error) {
+	for _, rawOperator := range sortedKeys(conditions) {
+		for key, policyValues := range conditions[rawOperator] {
+			contextValues, present := lookupContext(context, key)
+			var matched bool
+			var err error
+			if rawOperator == "Null" {
+				matched, err = nullMatches(policyValues, present)
+			} else {
+				var operator conditionOperator
+				operator, err = parseConditionOperator(rawOperator)
+				if err == nil {
+					matched, err = operator.matches(policyValues, contextValues, present)
+				}
+			}
+			if err != nil {
+				return false, err
+			}
+			if !matched {
+				return false, nil
+			}
+		}
+	}
+	return true, nil
+}
+
+func (o conditionOperator) matches(policyValues, contextValues []string, present bool) (bool, error) {
+	if !present {
+		switch {
+		case o.ifExists, o.set == "ForAllValues":
+			return true, nil
+		case o.set == "ForAnyValue":
+			return false, nil
+		default:
+			// 키가 없으면 일치하는 값도 없으므로 부정 연산자는 참
+			return o.negated, nil
+		}
+	}
+
+	// 요청 값 하나가 조건을 만족하는지 (부정이면 어떤 정책 값과도 일치하지 않아야 함)
+	satisfies := func(contextValue string) (bool, error) {
+		for _, policyValue := range policyValues {
+			matched, err := o.matchFunc(policyValue, contextValue)
+			if err != nil {
+				return false, fmt.Errorf("%s: %w", o.name, err)
+			}
+			if matched {
+				return !o.negated, nil
+			}
+		}
+		return o.negated, nil
+	}
+
+	switch o.set {
+	case "ForAllValues":
+		for _, value := range contextValues {
+			ok, err := satisfies(value)
+			if err != nil || !ok {
+				return false, err
+			}
+		}
+		return true, nil
+	case "ForAnyValue":
+		for _, value := range contextValues {
+			ok, err := satisfies(value)
+			if err != nil || ok {
+				return ok, err
+			}
+		}
+		return false, nil
+	default:
+		// 단일 값 키: 긍정은 하나라도 일치하면, 부정은 모든 값이 불일치해야 참
+		for _, value := range contextValues {
+			ok, err := satisfies(value)
+			if err != nil {
+				return false, err
+			}
+			if ok && !o.negated {
+				return true, nil
+			}
+			if !ok && o.negated {
+				return false, nil
+			}
+		}
+		return o.negated, nil
+	}
+}
+
+// Null: "true"는 키가 없어야, "false"는 키가 있어야 참
+func nullMatches(policyValues []string, present bool) (bool, error) {
+	for _, value := range policyValues {
+		expectAbsent, err := strconv.ParseBool(value)
+		if err != nil {
+			return false, fmt.Errorf("Null 조건 값은 true/false여야 합니다: %q", value)
+		}
+		if expectAbsent != present {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func lookupContext(context map[string][]string, key string) ([]string, bool) {
+	for contextKey, values := range context {
+		if strings.EqualFold(contextKey, key) {
+			return values, true
+		}
+	}
+	return nil, false
+}
+
+func ipMatches(policyValue, contextValue string) (bool, error) {
+	ip := net.ParseIP(contextValue)
+	if ip == nil {
+		return false, nil
+	}
+	if !strings.Contains(policyValue, "/") {
+		return ip.Equal(net.ParseIP(policyValue)), nil
+	}
+	_, network, err := net.ParseCIDR(policyValue)
+	if err != nil {
+		return false, fmt.Errorf("잘못된 CIDR: %q", policyValue)
+	}
+	return network.Contains(ip), nil
+}
+
+func numericMatcher(compare func(policyValue, contextValue float64) bool) func(string, string) (bool, error) {
+	return func(policyValue, contextValue string) (bool, error) {
+		p, err := strconv.ParseFloat(policyValue, 64)
+		if err != nil {
+			return false, fmt.Errorf("숫자가 아닌 조건 값: %q", policyValue)
+		}
+		c, err := strconv.ParseFloat(contextValue, 64)
+		if err != nil {
+			return false, nil
+		}
+		return compare(p, c), nil
+	}
+}
+
+func dateMatcher(compare func(policyValue, contextValue time.Time) bool) func(string, string) (bool, error) {
+	return func(policyValue, contextValue string) (bool, error) {
+		p, err := parseDate(policyValue)
+		if err != nil {
+			return false, fmt.Errorf("날짜가 아닌 조건 값: %q", policyValue)
+		}
+		c, err := parseDate(contextValue)
+		if err != nil {
+			return false, nil
+		}
+		return compare(p, c), nil
+	}
+}
+
+// RFC 3339 또는 epoch 초
+func parseDate(value string) (time.Time, error) {
+	if seconds, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return time.Unix(seconds, 0).UTC(), nil
+	}
+	for _, layout := range []string{time.RFC3339, "2006-01-02"} {
+		if parsed, err := time.Parse(layout, value); err == nil {
+			return parsed, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("날짜 형식이 아닙니다: %q", value)
+}
+
+func sortedKeys(conditions Conditions) []string {
+	keys := make([]string, 0, len(conditions))
+	for key := range conditions {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,160 @@
+package iampolicy
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+// Effect 문장 효과
+type Effect string
+
+const (
+	EffectAllow Effect = "Allow"
+	EffectDeny  Effect = "Deny"
+)
+
+// Strings 문자열 하나 또는 목록으로 쓸 수 있는 정책 값 (숫자/불리언은 문자열로 보관)
+type Strings []string
+
+// UnmarshalJSON Strings 디코딩
+func (s *Strings) UnmarshalJSON(data []byte) error {
+	var raw interface{}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	values, err := stringValues(raw)
+	if err != nil {
+		return err
+	}
+	*s = values
+	return nil
+}
+
+func stringValues(raw interface{}) ([]string, error) {
+	switch raw := raw.(type) {
+	case nil:
+		return nil, nil
+	case []interface{}:
+		values := make([]string, 0, len(raw))
+		for _, item := range raw {
+			value, err := scalarString(item)
+			if err != nil {
+				return nil, err
+			}
+			values = append(values, value)
+		}
+		return values, nil
+	default:
+		value, err := scalarString(raw)
+		if err != nil {
+			return nil, err
+		}
+		return []string{value}, nil
+	}
+}
+
+func scalarString(raw interface{}) (string, error) {
+	switch raw := raw.(type) {
+	case string:
+		return raw, nil
+	case bool:
+		return strconv.FormatBool(raw), nil
+	case float64:
+		return strconv.FormatFloat(raw, 'f', -1, 64), nil
+	default:
+		return "", fmt.Errorf("정책 값은 문자열, 숫자, 불리언 또는 그 목록이어야 합니다: %v", raw)
+	}
+}
+
+// Principals 주체 종류(AWS, Service, Federated, CanonicalUser)별 값
+//
+// "Principal": "*"는 {"*": ["*"]}로 보관합니다.
+type Principals map[string]Strings
+
+// UnmarshalJSON Principals 디코딩
+func (p *Principals) UnmarshalJSON(data []byte) error {
+	var wildcard string
+	if err := json.Unmarshal(data, &wildcard); err == nil {
+		if wildcard != "*" {
+			return fmt.Errorf("문자열 Principal은 \"*\"만 허용됩니다: %q", wildcard)
+		}
+		*p = Principals{"*": Strings{"*"}}
+		return nil
+	}
+	var values map[string]Strings
+	if err := json.Unmarshal(data, &values); err != nil {
+		return err
+	}
+	*p = values
+	return nil
+}
+
+// Conditions 조건 연산자 → 조건 키 → 값
+type Conditions map[string]map[string]Strings
+
+// Statement 정책 문장
+type Statement struct {
+	Sid          string     `json:"Sid,omitempty"`
+	Effect       Effect     `json:"Effect"`
+	Principal    Principals `json:"Principal,omitempty"`
+	NotPrincipal Principals `json:"NotPrincipal,omitempty"`
+	Action       Strings    `json:"Action,omitempty"`
+	NotAction    Strings    `json:"NotAction,omitempty"`
+	Resource     Strings    `json:"Resource,omitempty"`
+	NotResource  Strings    `json:"NotResource,omitempty"`
+	Condition    Conditions `json:"Condition,omitempty"`
+}
+
+// Statements 문장 하나 또는 목록
+type Statements []Statement
+
+// UnmarshalJSON Statements 디코딩
+func (s *Statements) UnmarshalJSON(data []byte) error {
+	var statement Statement
+	if err := json.Unmarshal(data, &statement); err == nil {
+		*s = Statements{statement}
+		return nil
+	}
+	var statements []Statement
+	if err := json.Unmarshal(data, &statements); err != nil {
+		return err
+	}
+	*s = statements
+	return nil
+}
+
+// Document IAM 정책 문서
+type Document struct {
+	// Name 정책을 구분하는 이름 (관리형 정책 ARN, Terraform 리소스 주소 등)
+	Name      string     `json:"-"`
+	Version   string     `json:"Version"`
+	Statement Statements `json:"Statement"`
+}
+
+// Parse JSON 정책 문서 파싱
+func Parse(name string, data []byte) (*Document, error) {
+	document := &Document{Name: name}
+	if err := json.Unmarshal(data, document); err != nil {
+		return nil, fmt.Errorf("%s: 정책 문서 파싱 실패: %w", name, err)
+	}
+	for i, statement := range document.Statement {
+		if statement.Effect != EffectAllow && statement.Effect != EffectDeny {
+			return nil, fmt.Errorf("%s: 문장 %d의 Effect가 Allow/Deny가 아닙니다: %q", name, i, statement.Effect)
+		}
+		if (len(statement.Action) == 0) == (len(statement.NotAction) == 0) {
+			return nil, fmt.Errorf("%s: 문장 %d에는 Action과 NotAction 중 하나만 있어야 합니다", name, i)
+		}
+	}
+	return document, nil
+}
+
+// ParseURLEncoded IAM API가 돌려주는 URL 인코딩된 정책 문서 파싱
+func ParseURLEncoded(name, encoded string) (*Document, error) {
+	decoded, err := url.QueryUnescape(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("%s: 정책 문서 디코딩 실패: %w", name, err)
+	}
+	return Parse(name, []byte(decoded))
+}
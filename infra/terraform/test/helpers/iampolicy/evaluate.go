@@ -0,0 +1,192 @@
+package iampolicy
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Decision 평가 결과
+type Decision string
+
+const (
+	DecisionAllow        Decision = "Allow"
+	DecisionExplicitDeny Decision = "ExplicitDeny"
+	DecisionImplicitDeny Decision = "ImplicitDeny"
+)
+
+// Principal 요청 주체
+type Principal struct {
+	// Type AWS, Service, Federated 중 하나
+	Type string
+	// ID AWS는 ARN, Service는 서비스 주체(ec2.amazonaws.com)
+	ID string
+}
+
+// Request 평가할 요청
+type Request struct {
+	// Principal 신뢰 정책(Principal이 있는 문장)을 평가할 때만 사용
+	Principal *Principal
+	Action    string
+	Resource  string
+	// Context 조건 키별 값 (키는 대소문자 구분 없음, 예: aws:SourceIp, aws:RequestTag/Project)
+	Context map[string][]string
+}
+
+// Match 요청과 일치한 문장
+type Match struct {
+	Policy    string
+	Statement Statement
+}
+
+// Result 평가 결과와 결정에 쓰인 문장
+type Result struct {
+	Decision Decision
+	// Matches 결정에 영향을 준 문장 (ExplicitDeny면 Deny 문장만)
+	Matches []Match
+}
+
+// Allowed 허용 여부
+func (r Result) Allowed() bool {
+	return r.Decision == DecisionAllow
+}
+
+// Evaluate 정책 문서들을 합쳐 요청을 평가
+//
+// 명시적 Deny가 하나라도 있으면 거부, 없고 Allow가 있으면 허용, 둘 다 없으면 암시적 거부입니다.
+// SCP, 권한 경계, 세션 정책, 리소스 기반 정책과의 교차 계정 평가는 다루지 않습니다.
+// 지원하지 않는 조건 연산자가 일치 여부를 좌우하면 오류를 돌려줍니다.
+func Evaluate(request Request, documents ...*Document) (Result, error) {
+	var allows, denies []Match
+	for _, document := range documents {
+		if document == nil {
+			continue
+		}
+		for _, statement := range document.Statement {
+			matched, err := statementMatches(statement, request)
+			if err != nil {
+				return Result{}, err
+			}
+			if !matched {
+				continue
+			}
+			match := Match{Policy: document.Name, Statement: statement}
+			if statement.Effect == EffectDeny {
+				denies = append(denies, match)
+			} else {
+				allows = append(allows, match)
+			}
+		}
+	}
+	switch {
+	case len(denies) > 0:
+		return Result{Decision: DecisionExplicitDeny, Matches: denies}, nil
+	case len(allows) > 0:
+		return Result{Decision: DecisionAllow, Matches: allows}, nil
+	default:
+		return Result{Decision: DecisionImplicitDeny}, nil
+	}
+}
+
+func statementMatches(statement Statement, request Request) (bool, error) {
+	if !principalMatches(statement, request.Principal) {
+		return false, nil
+	}
+	if len(statement.NotAction) > 0 {
+		if matchesAny(statement.NotAction, request.Action, true) {
+			return false, nil
+		}
+	} else if !matchesAny(statement.Action, request.Action, true) {
+		return false, nil
+	}
+	// 신뢰 정책은 Resource가 없음 (역할 자체가 리소스)
+	switch {
+	case len(statement.NotResource) > 0:
+		if matchesAny(statement.NotResource, request.Resource, false) {
+			return false, nil
+		}
+	case len(statement.Resource) > 0:
+		if !matchesAny(statement.Resource, request.Resource, false) {
+			return false, nil
+		}
+	}
+	return conditionsMatch(statement.Condition, request.Context)
+}
+
+func principalMatches(statement Statement, principal *Principal) bool {
+	if statement.Principal == nil && statement.NotPrincipal == nil {
+		return true
+	}
+	if principal == nil {
+		return false
+	}
+	if statement.NotPrincipal != nil {
+		return !principalListed(statement.NotPrincipal, principal)
+	}
+	return principalListed(statement.Principal, principal)
+}
+
+func principalListed(principals Principals, principal *Principal) bool {
+	if _, ok := principals["*"]; ok {
+		return true
+	}
+	for _, value := range principals[principal.Type] {
+		if value == "*" || value == principal.ID {
+			return true
+		}
+		// 계정 ID와 계정 루트 ARN은 그 계정의 모든 주체에게 위임
+		if principal.Type == "AWS" {
+			account := value
+			if strings.HasSuffix(value, ":root") {
+				account = arnAccount(value)
+			}
+			if account != "" && arnAccount(principal.ID) == account {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// arn:partition:service:region:account:resource의 계정
+func arnAccount(arn string) string {
+	parts := strings.SplitN(arn, ":", 6)
+	if len(parts) < 6 || parts[0] != "arn" {
+		return ""
+	}
+	return parts[4]
+}
+
+func matchesAny(patterns []string, value string, ignoreCase bool) bool {
+	for _, pattern := range patterns {
+		if matchWildcard(pattern, value, ignoreCase) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchWildcard *(0자 이상)와 ?(1자) 와일드카드 비교
+func matchWildcard(pattern, value string, ignoreCase bool) bool {
+	if pattern == "*" {
+		return true
+	}
+	var expr strings.Builder
+	if ignoreCase {
+		expr.WriteString("(?is)")
+	} else {
+		expr.WriteString("(?s)")
+	}
+	expr.WriteString("^")
+	for _, r := range pattern {
+		switch r {
+		case '*':
+			expr.WriteString(".*")
+		case '?':
+			expr.WriteString(".")
+		default:
+			expr.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+	expr.WriteString("$")
+	return regexp.MustCompile(expr.String()).MatchString(value)
+}
@@ -0,0 +1,213 @@
+package iampolicy
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/k8s-ec2-observability/test/helpers/tfcontract"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// AdministratorAccessARN AWS 관리형 AdministratorAccess 정책
+const AdministratorAccessARN = "arn:aws:iam::aws:policy/AdministratorAccess"
+
+// ManagedPolicies 오프라인 평가에 쓰는 AWS 관리형 정책 문서
+var ManagedPolicies = map[string]string{
+	AdministratorAccessARN: `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Action":"*","Resource":"*"}]}`,
+}
+
+// Role Terraform aws_iam_role과 연결된 정책
+type Role struct {
+	// Address Terraform 리소스 주소 (aws_iam_role.<name>)
+	Address string
+	Name    string
+	Trust   *Document
+	// Policies 연결된 관리형/고객 관리형/인라인 정책
+	Policies []*Document
+	// Unresolved 문서를 알 수 없는 정책 ARN (ManagedPolicies에 없는 관리형 정책 등)
+	Unresolved []string
+}
+
+// Evaluate 역할의 권한 정책으로 요청 평가
+func (r *Role) Evaluate(action, resource string, context map[string][]string) (Result, error) {
+	return Evaluate(Request{Action: action, Resource: resource, Context: context}, r.Policies...)
+}
+
+// CanAssume 주체가 신뢰 정책으로 sts:AssumeRole 할 수 있는지
+func (r *Role) CanAssume(principal Principal, context map[string][]string) (Result, error) {
+	return Evaluate(Request{Principal: &principal, Action: "sts:AssumeRole", Context: context}, r.Trust)
+}
+
+// Audit 신뢰 정책과 권한 정책 감사
+func (r *Role) Audit() []Finding {
+	var findings []Finding
+	if r.Trust != nil {
+		findings = append(findings, AuditTrust(r.Trust)...)
+	}
+	for _, policy := range r.Policies {
+		findings = append(findings, Audit(policy)...)
+	}
+	return findings
+}
+
+// Policy 이름으로 연결된 정책 조회
+func (r *Role) Policy(name string) *Document {
+	for _, policy := range r.Policies {
+		if policy.Name == name {
+			return policy
+		}
+	}
+	return nil
+}
+
+// Without 이름이 같은 정책을 뺀 역할 (연결 해제 시 권한 확인용)
+func (r *Role) Without(names ...string) *Role {
+	copied := *r
+	copied.Policies = nil
+	for _, policy := range r.Policies {
+		if !contains(names, policy.Name) {
+			copied.Policies = append(copied.Policies, policy)
+		}
+	}
+	return &copied
+}
+
+// LoadModuleRoles 모듈의 aws_iam_role, aws_iam_policy, 정책 연결 리소스를 apply 없이 평가
+//
+// jsonencode()로 쓴 정책은 vars와 변수 기본값으로 평가하고, 연결 리소스의 role/policy_arn은
+// 같은 모듈 리소스 참조로 해석합니다. 반환 맵의 키는 리소스 주소입니다.
+func LoadModuleRoles(dir string, vars map[string]interface{}) (map[string]*Role, error) {
+	module, err := tfcontract.LoadModule(dir)
+	if err != nil {
+		return nil, err
+	}
+	ctx, err := module.EvalContext(vars)
+	if err != nil {
+		return nil, err
+	}
+
+	roles := make(map[string]*Role)
+	for _, resource := range module.ResourcesOfType("aws_iam_role") {
+		role := &Role{Address: resource.Address()}
+		if role.Name, err = stringAttribute(ctx, resource, "name"); err != nil {
+			return nil, err
+		}
+		if role.Trust, err = documentAttribute(ctx, resource, "assume_role_policy"); err != nil {
+			return nil, err
+		}
+		roles[role.Address] = role
+	}
+
+	policies := make(map[string]*Document)
+	for _, resource := range module.ResourcesOfType("aws_iam_policy") {
+		document, err := documentAttribute(ctx, resource, "policy")
+		if err != nil {
+			return nil, err
+		}
+		policies[resource.Address()] = document
+	}
+
+	for _, resource := range module.ResourcesOfType("aws_iam_role_policy_attachment") {
+		role, err := referencedRole(roles, resource)
+		if err != nil {
+			return nil, err
+		}
+		attr, ok := resource.Attributes["policy_arn"]
+		if !ok {
+			return nil, fmt.Errorf("%s: policy_arn이 없습니다", resource.Address())
+		}
+		if address := resourceReference(attr.Expr, "aws_iam_policy"); address != "" {
+			document, ok := policies[address]
+			if !ok {
+				return nil, fmt.Errorf("%s: %s를 찾을 수 없습니다", resource.Address(), address)
+			}
+			role.Policies = append(role.Policies, document)
+			continue
+		}
+		arn, err := stringAttribute(ctx, resource, "policy_arn")
+		if err != nil {
+			return nil, err
+		}
+		managed, ok := ManagedPolicies[arn]
+		if !ok {
+			role.Unresolved = append(role.Unresolved, arn)
+			continue
+		}
+		document, err := Parse(arn, []byte(managed))
+		if err != nil {
+			return nil, err
+		}
+		role.Policies = append(role.Policies, document)
+	}
+
+	for _, resource := range module.ResourcesOfType("aws_iam_role_policy") {
+		role, err := referencedRole(roles, resource)
+		if err != nil {
+			return nil, err
+		}
+		document, err := documentAttribute(ctx, resource, "policy")
+		if err != nil {
+			return nil, err
+		}
+		role.Policies = append(role.Policies, document)
+	}
+
+	for _, role := range roles {
+		sort.SliceStable(role.Policies, func(i, j int) bool { return role.Policies[i].Name < role.Policies[j].Name })
+		sort.Strings(role.Unresolved)
+	}
+	return roles, nil
+}
+
+func referencedRole(roles map[string]*Role, resource *tfcontract.Resource) (*Role, error) {
+	attr, ok := resource.Attributes["role"]
+	if !ok {
+		return nil, fmt.Errorf("%s: role이 없습니다", resource.Address())
+	}
+	address := resourceReference(attr.Expr, "aws_iam_role")
+	role, ok := roles[address]
+	if !ok {
+		return nil, fmt.Errorf("%s: 같은 모듈의 aws_iam_role을 참조해야 합니다", resource.Address())
+	}
+	return role, nil
+}
+
+// resourceReference <resourceType>.<name>.<attr> 참조의 리소스 주소 (없으면 빈 문자열)
+func resourceReference(expr hcl.Expression, resourceType string) string {
+	for _, traversal := range expr.Variables() {
+		if traversal.RootName() != resourceType || len(traversal) < 2 {
+			continue
+		}
+		if attr, ok := traversal[1].(hcl.TraverseAttr); ok {
+			return resourceType + "." + attr.Name
+		}
+	}
+	return ""
+}
+
+func stringAttribute(ctx *hcl.EvalContext, resource *tfcontract.Resource, name string) (string, error) {
+	attr, ok := resource.Attributes[name]
+	if !ok {
+		return "", nil
+	}
+	value, diags := attr.Expr.Value(ctx)
+	if diags.HasErrors() {
+		return "", fmt.Errorf("%s.%s 평가 실패: %s", resource.Address(), name, diags.Error())
+	}
+	if value.IsNull() || !value.IsKnown() || value.Type() != cty.String {
+		return "", fmt.Errorf("%s.%s가 문자열이 아닙니다", resource.Address(), name)
+	}
+	return value.AsString(), nil
+}
+
+func documentAttribute(ctx *hcl.EvalContext, resource *tfcontract.Resource, name string) (*Document, error) {
+	text, err := stringAttribute(ctx, resource, name)
+	if err != nil {
+		return nil, err
+	}
+	if text == "" {
+		return nil, fmt.Errorf("%s.%s가 없습니다", resource.Address(), name)
+	}
+	return Parse(resource.Address(), []byte(text))
+}
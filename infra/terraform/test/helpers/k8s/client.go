@@ -0,0 +1,68 @@
+package k8s
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+
+	"github.com/gruntwork-io/terratest/modules/ssh"
+	"github.com/gruntwork-io/terratest/modules/testing"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+// MasterKubeconfigPath combined_settings.sh가 kubeadm init 후 복사하는 kubeconfig 경로
+const MasterKubeconfigPath = "/home/ubuntu/.kube/config"
+
+// APIServerPort kubeadm 기본 API 서버 포트
+const APIServerPort = "6443"
+
+// kubeadm이 발급하는 API 서버 인증서에 항상 포함되는 SAN
+const apiServerCertName = "kubernetes"
+
+// FetchKubeconfigFromMaster 마스터 노드에서 kubeconfig 내용 조회
+func FetchKubeconfigFromMaster(t testing.TestingT, master ssh.Host) ([]byte, error) {
+	contents, err := ssh.FetchContentsOfFileE(t, master, true, MasterKubeconfigPath)
+	if err != nil {
+		return nil, fmt.Errorf("마스터 노드에서 kubeconfig를 가져오지 못했습니다: %w", err)
+	}
+	return []byte(contents), nil
+}
+
+// NewClientFromKubeconfig kubeconfig로 클라이언트 생성
+//
+// kubeadm은 API 서버 주소를 마스터의 프라이빗 IP로 기록하므로, apiHost가 주어지면
+// 해당 주소(보통 마스터 퍼블릭 IP)로 접속하되 인증서 검증은 기본 SAN으로 수행합니다.
+func NewClientFromKubeconfig(kubeconfig []byte, apiHost string) (kubernetes.Interface, error) {
+	restConfig, err := clientcmd.RESTConfigFromKubeConfig(kubeconfig)
+	if err != nil {
+		return nil, fmt.Errorf("kubeconfig 파싱 실패: %w", err)
+	}
+
+	if apiHost != "" {
+		server, err := url.Parse(restConfig.Host)
+		if err != nil {
+			return nil, fmt.Errorf("API 서버 주소 파싱 실패: %w", err)
+		}
+		port := server.Port()
+		if port == "" {
+			port = APIServerPort
+		}
+		server.Host = net.JoinHostPort(apiHost, port)
+		restConfig.Host = server.String()
+		if restConfig.TLSClientConfig.ServerName == "" {
+			restConfig.TLSClientConfig.ServerName = apiServerCertName
+		}
+	}
+
+	return kubernetes.NewForConfig(restConfig)
+}
+
+// NewClientFromMaster 마스터 노드의 kubeconfig로 클라이언트 생성
+func NewClientFromMaster(t testing.TestingT, master ssh.Host) (kubernetes.Interface, error) {
+	kubeconfig, err := FetchKubeconfigFromMaster(t, master)
+	if err != nil {
+		return nil, err
+	}
+	return NewClientFromKubeconfig(kubeconfig, master.Hostname)
+}
@@ -0,0 +1,239 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gruntwork-io/terratest/modules/retry"
+	"github.com/gruntwork-io/terratest/modules/testing"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	// CNINamespace Tigera Operator가 Calico 구성요소를 배포하는 네임스페이스
+	CNINamespace = "calico-system"
+	// CoreDNSNamespace CoreDNS가 배포되는 네임스페이스
+	CoreDNSNamespace = "kube-system"
+	// CoreDNSSelector kubeadm이 CoreDNS에 부여하는 레이블 셀렉터
+	CoreDNSSelector = "k8s-app=kube-dns"
+
+	nodeRoleLabelPrefix = "node-role.kubernetes.io/"
+)
+
+// NodeStatus 노드별 상태 요약
+type NodeStatus struct {
+	Name           string
+	Roles          []string
+	Ready          bool
+	KubeletVersion string
+	Conditions     map[corev1.NodeConditionType]corev1.ConditionStatus
+}
+
+// PodStatus 파드별 상태 요약
+type PodStatus struct {
+	Namespace string
+	Name      string
+	NodeName  string
+	Phase     corev1.PodPhase
+	Ready     bool
+	Restarts  int32
+}
+
+// ClusterReadiness 클러스터 준비 상태 보고서
+type ClusterReadiness struct {
+	ExpectedNodes int
+	Nodes         []NodeStatus
+	CNIPods       []PodStatus
+	CoreDNSPods   []PodStatus
+}
+
+// ExpectedNodeCount 마스터 1대와 워커 노드 수를 합한 기대 노드 수
+func ExpectedNodeCount(workerCount int) int {
+	return 1 + workerCount
+}
+
+// ReadyNodeCount Ready 상태인 노드 수
+func (r *ClusterReadiness) ReadyNodeCount() int {
+	count := 0
+	for _, node := range r.Nodes {
+		if node.Ready {
+			count++
+		}
+	}
+	return count
+}
+
+// Problems 준비되지 않은 항목 목록 (비어 있으면 클러스터 준비 완료)
+func (r *ClusterReadiness) Problems() []string {
+	var problems []string
+
+	if ready := r.ReadyNodeCount(); ready < r.ExpectedNodes {
+		problems = append(problems, fmt.Sprintf("Ready 노드 수 부족: %d/%d", ready, r.ExpectedNodes))
+	}
+	for _, node := range r.Nodes {
+		if !node.Ready {
+			problems = append(problems, fmt.Sprintf("노드 %s NotReady (%s)", node.Name, formatConditions(node.Conditions)))
+		}
+	}
+
+	problems = append(problems, podProblems("CNI", r.CNIPods)...)
+	problems = append(problems, podProblems("CoreDNS", r.CoreDNSPods)...)
+
+	return problems
+}
+
+// IsReady 모든 노드와 CNI/CoreDNS 파드가 준비되었는지 여부
+func (r *ClusterReadiness) IsReady() bool {
+	return len(r.Problems()) == 0
+}
+
+// GetNodeStatuses 모든 노드의 상태 조회
+func GetNodeStatuses(ctx context.Context, client kubernetes.Interface) ([]NodeStatus, error) {
+	nodes, err := client.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]NodeStatus, 0, len(nodes.Items))
+	for _, node := range nodes.Items {
+		statuses = append(statuses, newNodeStatus(node))
+	}
+	sort.Slice(statuses, func(i, j int) bool { return statuses[i].Name < statuses[j].Name })
+
+	return statuses, nil
+}
+
+// GetPodStatuses 네임스페이스와 레이블 셀렉터로 파드 상태 조회
+func GetPodStatuses(ctx context.Context, client kubernetes.Interface, namespace, selector string) ([]PodStatus, error) {
+	pods, err := client.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{LabelSelector: selector})
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]PodStatus, 0, len(pods.Items))
+	for _, pod := range pods.Items {
+		statuses = append(statuses, newPodStatus(pod))
+	}
+	sort.Slice(statuses, func(i, j int) bool { return statuses[i].Name < statuses[j].Name })
+
+	return statuses, nil
+}
+
+// CheckClusterReadiness 노드, CNI, CoreDNS 상태를 한 번 조회하여 보고서 생성
+func CheckClusterReadiness(ctx context.Context, client kubernetes.Interface, expectedNodes int) (*ClusterReadiness, error) {
+	nodes, err := GetNodeStatuses(ctx, client)
+	if err != nil {
+		return nil, fmt.Errorf("노드 조회 실패: %w", err)
+	}
+
+	cniPods, err := GetPodStatuses(ctx, client, CNINamespace, "")
+	if err != nil {
+		return nil, fmt.Errorf("CNI 파드 조회 실패: %w", err)
+	}
+
+	coreDNSPods, err := GetPodStatuses(ctx, client, CoreDNSNamespace, CoreDNSSelector)
+	if err != nil {
+		return nil, fmt.Errorf("CoreDNS 파드 조회 실패: %w", err)
+	}
+
+	return &ClusterReadiness{
+		ExpectedNodes: expectedNodes,
+		Nodes:         nodes,
+		CNIPods:       cniPods,
+		CoreDNSPods:   coreDNSPods,
+	}, nil
+}
+
+// WaitForClusterReady 클러스터가 준비될 때까지 재시도하며 대기
+//
+// 실패 시에도 마지막으로 조회한 보고서를 반환하므로 노드별 상태를 로그로 남길 수 있습니다.
+func WaitForClusterReady(t testing.TestingT, client kubernetes.Interface, expectedNodes int, maxRetries int, sleepBetweenRetries time.Duration) (*ClusterReadiness, error) {
+	var last *ClusterReadiness
+
+	_, err := retry.DoWithRetryE(t, "쿠버네티스 클러스터 준비 대기", maxRetries, sleepBetweenRetries, func() (string, error) {
+		readiness, err := CheckClusterReadiness(context.Background(), client, expectedNodes)
+		if err != nil {
+			return "", err
+		}
+		last = readiness
+
+		if problems := readiness.Problems(); len(problems) > 0 {
+			return "", fmt.Errorf("클러스터 미준비: %s", strings.Join(problems, "; "))
+		}
+		return fmt.Sprintf("Ready 노드 %d/%d", readiness.ReadyNodeCount(), expectedNodes), nil
+	})
+
+	return last, err
+}
+
+func newNodeStatus(node corev1.Node) NodeStatus {
+	status := NodeStatus{
+		Name:           node.Name,
+		KubeletVersion: node.Status.NodeInfo.KubeletVersion,
+		Conditions:     make(map[corev1.NodeConditionType]corev1.ConditionStatus),
+	}
+
+	for label := range node.Labels {
+		if strings.HasPrefix(label, nodeRoleLabelPrefix) {
+			status.Roles = append(status.Roles, strings.TrimPrefix(label, nodeRoleLabelPrefix))
+		}
+	}
+	sort.Strings(status.Roles)
+
+	for _, condition := range node.Status.Conditions {
+		status.Conditions[condition.Type] = condition.Status
+		if condition.Type == corev1.NodeReady {
+			status.Ready = condition.Status == corev1.ConditionTrue
+		}
+	}
+
+	return status
+}
+
+func newPodStatus(pod corev1.Pod) PodStatus {
+	status := PodStatus{
+		Namespace: pod.Namespace,
+		Name:      pod.Name,
+		NodeName:  pod.Spec.NodeName,
+		Phase:     pod.Status.Phase,
+	}
+
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.PodReady {
+			status.Ready = condition.Status == corev1.ConditionTrue
+		}
+	}
+	for _, container := range pod.Status.ContainerStatuses {
+		status.Restarts += container.RestartCount
+	}
+
+	return status
+}
+
+func podProblems(component string, pods []PodStatus) []string {
+	if len(pods) == 0 {
+		return []string{fmt.Sprintf("%s 파드가 없습니다", component)}
+	}
+
+	var problems []string
+	for _, pod := range pods {
+		if pod.Phase != corev1.PodRunning || !pod.Ready {
+			problems = append(problems, fmt.Sprintf("%s 파드 %s/%s 미준비 (phase=%s, node=%s)", component, pod.Namespace, pod.Name, pod.Phase, pod.NodeName))
+		}
+	}
+	return problems
+}
+
+func formatConditions(conditions map[corev1.NodeConditionType]corev1.ConditionStatus) string {
+	parts := make([]string, 0, len(conditions))
+	for conditionType, status := range conditions {
+		parts = append(parts, fmt.Sprintf("%s=%s", conditionType, status))
+	}
+	sort.Strings(parts)
+	return strings.Join(parts, ", ")
+}
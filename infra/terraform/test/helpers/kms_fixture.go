@@ -0,0 +1,85 @@
+package helpers
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/gruntwork-io/terratest/modules/terraform"
+)
+
+// KMSKey 공유 픽스처가 만든 KMS 키
+type KMSKey struct {
+	Config  *KMSTestConfig
+	Options *terraform.Options
+	KeyID   string
+	KeyARN  string
+	// workDir 픽스처 전용 작업 디렉터리 (destroy에 성공하면 삭제)
+	workDir string
+}
+
+// NewKMSKeyFixture 기본 설정(선택 기능 없음)의 KMS 키 하나를 패키지 테스트가 함께 쓰는 픽스처
+//
+// 키마다 삭제 대기 기간(7일)이 생기므로 키 속성만 확인하는 테스트는 키를 새로 만들지 말고 이 픽스처를 씁니다.
+//
+//	var kmsKey = helpers.NewKMSKeyFixture()
+//
+//	func TestMain(m *testing.M) {
+//		os.Exit(kmsKey.RunTests(m))
+//	}
+//
+//	func TestKMSKeyRotation(t *testing.T) {
+//		key := kmsKey.Acquire(t)
+//		...
+//	}
+func NewKMSKeyFixture() *SharedFixture[*KMSKey] {
+	return NewSharedFixture("KMS 키", setupKMSKey, destroyKMSKey)
+}
+
+func setupKMSKey(t TestingT) (*KMSKey, error) {
+	config := NewKMSTestConfig()
+	// 픽스처는 테스트보다 오래 살아 있으므로 t.TempDir 대신 직접 만든 디렉터리에서 실행
+	workDir, err := os.MkdirTemp("", "kms-fixture-")
+	if err != nil {
+		return nil, err
+	}
+	terraformDir, err := CopyTerraformModuleE(config.TestFolder, workDir)
+	if err != nil {
+		os.RemoveAll(workDir)
+		return nil, err
+	}
+	key := &KMSKey{
+		Config:  config,
+		Options: kmsTerraformOptions(t, config, terraformDir),
+		workDir: workDir,
+	}
+
+	t.Logf("🔐 공유 KMS 키 생성 중: %s", config.UniqueID)
+	if err := ApplyTerraformE(t, key.Options); err != nil {
+		return nil, cleanupFailedKMSKey(t, key, err)
+	}
+	if key.KeyID, err = terraform.OutputE(t, key.Options, "key_id"); err != nil {
+		return nil, cleanupFailedKMSKey(t, key, err)
+	}
+	if key.KeyARN, err = terraform.OutputE(t, key.Options, "key_arn"); err != nil {
+		return nil, cleanupFailedKMSKey(t, key, err)
+	}
+	t.Logf("✅ 공유 KMS 키 생성 완료: %s", key.KeyID)
+	return key, nil
+}
+
+// cleanupFailedKMSKey 일부만 만들어졌을 수 있는 리소스를 정리하고 원래 오류 반환
+func cleanupFailedKMSKey(t TestingT, key *KMSKey, cause error) error {
+	if err := destroyKMSKey(t, key); err != nil {
+		return fmt.Errorf("%w (정리 실패: %v)", cause, err)
+	}
+	return cause
+}
+
+func destroyKMSKey(t TestingT, key *KMSKey) error {
+	t.Logf("🧹 공유 KMS 키 정리 중: %s", key.Config.UniqueID)
+	if err := DestroyTerraformE(t, key.Options); err != nil {
+		t.Logf("수동 정리를 위해 작업 디렉터리를 남겨 둡니다: %s", key.workDir)
+		return err
+	}
+	return os.RemoveAll(key.workDir)
+}
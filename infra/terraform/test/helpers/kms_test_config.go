@@ -4,7 +4,6 @@ import (
 	"fmt"
 	"strings"
 	"testing"
-	"time"
 
 	"github.com/gruntwork-io/terratest/modules/random"
 	"github.com/gruntwork-io/terratest/modules/terraform"
@@ -18,6 +17,30 @@ type KMSTestConfig struct {
 	Environment string
 	ProjectName string
 	Tags        map[string]string
+	// Features modules/kms 선택 기능 (EnableAllowedFeatures로 권한이 있는 기능만 켬)
+	Features KMSFeatures
+}
+
+// KMSFeatures modules/kms 선택 기능 플래그
+type KMSFeatures struct {
+	Monitoring   bool
+	CloudTrail   bool
+	Backup       bool
+	AutoRecovery bool
+}
+
+// kmsFeature 기능 플래그와 그 기능이 만드는 리소스에 필요한 권한
+type kmsFeature struct {
+	variable     string
+	capabilities []Capability
+	flag         func(*KMSFeatures) *bool
+}
+
+var kmsFeatures = []kmsFeature{
+	{"enable_monitoring", []Capability{CapabilityCloudWatch}, func(f *KMSFeatures) *bool { return &f.Monitoring }},
+	{"enable_cloudtrail", []Capability{CapabilityCloudTrail, CapabilityLogs}, func(f *KMSFeatures) *bool { return &f.CloudTrail }},
+	{"enable_backup", []Capability{CapabilityBackup}, func(f *KMSFeatures) *bool { return &f.Backup }},
+	{"enable_auto_recovery", []Capability{CapabilityLambda, CapabilityEventBridge}, func(f *KMSFeatures) *bool { return &f.AutoRecovery }},
 }
 
 // NewKMSTestConfig 새로운 KMS 테스트 설정 생성
@@ -40,36 +63,56 @@ func NewKMSTestConfig() *KMSTestConfig {
 	}
 }
 
+// EnableAllowedFeatures 현재 자격 증명으로 만들 수 있는 선택 기능을 모두 켜고, 나머지는 사유를 기록
+func (c *KMSTestConfig) EnableAllowedFeatures(t *testing.T, capabilities *CapabilitySet) {
+	for _, feature := range kmsFeatures {
+		enabled := capabilities.Has(feature.capabilities...)
+		*feature.flag(&c.Features) = enabled
+		if enabled {
+			t.Logf("✅ %s 활성화", feature.variable)
+		} else {
+			t.Logf("⏭️ %s 비활성화: %s", feature.variable, capabilities.Reason(feature.capabilities...))
+		}
+	}
+}
+
+// TerraformVars examples/kms 변수
+func (c *KMSTestConfig) TerraformVars() map[string]interface{} {
+	return map[string]interface{}{
+		"region":                  c.Region,
+		"project_name":            c.ProjectName,
+		"environment":             c.Environment,
+		"unique_id":               c.UniqueID,
+		"test_name":               fmt.Sprintf("kms-test-%s", c.UniqueID),
+		"enable_backup":           c.Features.Backup,
+		"enable_auto_recovery":    c.Features.AutoRecovery,
+		"enable_monitoring":       c.Features.Monitoring,
+		"enable_cloudtrail":       c.Features.CloudTrail,
+		"deletion_window_in_days": 7,    // 최소값으로 설정
+		"enable_key_rotation":     true, // 기본 KMS 기능만 사용
+		"tags":                    c.Tags,
+	}
+}
+
 // SetupKMSTest KMS 테스트 설정 초기화 (GitHub Actions 최적화)
+//
+// 선택 기능은 config.Features를 따릅니다. 기본값은 모두 꺼져 있으며,
+// 기능을 검증하는 테스트는 EnableAllowedFeatures로 권한이 있는 기능을 켭니다.
+// 병렬 테스트가 .terraform과 상태 파일을 공유하지 않도록 examples/kms와 modules/kms만 테스트별 임시 디렉터리에 복사해 실행합니다.
 func SetupKMSTest(t *testing.T, config *KMSTestConfig) *terraform.Options {
-	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
-		TerraformDir: config.TestFolder,
-		Vars: map[string]interface{}{
-			"region":                  config.Region,
-			"project_name":            config.ProjectName,
-			"environment":             config.Environment,
-			"unique_id":               config.UniqueID,
-			"test_name":               fmt.Sprintf("kms-test-%s", config.UniqueID),
-			"enable_multi_region":     false, // 단순화
-			"replica_region":          "us-west-2",
-			"enable_backup":           false, // GitHub Actions에서 권한 문제 방지
-			"enable_auto_recovery":    false, // Lambda/EventBridge 권한 문제 방지
-			"enable_monitoring":       false, // CloudWatch 권한 문제 방지
-			"enable_cloudtrail":       false, // CloudTrail/S3 권한 문제 방지
-			"deletion_window_in_days": 7,     // 최소값으로 설정
-			"enable_key_rotation":     true,  // 기본 KMS 기능만 사용
-			"tags":                    config.Tags,
-		},
-		// 재시도 설정 추가 (GitHub Actions 안정성 향상)
-		RetryableTerraformErrors: map[string]string{
-			".*RequestLimitExceeded.*": "AWS API 요청 제한, 재시도 중...",
-			".*throttling.*":           "AWS API 스로틀링, 재시도 중...",
-			".*timeout.*":              "타임아웃 발생, 재시도 중...",
-			".*":                       "Terraform operation failed, retrying...",
-		},
-		MaxRetries:         5,                // 재시도 횟수 증가
-		TimeBetweenRetries: 10 * time.Second, // 재시도 간격 증가
-	})
+	return kmsTerraformOptions(t, config, CopyTerraformModule(t, config.TestFolder))
+}
 
-	return terraformOptions
+// kmsTerraformOptions 복사된 examples/kms 디렉터리를 실행하는 Terraform 옵션
+func kmsTerraformOptions(t TestingT, config *KMSTestConfig, terraformDir string) *terraform.Options {
+	envVars := make(map[string]string)
+	usePluginCache(t, envVars)
+
+	return &terraform.Options{
+		TerraformDir: terraformDir,
+		Vars:         config.TerraformVars(),
+		EnvVars:      envVars,
+		// 재시도는 ApplyTerraform/DestroyTerraform이 오류를 분류해 일시적인 오류만 수행
+		NoColor: true,
+	}
 }
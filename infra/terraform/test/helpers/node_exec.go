@@ -0,0 +1,132 @@
+package helpers
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// 노드에서 확인할 주요 로그 경로
+const (
+	CombinedSettingsLogPath = "/home/ubuntu/combined_settings.log"
+	KubeadmInitLogPath      = "/var/log/kubeadm_init.log"
+	JoinCommandPath         = "/home/ubuntu/join_command"
+)
+
+// NodeCommand 노드에서 실행할 명령
+type NodeCommand struct {
+	Command string
+	Sudo    bool          // sudo로 실행 여부
+	Timeout time.Duration // 0이면 ctx의 기한만 적용
+	Stdout  io.Writer     // 표준 출력 스트리밍 대상 (선택)
+	Stderr  io.Writer     // 표준 에러 스트리밍 대상 (선택)
+}
+
+// NodeCommandResult 노드 명령 실행 결과
+type NodeCommandResult struct {
+	Stdout   string
+	Stderr   string
+	ExitCode int
+}
+
+// Err 종료 코드가 0이 아니면 에러 반환
+func (r *NodeCommandResult) Err() error {
+	if r.ExitCode == 0 {
+		return nil
+	}
+	return &NodeCommandError{ExitCode: r.ExitCode, Stderr: r.Stderr}
+}
+
+// NodeCommandError 명령이 0이 아닌 종료 코드로 끝났을 때의 에러
+type NodeCommandError struct {
+	ExitCode int
+	Stderr   string
+}
+
+func (e *NodeCommandError) Error() string {
+	return fmt.Sprintf("명령이 종료 코드 %d로 실패했습니다: %s", e.ExitCode, strings.TrimSpace(e.Stderr))
+}
+
+// NodeExec 클러스터 노드 원격 명령 실행 인터페이스
+//
+// 명령이 실행되어 종료 코드를 받은 경우 에러는 nil이며, 연결 실패나 타임아웃일 때만 에러를 반환합니다.
+type NodeExec interface {
+	Exec(ctx context.Context, command NodeCommand) (*NodeCommandResult, error)
+	Close() error
+}
+
+// RunNodeCommand 명령을 실행하고 종료 코드가 0이 아니면 에러 반환
+func RunNodeCommand(ctx context.Context, node NodeExec, command string) (string, error) {
+	result, err := node.Exec(ctx, NodeCommand{Command: command})
+	if err != nil {
+		return "", err
+	}
+	return result.Stdout, result.Err()
+}
+
+// ReadNodeFile 노드의 파일 내용 조회 (root 권한)
+func ReadNodeFile(ctx context.Context, node NodeExec, path string) (string, error) {
+	result, err := node.Exec(ctx, NodeCommand{Command: "cat " + shellQuote(path), Sudo: true})
+	if err != nil {
+		return "", err
+	}
+	return result.Stdout, result.Err()
+}
+
+// GetJournalLogs systemd 유닛의 최근 journald 로그 조회
+func GetJournalLogs(ctx context.Context, node NodeExec, unit string, lines int) (string, error) {
+	command := fmt.Sprintf("journalctl -u %s -n %d --no-pager", shellQuote(unit), lines)
+	result, err := node.Exec(ctx, NodeCommand{Command: command, Sudo: true})
+	if err != nil {
+		return "", err
+	}
+	return result.Stdout, result.Err()
+}
+
+// GetKubeadmInitLog 마스터 노드의 kubeadm init 로그 조회
+func GetKubeadmInitLog(ctx context.Context, node NodeExec) (string, error) {
+	return ReadNodeFile(ctx, node, KubeadmInitLogPath)
+}
+
+// GetCombinedSettingsLog combined_settings.sh 실행 로그 조회
+func GetCombinedSettingsLog(ctx context.Context, node NodeExec) (string, error) {
+	return ReadNodeFile(ctx, node, CombinedSettingsLogPath)
+}
+
+func buildShellCommand(command NodeCommand) string {
+	if command.Sudo {
+		return "sudo -n bash -c " + shellQuote(command.Command)
+	}
+	return command.Command
+}
+
+func commandContext(ctx context.Context, command NodeCommand) (context.Context, context.CancelFunc) {
+	if command.Timeout > 0 {
+		return context.WithTimeout(ctx, command.Timeout)
+	}
+	return context.WithCancel(ctx)
+}
+
+// 컨텍스트 종료 원인 (기한 초과는 타임아웃, 그 외는 취소)
+func interruptReason(err error) string {
+	if errors.Is(err, context.DeadlineExceeded) {
+		return "타임아웃"
+	}
+	return "취소"
+}
+
+// 캡처용 버퍼와 스트리밍 대상에 동시에 기록
+func teeWriter(buffer *bytes.Buffer, stream io.Writer) io.Writer {
+	if stream == nil {
+		return buffer
+	}
+	return io.MultiWriter(buffer, stream)
+}
+
+func shellQuote(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", `'"'"'`) + "'"
+}
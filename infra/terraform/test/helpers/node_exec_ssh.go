@@ -0,0 +1,304 @@
+package helpers
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"golang.org/x/crypto/ssh"
+)
+
+// SSHEndpoint SSH 접속 대상 정보
+type SSHEndpoint struct {
+	Host       string
+	Port       int // 0이면 22
+	User       string
+	PrivateKey []byte // PEM 형식 개인키
+}
+
+// SSHNodeExecConfig SSH 기반 NodeExec 설정
+type SSHNodeExecConfig struct {
+	Target          SSHEndpoint
+	Bastion         *SSHEndpoint // 워커 노드처럼 마스터를 경유해야 하는 경우 설정
+	DialTimeout     time.Duration
+	HostKeyCallback ssh.HostKeyCallback // nil이면 호스트 키 검증 생략 (테스트 인스턴스 전용)
+}
+
+// SSHNodeExec SSH로 노드 명령을 실행하는 NodeExec 구현
+type SSHNodeExec struct {
+	config SSHNodeExecConfig
+
+	mu      sync.Mutex
+	client  *ssh.Client
+	bastion *ssh.Client
+}
+
+// NewSSHNodeExec 새로운 SSH NodeExec 생성 (연결은 첫 실행 시 수립)
+func NewSSHNodeExec(config SSHNodeExecConfig) *SSHNodeExec {
+	if config.DialTimeout == 0 {
+		config.DialTimeout = 30 * time.Second
+	}
+	if config.HostKeyCallback == nil {
+		config.HostKeyCallback = ssh.InsecureIgnoreHostKey()
+	}
+	return &SSHNodeExec{config: config}
+}
+
+// NewSSHEndpointFromKeyFile 모듈의 private_key_path로 SSH 접속 정보 생성
+func NewSSHEndpointFromKeyFile(host, user, privateKeyPath string) (SSHEndpoint, error) {
+	key, err := os.ReadFile(privateKeyPath)
+	if err != nil {
+		return SSHEndpoint{}, fmt.Errorf("SSH 개인키를 읽을 수 없습니다: %w", err)
+	}
+	return SSHEndpoint{Host: host, User: user, PrivateKey: key}, nil
+}
+
+// Exec NodeExec 구현
+func (e *SSHNodeExec) Exec(ctx context.Context, command NodeCommand) (*NodeCommandResult, error) {
+	ctx, cancel := commandContext(ctx, command)
+	defer cancel()
+
+	client, err := e.connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	session, err := client.NewSession()
+	if err != nil {
+		// 끊어진 연결을 캐시에 남기면 이후 호출도 모두 실패하므로 버리고 다음 호출에서 재연결
+		e.discard(client)
+		return nil, fmt.Errorf("SSH 세션 생성 실패: %w", err)
+	}
+	defer session.Close()
+
+	var stdout, stderr bytes.Buffer
+	session.Stdout = teeWriter(&stdout, command.Stdout)
+	session.Stderr = teeWriter(&stderr, command.Stderr)
+
+	if err := session.Start(buildShellCommand(command)); err != nil {
+		e.discard(client)
+		return nil, fmt.Errorf("원격 명령 시작 실패: %w", err)
+	}
+
+	done := make(chan error, 1)
+	go func() { done <- session.Wait() }()
+
+	select {
+	case <-ctx.Done():
+		_ = session.Signal(ssh.SIGKILL)
+		session.Close()
+		// 출력 버퍼를 읽기 전에 세션 고루틴이 끝나기를 기다림
+		<-done
+		return &NodeCommandResult{Stdout: stdout.String(), Stderr: stderr.String(), ExitCode: -1},
+			fmt.Errorf("원격 명령 %s: %w", interruptReason(ctx.Err()), ctx.Err())
+	case err := <-done:
+		result := &NodeCommandResult{Stdout: stdout.String(), Stderr: stderr.String()}
+		if err == nil {
+			return result, nil
+		}
+		var exitErr *ssh.ExitError
+		if errors.As(err, &exitErr) {
+			result.ExitCode = exitErr.ExitStatus()
+			return result, nil
+		}
+		result.ExitCode = -1
+		return result, fmt.Errorf("원격 명령 실행 실패: %w", err)
+	}
+}
+
+// Close SSH 연결 종료
+func (e *SSHNodeExec) Close() error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	var err error
+	if e.client != nil {
+		err = e.client.Close()
+		e.client = nil
+	}
+	if e.bastion != nil {
+		if bastionErr := e.bastion.Close(); err == nil {
+			err = bastionErr
+		}
+		e.bastion = nil
+	}
+	return err
+}
+
+// discard 캐시된 연결이 client이면 닫고 비움
+func (e *SSHNodeExec) discard(client *ssh.Client) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.client != client {
+		return
+	}
+	e.client.Close()
+	e.client = nil
+	if e.bastion != nil {
+		e.bastion.Close()
+		e.bastion = nil
+	}
+}
+
+func (e *SSHNodeExec) connect(ctx context.Context) (*ssh.Client, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.client != nil {
+		return e.client, nil
+	}
+
+	targetConfig, err := e.clientConfig(e.config.Target)
+	if err != nil {
+		return nil, err
+	}
+	targetAddr := endpointAddress(e.config.Target)
+
+	if e.config.Bastion == nil {
+		conn, err := (&net.Dialer{Timeout: e.config.DialTimeout}).DialContext(ctx, "tcp", targetAddr)
+		if err != nil {
+			return nil, fmt.Errorf("SSH 연결 실패 (%s): %w", targetAddr, err)
+		}
+		client, err := newSSHClient(ctx, conn, targetAddr, targetConfig)
+		if err != nil {
+			return nil, err
+		}
+		e.client = client
+		return client, nil
+	}
+
+	bastionConfig, err := e.clientConfig(*e.config.Bastion)
+	if err != nil {
+		return nil, err
+	}
+	bastionAddr := endpointAddress(*e.config.Bastion)
+	bastionConn, err := (&net.Dialer{Timeout: e.config.DialTimeout}).DialContext(ctx, "tcp", bastionAddr)
+	if err != nil {
+		return nil, fmt.Errorf("Bastion 연결 실패 (%s): %w", bastionAddr, err)
+	}
+	bastion, err := newSSHClient(ctx, bastionConn, bastionAddr, bastionConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	conn, err := dialThroughBastion(ctx, bastion, targetAddr, e.config.DialTimeout)
+	if err != nil {
+		bastion.Close()
+		return nil, fmt.Errorf("Bastion 경유 연결 실패 (%s): %w", targetAddr, err)
+	}
+	client, err := newSSHClient(ctx, conn, targetAddr, targetConfig)
+	if err != nil {
+		bastion.Close()
+		return nil, err
+	}
+
+	e.bastion = bastion
+	e.client = client
+	return client, nil
+}
+
+func (e *SSHNodeExec) clientConfig(endpoint SSHEndpoint) (*ssh.ClientConfig, error) {
+	privateKey := endpoint.PrivateKey
+	// Bastion 키가 없으면 대상 노드와 같은 키 사용 (ec2-worker 모듈과 동일)
+	if len(privateKey) == 0 {
+		privateKey = e.config.Target.PrivateKey
+	}
+	signer, err := ssh.ParsePrivateKey(privateKey)
+	if err != nil {
+		return nil, fmt.Errorf("SSH 개인키 파싱 실패: %w", err)
+	}
+
+	return &ssh.ClientConfig{
+		User:            endpoint.User,
+		Auth:            []ssh.AuthMethod{ssh.PublicKeys(signer)},
+		HostKeyCallback: e.config.HostKeyCallback,
+		Timeout:         e.config.DialTimeout,
+	}, nil
+}
+
+// newSSHClient conn 위에서 SSH 핸드셰이크 수행
+// ssh.NewClientConn은 config.Timeout을 적용하지 않으므로, 응답하지 않는 서버에 묶이지 않도록
+// ctx가 끝나거나 config.Timeout이 지나면 conn을 닫아 핸드셰이크를 중단
+func newSSHClient(ctx context.Context, conn net.Conn, addr string, config *ssh.ClientConfig) (*ssh.Client, error) {
+	if config.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, config.Timeout)
+		defer cancel()
+	}
+
+	type handshake struct {
+		conn     ssh.Conn
+		channels <-chan ssh.NewChannel
+		requests <-chan *ssh.Request
+		err      error
+	}
+	done := make(chan handshake, 1)
+	go func() {
+		clientConn, channels, requests, err := ssh.NewClientConn(conn, addr, config)
+		done <- handshake{clientConn, channels, requests, err}
+	}()
+
+	select {
+	case result := <-done:
+		if result.err != nil {
+			conn.Close()
+			return nil, fmt.Errorf("SSH 핸드셰이크 실패 (%s): %w", addr, result.err)
+		}
+		return ssh.NewClient(result.conn, result.channels, result.requests), nil
+	case <-ctx.Done():
+		conn.Close()
+		// 핸드셰이크 고루틴이 끝나기를 기다림 (직전에 성공했다면 그 연결도 닫음)
+		if result := <-done; result.err == nil {
+			result.conn.Close()
+		}
+		return nil, fmt.Errorf("SSH 핸드셰이크 타임아웃 (%s): %w", addr, ctx.Err())
+	}
+}
+
+// dialThroughBastion Bastion을 경유해 addr에 연결
+// ssh.Client.Dial은 ctx를 받지 않으므로 고루틴에서 연결하고 ctx가 끝나거나 timeout이 지나면 기다리지 않고 반환
+func dialThroughBastion(ctx context.Context, bastion *ssh.Client, addr string, timeout time.Duration) (net.Conn, error) {
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	type dialResult struct {
+		conn net.Conn
+		err  error
+	}
+	done := make(chan dialResult, 1)
+	go func() {
+		conn, err := bastion.Dial("tcp", addr)
+		done <- dialResult{conn, err}
+	}()
+
+	select {
+	case result := <-done:
+		return result.conn, result.err
+	case <-ctx.Done():
+		// 호출자가 Bastion 연결을 닫으면 Dial도 끝나며, 그 사이 성립한 연결은 닫음
+		go func() {
+			if result := <-done; result.err == nil {
+				result.conn.Close()
+			}
+		}()
+		return nil, ctx.Err()
+	}
+}
+
+func endpointAddress(endpoint SSHEndpoint) string {
+	port := endpoint.Port
+	if port == 0 {
+		port = 22
+	}
+	return net.JoinHostPort(endpoint.Host, strconv.Itoa(port))
+}
@@ -0,0 +1,137 @@
+package helpers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/ssm"
+	"github.com/aws/aws-sdk-go/service/ssm/ssmiface"
+)
+
+// SSMNodeExec SSM Run Command로 노드 명령을 실행하는 NodeExec 구현
+//
+// SSM은 실행이 끝난 뒤에만 출력을 돌려주므로 Stdout/Stderr 스트리밍은 완료 시점에 한 번 기록됩니다.
+type SSMNodeExec struct {
+	client       ssmiface.SSMAPI
+	instanceID   string
+	PollInterval time.Duration
+}
+
+// NewSSMNodeExec 새로운 SSM NodeExec 생성
+func NewSSMNodeExec(client ssmiface.SSMAPI, instanceID string) *SSMNodeExec {
+	return &SSMNodeExec{
+		client:       client,
+		instanceID:   instanceID,
+		PollInterval: 2 * time.Second,
+	}
+}
+
+// NewSSMNodeExec 클라이언트의 SSM 세션으로 NodeExec 생성
+func (c *AWSTestClient) NewSSMNodeExec(instanceID string) *SSMNodeExec {
+	return NewSSMNodeExec(c.SSM, instanceID)
+}
+
+// Exec NodeExec 구현
+func (e *SSMNodeExec) Exec(ctx context.Context, command NodeCommand) (*NodeCommandResult, error) {
+	ctx, cancel := commandContext(ctx, command)
+	defer cancel()
+
+	input := &ssm.SendCommandInput{
+		DocumentName: aws.String("AWS-RunShellScript"),
+		InstanceIds:  []*string{aws.String(e.instanceID)},
+		Parameters: map[string][]*string{
+			"commands": {aws.String(buildShellCommand(command))},
+		},
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		// SSM 실행 타임아웃은 초 단위이며 최소 30초
+		seconds := int64(time.Until(deadline).Seconds())
+		if seconds < 30 {
+			seconds = 30
+		}
+		input.Parameters["executionTimeout"] = []*string{aws.String(fmt.Sprintf("%d", seconds))}
+	}
+
+	sent, err := e.client.SendCommandWithContext(ctx, input)
+	if err != nil {
+		return nil, fmt.Errorf("SSM 명령 전송 실패: %w", err)
+	}
+	commandID := aws.StringValue(sent.Command.CommandId)
+
+	ticker := time.NewTicker(e.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		invocation, err := e.client.GetCommandInvocationWithContext(ctx, &ssm.GetCommandInvocationInput{
+			CommandId:  aws.String(commandID),
+			InstanceId: aws.String(e.instanceID),
+		})
+		if err != nil && !isInvocationPending(err) {
+			if ctx.Err() != nil {
+				return e.cancel(commandID, ctx.Err())
+			}
+			return nil, fmt.Errorf("SSM 명령 상태 조회 실패: %w", err)
+		}
+
+		if err == nil && isTerminalInvocationStatus(aws.StringValue(invocation.Status)) {
+			result := &NodeCommandResult{
+				Stdout:   aws.StringValue(invocation.StandardOutputContent),
+				Stderr:   aws.StringValue(invocation.StandardErrorContent),
+				ExitCode: int(aws.Int64Value(invocation.ResponseCode)),
+			}
+			if command.Stdout != nil {
+				_, _ = command.Stdout.Write([]byte(result.Stdout))
+			}
+			if command.Stderr != nil {
+				_, _ = command.Stderr.Write([]byte(result.Stderr))
+			}
+
+			status := aws.StringValue(invocation.Status)
+			if status == ssm.CommandInvocationStatusTimedOut || status == ssm.CommandInvocationStatusCancelled {
+				return result, fmt.Errorf("SSM 명령이 완료되지 않았습니다: %s", status)
+			}
+			return result, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return e.cancel(commandID, ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
+
+// Close SSM은 유지하는 연결이 없으므로 아무 작업도 하지 않음
+func (e *SSMNodeExec) Close() error {
+	return nil
+}
+
+func (e *SSMNodeExec) cancel(commandID string, cause error) (*NodeCommandResult, error) {
+	_, _ = e.client.CancelCommand(&ssm.CancelCommandInput{
+		CommandId:   aws.String(commandID),
+		InstanceIds: []*string{aws.String(e.instanceID)},
+	})
+	return &NodeCommandResult{ExitCode: -1}, fmt.Errorf("SSM 명령 %s: %w", interruptReason(cause), cause)
+}
+
+// 명령 전송 직후에는 호출 정보가 아직 생성되지 않았을 수 있음
+func isInvocationPending(err error) bool {
+	if aerr, ok := err.(awserr.Error); ok {
+		return aerr.Code() == ssm.ErrCodeInvocationDoesNotExist
+	}
+	return false
+}
+
+func isTerminalInvocationStatus(status string) bool {
+	switch status {
+	case ssm.CommandInvocationStatusSuccess,
+		ssm.CommandInvocationStatusFailed,
+		ssm.CommandInvocationStatusTimedOut,
+		ssm.CommandInvocationStatusCancelled:
+		return true
+	}
+	return false
+}
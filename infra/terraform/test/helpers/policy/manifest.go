@@ -0,0 +1,157 @@
+package policy
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	k8syaml "k8s.io/apimachinery/pkg/util/yaml"
+	"k8s.io/client-go/kubernetes/scheme"
+)
+
+// Document 매니페스트 파일의 YAML 문서 하나
+type Document struct {
+	Path   string
+	Line   int // 문서가 시작하는 줄 번호
+	Raw    []byte
+	GVK    schema.GroupVersionKind
+	Object runtime.Object // 쿠버네티스 스킴에 없는 종류(CRD 등)면 nil
+	Err    error          // 디코딩 실패 사유
+}
+
+// Name 문서의 namespace/name
+func (d Document) Name() string {
+	accessor, ok := d.Object.(interface {
+		GetNamespace() string
+		GetName() string
+	})
+	if !ok {
+		return ""
+	}
+	if accessor.GetNamespace() == "" {
+		return accessor.GetName()
+	}
+	return accessor.GetNamespace() + "/" + accessor.GetName()
+}
+
+var documentSeparator = regexp.MustCompile(`^---\s*(#.*)?$`)
+
+// DecodeManifest 다중 문서 YAML을 쿠버네티스 스킴으로 디코딩
+//
+// 주석만 있는 문서는 건너뛰며, 디코딩에 실패한 문서는 Err를 채워 반환합니다.
+// Line은 문서에서 주석을 제외한 첫 줄을 가리킵니다.
+func DecodeManifest(path string, data []byte) []Document {
+	var documents []Document
+	decoder := scheme.Codecs.UniversalDeserializer()
+
+	for _, chunk := range splitDocuments(data) {
+		offset, ok := firstContentLine(chunk.raw)
+		if !ok {
+			continue
+		}
+		document := Document{Path: path, Line: chunk.line + offset, Raw: chunk.raw}
+
+		jsonData, err := k8syaml.ToJSON(chunk.raw)
+		if err != nil {
+			document.Err = fmt.Errorf("YAML 파싱 실패: %w", err)
+			documents = append(documents, document)
+			continue
+		}
+
+		object, gvk, err := decoder.Decode(jsonData, nil, nil)
+		if gvk != nil {
+			document.GVK = *gvk
+		}
+		switch {
+		case err == nil:
+			document.Object = object
+		case runtime.IsNotRegisteredError(err):
+			// CRD 등 스킴 밖의 리소스는 종류만 기록
+		default:
+			document.Err = err
+		}
+		documents = append(documents, document)
+	}
+	return documents
+}
+
+// LoadManifests 파일 또는 디렉터리(*.yml, *.yaml)의 매니페스트 로드
+func LoadManifests(paths ...string) ([]Document, error) {
+	var files []string
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, err
+		}
+		if !info.IsDir() {
+			files = append(files, path)
+			continue
+		}
+		for _, pattern := range []string{"*.yml", "*.yaml"} {
+			matches, err := filepath.Glob(filepath.Join(path, pattern))
+			if err != nil {
+				return nil, err
+			}
+			files = append(files, matches...)
+		}
+	}
+	sort.Strings(files)
+
+	var documents []Document
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		documents = append(documents, DecodeManifest(file, data)...)
+	}
+	return documents, nil
+}
+
+type documentChunk struct {
+	line int
+	raw  []byte
+}
+
+func splitDocuments(data []byte) []documentChunk {
+	var chunks []documentChunk
+	current := documentChunk{line: 1}
+	var buf bytes.Buffer
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 0, 64*1024), 10*1024*1024)
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := scanner.Text()
+		if documentSeparator.MatchString(text) {
+			current.raw = append([]byte(nil), buf.Bytes()...)
+			chunks = append(chunks, current)
+			buf.Reset()
+			current = documentChunk{line: line + 1}
+			continue
+		}
+		buf.WriteString(text)
+		buf.WriteByte('\n')
+	}
+	current.raw = buf.Bytes()
+	return append(chunks, current)
+}
+
+// 주석/빈 줄이 아닌 첫 줄의 위치 (없으면 빈 문서)
+func firstContentLine(raw []byte) (int, bool) {
+	for i, line := range strings.Split(string(raw), "\n") {
+		trimmed := strings.TrimSpace(line)
+		if trimmed != "" && !strings.HasPrefix(trimmed, "#") {
+			return i, true
+		}
+	}
+	return 0, false
+}
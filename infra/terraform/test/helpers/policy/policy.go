@@ -0,0 +1,312 @@
+package policy
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Severity 정책 위반 심각도
+type Severity string
+
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+)
+
+// 정책 규칙
+const (
+	RuleDecode            = "decode"
+	RuleImageTag          = "image-tag"
+	RuleResources         = "resources"
+	RuleProbes            = "probes"
+	RuleLinkerdInject     = "linkerd-inject"
+	RuleNodePortRange     = "nodeport-range"
+	RuleNodePortCollision = "nodeport-collision"
+)
+
+const (
+	linkerdInjectAnnotation = "linkerd.io/inject"
+	nodePortMin             = 30000
+	nodePortMax             = 32767
+)
+
+// Finding 정책 위반 항목
+type Finding struct {
+	Path     string
+	Line     int
+	Kind     string
+	Name     string // namespace/name
+	Rule     string
+	Severity Severity
+	Message  string
+}
+
+func (f Finding) String() string {
+	return fmt.Sprintf("%s:%d: [%s] %s %s (%s): %s", f.Path, f.Line, f.Severity, f.Kind, f.Name, f.Rule, f.Message)
+}
+
+// Options 정책 설정
+type Options struct {
+	// LinkerdNamespaces 메시 주입이 필요한 네임스페이스
+	LinkerdNamespaces []string
+	// ReservedNodePorts 매니페스트 밖(Helm 차트 등)에서 사용하는 NodePort와 사용처
+	ReservedNodePorts map[int32]string
+	// ProbeSeverity 프로브 누락 심각도 (기본 warning)
+	ProbeSeverity Severity
+}
+
+// DefaultOptions bookinfo 네임스페이스에 Linkerd 주입을 요구하는 기본 설정
+func DefaultOptions() Options {
+	return Options{
+		LinkerdNamespaces: []string{"bookinfo"},
+		ReservedNodePorts: map[int32]string{},
+		ProbeSeverity:     SeverityWarning,
+	}
+}
+
+// Evaluate 모든 문서에 정책 적용
+func Evaluate(documents []Document, options Options) []Finding {
+	if options.ProbeSeverity == "" {
+		options.ProbeSeverity = SeverityWarning
+	}
+
+	var findings []Finding
+	for _, document := range documents {
+		report := func(rule string, severity Severity, format string, args ...interface{}) {
+			findings = append(findings, Finding{
+				Path: document.Path, Line: document.Line, Kind: document.GVK.Kind, Name: document.Name(),
+				Rule: rule, Severity: severity, Message: fmt.Sprintf(format, args...),
+			})
+		}
+
+		if document.Err != nil {
+			report(RuleDecode, SeverityError, "%v", document.Err)
+			continue
+		}
+
+		if template, ok := podTemplate(document.Object); ok {
+			checkPodSpec(template.Spec, options, report)
+			if value, ok := template.Annotations[linkerdInjectAnnotation]; ok && value != "enabled" && contains(options.LinkerdNamespaces, namespaceOf(document.Object)) {
+				report(RuleLinkerdInject, SeverityError, "Pod 템플릿이 %s=%s로 메시 주입을 끕니다", linkerdInjectAnnotation, value)
+			}
+		}
+
+		if namespace, ok := document.Object.(*corev1.Namespace); ok && contains(options.LinkerdNamespaces, namespace.Name) {
+			if value := namespace.Annotations[linkerdInjectAnnotation]; value != "enabled" {
+				report(RuleLinkerdInject, SeverityError, "네임스페이스에 %s: enabled 어노테이션이 없습니다", linkerdInjectAnnotation)
+			}
+		}
+
+		if service, ok := document.Object.(*corev1.Service); ok {
+			for _, port := range service.Spec.Ports {
+				if port.NodePort != 0 && (port.NodePort < nodePortMin || port.NodePort > nodePortMax) {
+					report(RuleNodePortRange, SeverityError, "NodePort %d가 허용 범위(%d-%d)를 벗어났습니다", port.NodePort, nodePortMin, nodePortMax)
+				}
+			}
+		}
+	}
+
+	return append(findings, nodePortCollisions(documents, options)...)
+}
+
+// Errors 심각도가 error인 항목만 반환
+func Errors(findings []Finding) []Finding {
+	var errs []Finding
+	for _, finding := range findings {
+		if finding.Severity == SeverityError {
+			errs = append(errs, finding)
+		}
+	}
+	return errs
+}
+
+func checkPodSpec(spec corev1.PodSpec, options Options, report func(string, Severity, string, ...interface{})) {
+	containers := append(append([]corev1.Container(nil), spec.InitContainers...), spec.Containers...)
+	for i, container := range containers {
+		initContainer := i < len(spec.InitContainers)
+
+		if problem := imageTagProblem(container.Image); problem != "" {
+			report(RuleImageTag, SeverityError, "컨테이너 %s 이미지 %q: %s", container.Name, container.Image, problem)
+		}
+
+		var missing []string
+		for _, resources := range []struct {
+			kind string
+			list corev1.ResourceList
+		}{{"requests", container.Resources.Requests}, {"limits", container.Resources.Limits}} {
+			for _, name := range []corev1.ResourceName{corev1.ResourceCPU, corev1.ResourceMemory} {
+				if _, ok := resources.list[name]; !ok {
+					missing = append(missing, resources.kind+"."+string(name))
+				}
+			}
+		}
+		if len(missing) > 0 {
+			report(RuleResources, SeverityError, "컨테이너 %s에 %s가 없습니다", container.Name, strings.Join(missing, ", "))
+		}
+
+		// init 컨테이너는 프로브를 지원하지 않음
+		if initContainer {
+			continue
+		}
+		var probes []string
+		if container.LivenessProbe == nil {
+			probes = append(probes, "livenessProbe")
+		}
+		if container.ReadinessProbe == nil {
+			probes = append(probes, "readinessProbe")
+		}
+		if len(probes) > 0 {
+			report(RuleProbes, options.ProbeSeverity, "컨테이너 %s에 %s가 없습니다", container.Name, strings.Join(probes, ", "))
+		}
+	}
+}
+
+// 태그가 고정되지 않은 이미지 판별 (digest 고정은 허용)
+func imageTagProblem(image string) string {
+	if strings.Contains(image, "@sha256:") {
+		return ""
+	}
+	name := image
+	if slash := strings.LastIndex(name, "/"); slash >= 0 {
+		name = name[slash+1:]
+	}
+	colon := strings.LastIndex(name, ":")
+	if colon < 0 {
+		return "태그가 없습니다 (latest로 해석됨)"
+	}
+	if tag := name[colon+1:]; tag == "latest" {
+		return "latest 태그는 고정된 버전이 아닙니다"
+	}
+	return ""
+}
+
+type nodePortUse struct {
+	owner    string // Service 식별자 또는 예약 출처
+	document *Document
+}
+
+func nodePortCollisions(documents []Document, options Options) []Finding {
+	uses := make(map[int32][]nodePortUse)
+	for port, owner := range options.ReservedNodePorts {
+		uses[port] = append(uses[port], nodePortUse{owner: owner})
+	}
+	for i := range documents {
+		service, ok := documents[i].Object.(*corev1.Service)
+		if !ok {
+			continue
+		}
+		for _, port := range service.Spec.Ports {
+			if port.NodePort == 0 {
+				continue
+			}
+			owner := fmt.Sprintf("Service %s (%s:%d)", documents[i].Name(), documents[i].Path, documents[i].Line)
+			uses[port.NodePort] = append(uses[port.NodePort], nodePortUse{owner: owner, document: &documents[i]})
+		}
+	}
+
+	ports := make([]int, 0, len(uses))
+	for port := range uses {
+		ports = append(ports, int(port))
+	}
+	sort.Ints(ports)
+
+	var findings []Finding
+	for _, port := range ports {
+		portUses := uses[int32(port)]
+		if len(portUses) < 2 {
+			continue
+		}
+		for i, use := range portUses {
+			if use.document == nil {
+				continue
+			}
+			var others []string
+			for j, other := range portUses {
+				if i != j {
+					others = append(others, other.owner)
+				}
+			}
+			findings = append(findings, Finding{
+				Path: use.document.Path, Line: use.document.Line, Kind: use.document.GVK.Kind, Name: use.document.Name(),
+				Rule: RuleNodePortCollision, Severity: SeverityError,
+				Message: fmt.Sprintf("NodePort %d가 %s와 충돌합니다", port, strings.Join(others, ", ")),
+			})
+		}
+	}
+	return findings
+}
+
+// ReservedNodePortsFromValues Helm values 파일에서 nodePort 값을 찾아 예약 목록 생성
+func ReservedNodePortsFromValues(path string) (map[int32]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var values map[string]interface{}
+	if err := yaml.Unmarshal(data, &values); err != nil {
+		return nil, fmt.Errorf("values 파일 파싱 실패: %w", err)
+	}
+
+	reserved := make(map[int32]string)
+	var walk func(prefix string, node interface{})
+	walk = func(prefix string, node interface{}) {
+		m, ok := node.(map[string]interface{})
+		if !ok {
+			return
+		}
+		for key, value := range m {
+			keyPath := strings.TrimPrefix(prefix+"."+key, ".")
+			if port, ok := value.(int); ok && key == "nodePort" {
+				reserved[int32(port)] = fmt.Sprintf("%s (%s)", keyPath, path)
+				continue
+			}
+			walk(keyPath, value)
+		}
+	}
+	walk("", values)
+	return reserved, nil
+}
+
+func podTemplate(object interface{}) (corev1.PodTemplateSpec, bool) {
+	switch o := object.(type) {
+	case *corev1.Pod:
+		return corev1.PodTemplateSpec{ObjectMeta: o.ObjectMeta, Spec: o.Spec}, true
+	case *appsv1.Deployment:
+		return o.Spec.Template, true
+	case *appsv1.StatefulSet:
+		return o.Spec.Template, true
+	case *appsv1.DaemonSet:
+		return o.Spec.Template, true
+	case *appsv1.ReplicaSet:
+		return o.Spec.Template, true
+	case *batchv1.Job:
+		return o.Spec.Template, true
+	case *batchv1.CronJob:
+		return o.Spec.JobTemplate.Spec.Template, true
+	}
+	return corev1.PodTemplateSpec{}, false
+}
+
+func namespaceOf(object interface{}) string {
+	if accessor, ok := object.(metav1.Object); ok {
+		return accessor.GetNamespace()
+	}
+	return ""
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
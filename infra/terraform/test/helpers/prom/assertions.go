@@ -0,0 +1,166 @@
+package prom
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gruntwork-io/terratest/modules/retry"
+	"github.com/gruntwork-io/terratest/modules/testing"
+	"github.com/k8s-ec2-observability/test/helpers/alerts"
+)
+
+const (
+	// NodeExporterJob kube-prometheus-stack의 node-exporter 스크레이프 job
+	NodeExporterJob = "node-exporter"
+	// KubeStateMetricsJob kube-prometheus-stack의 kube-state-metrics 스크레이프 job
+	KubeStateMetricsJob = "kube-state-metrics"
+)
+
+// CheckNodeExporterTargets 노드 IP마다 up 상태의 node-exporter 타깃이 있는지 검사
+//
+// node-exporter는 hostNetwork로 뜨므로 instance 레이블의 호스트가 노드 프라이빗 IP입니다.
+func CheckNodeExporterTargets(targets *Targets, nodeIPs []string) []string {
+	byHost := make(map[string]Target)
+	for _, target := range targets.ByJob(NodeExporterJob) {
+		byHost[instanceHost(target.Instance())] = target
+	}
+
+	var problems []string
+	for _, ip := range nodeIPs {
+		target, ok := byHost[ip]
+		switch {
+		case !ok:
+			problems = append(problems, fmt.Sprintf("노드 %s의 %s 타깃이 없습니다", ip, NodeExporterJob))
+		case target.Health != HealthUp:
+			problems = append(problems, fmt.Sprintf("노드 %s의 %s 타깃이 %s입니다: %s", ip, NodeExporterJob, target.Health, target.LastError))
+		}
+	}
+	return problems
+}
+
+// CheckJobUp job의 타깃이 하나 이상 있고 모두 up인지 검사
+func CheckJobUp(targets *Targets, job string) []string {
+	jobTargets := targets.ByJob(job)
+	if len(jobTargets) == 0 {
+		return []string{fmt.Sprintf("%s 타깃이 없습니다", job)}
+	}
+	var problems []string
+	for _, target := range jobTargets {
+		if target.Health != HealthUp {
+			problems = append(problems, fmt.Sprintf("%s 타깃 %s가 %s입니다: %s", job, target.Instance(), target.Health, target.LastError))
+		}
+	}
+	return problems
+}
+
+// CheckRulesLoaded PrometheusRule의 모든 그룹/규칙이 로드되어 오류 없이 평가되는지 검사
+//
+// Prometheus Operator는 규칙 파일 이름에 <namespace>-<PrometheusRule 이름>을 넣으므로,
+// 같은 이름의 그룹이 여러 파일에 있으면 파일 이름으로 구분합니다.
+func CheckRulesLoaded(groups []RuleGroup, rule *alerts.PrometheusRule) []string {
+	var problems []string
+	for _, expected := range rule.Spec.Groups {
+		loaded, ok := findGroup(groups, expected.Name, rule.Metadata.Name)
+		if !ok {
+			problems = append(problems, fmt.Sprintf("규칙 그룹 %s가 로드되지 않았습니다", expected.Name))
+			continue
+		}
+
+		byName := make(map[string]Rule)
+		for _, r := range loaded.Rules {
+			byName[r.Name] = r
+		}
+		for _, expectedRule := range expected.Rules {
+			r, ok := byName[expectedRule.Name()]
+			switch {
+			case !ok:
+				problems = append(problems, fmt.Sprintf("%s/%s 규칙이 로드되지 않았습니다", expected.Name, expectedRule.Name()))
+			case r.Health == RuleHealthErr:
+				problems = append(problems, fmt.Sprintf("%s/%s 규칙 평가 오류: %s", expected.Name, r.Name, r.LastError))
+			}
+		}
+	}
+	return problems
+}
+
+func findGroup(groups []RuleGroup, name, ruleName string) (RuleGroup, bool) {
+	var candidates []RuleGroup
+	for _, group := range groups {
+		if group.Name == name {
+			candidates = append(candidates, group)
+		}
+	}
+	if len(candidates) == 1 {
+		return candidates[0], true
+	}
+	for _, group := range candidates {
+		if strings.Contains(group.File, ruleName) {
+			return group, true
+		}
+	}
+	return RuleGroup{}, false
+}
+
+// FiringAlerts 이름별 firing 알림 (정렬된 이름 목록)
+func FiringAlerts(alertList []Alert) []string {
+	seen := make(map[string]bool)
+	for _, alert := range alertList {
+		if alert.State == AlertFiring {
+			seen[alert.Name()] = true
+		}
+	}
+	names := make([]string, 0, len(seen))
+	for name := range seen {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// WaitForScrapeTargets 모든 노드의 node-exporter와 kube-state-metrics가 up이 될 때까지 대기
+func WaitForScrapeTargets(t testing.TestingT, client *Client, nodeIPs []string, maxRetries int, sleepBetweenRetries time.Duration) (*Targets, error) {
+	var last *Targets
+
+	_, err := retry.DoWithRetryE(t, "Prometheus 스크레이프 타깃 대기", maxRetries, sleepBetweenRetries, func() (string, error) {
+		targets, err := client.Targets(context.Background())
+		if err != nil {
+			return "", err
+		}
+		last = targets
+
+		problems := CheckNodeExporterTargets(targets, nodeIPs)
+		problems = append(problems, CheckJobUp(targets, KubeStateMetricsJob)...)
+		if len(problems) > 0 {
+			return "", fmt.Errorf("스크레이프 타깃 미준비: %s", strings.Join(problems, "; "))
+		}
+		return fmt.Sprintf("node-exporter %d개, kube-state-metrics up", len(nodeIPs)), nil
+	})
+
+	return last, err
+}
+
+// WaitForRulesLoaded PrometheusRule이 로드되어 오류 없이 평가될 때까지 대기
+func WaitForRulesLoaded(t testing.TestingT, client *Client, rule *alerts.PrometheusRule, maxRetries int, sleepBetweenRetries time.Duration) error {
+	_, err := retry.DoWithRetryE(t, fmt.Sprintf("PrometheusRule %s 로드 대기", rule.Metadata.Name), maxRetries, sleepBetweenRetries, func() (string, error) {
+		groups, err := client.Rules(context.Background())
+		if err != nil {
+			return "", err
+		}
+		if problems := CheckRulesLoaded(groups, rule); len(problems) > 0 {
+			return "", fmt.Errorf("규칙 미준비: %s", strings.Join(problems, "; "))
+		}
+		return fmt.Sprintf("규칙 그룹 %d개 로드", len(rule.Spec.Groups)), nil
+	})
+	return err
+}
+
+func instanceHost(instance string) string {
+	if host, _, err := net.SplitHostPort(instance); err == nil {
+		return host
+	}
+	return instance
+}
@@ -0,0 +1,378 @@
+package prom
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// Namespace deploy-observability-stack.sh가 kube-prometheus-stack을 설치하는 네임스페이스
+	Namespace = "monitoring"
+	// ServiceName 릴리스 이름 prometheus로 설치한 Prometheus 서비스
+	ServiceName = "prometheus-kube-prometheus-prometheus"
+	// ServicePort Prometheus 웹/API 포트
+	ServicePort = 9090
+
+	defaultTimeout = 30 * time.Second
+)
+
+// APIError Prometheus API가 status=error로 응답한 경우
+type APIError struct {
+	StatusCode int
+	ErrorType  string
+	Message    string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("Prometheus API 오류 (HTTP %d, %s): %s", e.StatusCode, e.ErrorType, e.Message)
+}
+
+// Client Prometheus HTTP API(/api/v1) 클라이언트
+type Client struct {
+	baseURL    *url.URL
+	httpClient *http.Client
+}
+
+// NewClient Prometheus 주소(예: http://localhost:9090)로 클라이언트 생성
+//
+// httpClient가 nil이면 30초 타임아웃의 기본 클라이언트를 사용합니다.
+func NewClient(address string, httpClient *http.Client) (*Client, error) {
+	baseURL, err := url.Parse(strings.TrimSuffix(address, "/"))
+	if err != nil {
+		return nil, fmt.Errorf("Prometheus 주소 파싱 실패: %w", err)
+	}
+	if baseURL.Scheme == "" || baseURL.Host == "" {
+		return nil, fmt.Errorf("Prometheus 주소에 scheme과 host가 필요합니다: %q", address)
+	}
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: defaultTimeout}
+	}
+	return &Client{baseURL: baseURL, httpClient: httpClient}, nil
+}
+
+// API 공통 응답 형식
+type response struct {
+	Status    string          `json:"status"`
+	Data      json.RawMessage `json:"data"`
+	ErrorType string          `json:"errorType"`
+	Error     string          `json:"error"`
+	Warnings  []string        `json:"warnings"`
+}
+
+// get /api/v1 하위 경로를 조회하여 data 필드를 out으로 디코딩
+func (c *Client) get(ctx context.Context, path string, params url.Values, out interface{}) error {
+	endpoint := *c.baseURL
+	endpoint.Path += "/api/v1/" + path
+	endpoint.RawQuery = params.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint.String(), nil)
+	if err != nil {
+		return err
+	}
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("Prometheus %s 요청 실패: %w", path, err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("Prometheus %s 응답 읽기 실패: %w", path, err)
+	}
+
+	var decoded response
+	if err := json.Unmarshal(body, &decoded); err != nil {
+		return fmt.Errorf("Prometheus %s 응답 파싱 실패 (HTTP %d): %w", path, resp.StatusCode, err)
+	}
+	if decoded.Status != "success" {
+		return &APIError{StatusCode: resp.StatusCode, ErrorType: decoded.ErrorType, Message: decoded.Error}
+	}
+	if out == nil {
+		return nil
+	}
+	if err := json.Unmarshal(decoded.Data, out); err != nil {
+		return fmt.Errorf("Prometheus %s data 파싱 실패: %w", path, err)
+	}
+	return nil
+}
+
+// Target 스크레이프 대상
+type Target struct {
+	DiscoveredLabels   map[string]string `json:"discoveredLabels"`
+	Labels             map[string]string `json:"labels"`
+	ScrapePool         string            `json:"scrapePool"`
+	ScrapeURL          string            `json:"scrapeUrl"`
+	LastError          string            `json:"lastError"`
+	LastScrape         time.Time         `json:"lastScrape"`
+	LastScrapeDuration float64           `json:"lastScrapeDuration"`
+	Health             string            `json:"health"`
+}
+
+// 타깃 상태
+const (
+	HealthUp      = "up"
+	HealthDown    = "down"
+	HealthUnknown = "unknown"
+)
+
+// Job job 레이블
+func (t Target) Job() string {
+	return t.Labels["job"]
+}
+
+// Instance instance 레이블
+func (t Target) Instance() string {
+	return t.Labels["instance"]
+}
+
+// Targets /api/v1/targets 결과
+type Targets struct {
+	Active  []Target `json:"activeTargets"`
+	Dropped []Target `json:"droppedTargets"`
+}
+
+// ByJob job 레이블이 일치하는 활성 타깃
+func (t *Targets) ByJob(job string) []Target {
+	var targets []Target
+	for _, target := range t.Active {
+		if target.Job() == job {
+			targets = append(targets, target)
+		}
+	}
+	return targets
+}
+
+// Targets 활성/제외된 스크레이프 대상 조회
+func (c *Client) Targets(ctx context.Context) (*Targets, error) {
+	var targets Targets
+	if err := c.get(ctx, "targets", url.Values{"state": {"any"}}, &targets); err != nil {
+		return nil, err
+	}
+	return &targets, nil
+}
+
+// Sample 인스턴트 쿼리 결과의 샘플 하나
+type Sample struct {
+	Metric    map[string]string
+	Timestamp time.Time
+	Value     float64
+}
+
+// Series 범위 쿼리 결과의 시계열 하나
+type Series struct {
+	Metric map[string]string
+	Points []Point
+}
+
+// Point 시계열의 값 하나
+type Point struct {
+	Timestamp time.Time
+	Value     float64
+}
+
+// 결과 타입
+const (
+	ResultVector = "vector"
+	ResultMatrix = "matrix"
+	ResultScalar = "scalar"
+	ResultString = "string"
+)
+
+// QueryResult 쿼리 결과
+//
+// vector와 scalar는 Samples에, matrix는 Series에 채웁니다.
+type QueryResult struct {
+	ResultType string
+	Samples    []Sample
+	Series     []Series
+}
+
+// Query 인스턴트 쿼리 (ts가 0이면 서버 현재 시각)
+func (c *Client) Query(ctx context.Context, query string, ts time.Time) (*QueryResult, error) {
+	params := url.Values{"query": {query}}
+	if !ts.IsZero() {
+		params.Set("time", formatTime(ts))
+	}
+	return c.query(ctx, "query", params)
+}
+
+// Range 범위 쿼리 구간
+type Range struct {
+	Start time.Time
+	End   time.Time
+	Step  time.Duration
+}
+
+// QueryRange 범위 쿼리
+func (c *Client) QueryRange(ctx context.Context, query string, r Range) (*QueryResult, error) {
+	params := url.Values{
+		"query": {query},
+		"start": {formatTime(r.Start)},
+		"end":   {formatTime(r.End)},
+		"step":  {strconv.FormatFloat(r.Step.Seconds(), 'f', -1, 64)},
+	}
+	return c.query(ctx, "query_range", params)
+}
+
+func (c *Client) query(ctx context.Context, path string, params url.Values) (*QueryResult, error) {
+	var raw struct {
+		ResultType string          `json:"resultType"`
+		Result     json.RawMessage `json:"result"`
+	}
+	if err := c.get(ctx, path, params, &raw); err != nil {
+		return nil, err
+	}
+
+	result := &QueryResult{ResultType: raw.ResultType}
+	switch raw.ResultType {
+	case ResultVector:
+		var samples []struct {
+			Metric map[string]string `json:"metric"`
+			Value  samplePair        `json:"value"`
+		}
+		if err := json.Unmarshal(raw.Result, &samples); err != nil {
+			return nil, fmt.Errorf("vector 결과 파싱 실패: %w", err)
+		}
+		for _, s := range samples {
+			result.Samples = append(result.Samples, Sample{Metric: s.Metric, Timestamp: s.Value.Timestamp, Value: s.Value.Value})
+		}
+	case ResultScalar:
+		var pair samplePair
+		if err := json.Unmarshal(raw.Result, &pair); err != nil {
+			return nil, fmt.Errorf("scalar 결과 파싱 실패: %w", err)
+		}
+		result.Samples = []Sample{{Metric: map[string]string{}, Timestamp: pair.Timestamp, Value: pair.Value}}
+	case ResultMatrix:
+		var series []struct {
+			Metric map[string]string `json:"metric"`
+			Values []samplePair      `json:"values"`
+		}
+		if err := json.Unmarshal(raw.Result, &series); err != nil {
+			return nil, fmt.Errorf("matrix 결과 파싱 실패: %w", err)
+		}
+		for _, s := range series {
+			points := make([]Point, 0, len(s.Values))
+			for _, v := range s.Values {
+				points = append(points, Point(v))
+			}
+			result.Series = append(result.Series, Series{Metric: s.Metric, Points: points})
+		}
+	case ResultString:
+		// 문자열 결과는 값 검증에 쓰지 않으므로 타입만 기록
+	default:
+		return nil, fmt.Errorf("알 수 없는 결과 타입: %q", raw.ResultType)
+	}
+	return result, nil
+}
+
+// [<unix 초>, "<값>"] 형식의 샘플
+type samplePair struct {
+	Timestamp time.Time
+	Value     float64
+}
+
+func (p *samplePair) UnmarshalJSON(data []byte) error {
+	var raw [2]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	var seconds float64
+	if err := json.Unmarshal(raw[0], &seconds); err != nil {
+		return fmt.Errorf("타임스탬프 파싱 실패: %w", err)
+	}
+	var value string
+	if err := json.Unmarshal(raw[1], &value); err != nil {
+		return fmt.Errorf("샘플 값 파싱 실패: %w", err)
+	}
+	parsed, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return fmt.Errorf("샘플 값 파싱 실패: %w", err)
+	}
+	p.Timestamp = time.Unix(0, int64(seconds*float64(time.Second))).UTC()
+	p.Value = parsed
+	return nil
+}
+
+// RuleGroup 로드된 규칙 그룹
+type RuleGroup struct {
+	Name           string    `json:"name"`
+	File           string    `json:"file"`
+	Rules          []Rule    `json:"rules"`
+	Interval       float64   `json:"interval"`
+	LastEvaluation time.Time `json:"lastEvaluation"`
+}
+
+// 규칙 종류와 상태
+const (
+	RuleAlerting  = "alerting"
+	RuleRecording = "recording"
+
+	RuleHealthOK      = "ok"
+	RuleHealthErr     = "err"
+	RuleHealthUnknown = "unknown"
+)
+
+// Rule 알림/기록 규칙의 평가 상태
+type Rule struct {
+	Name      string            `json:"name"`
+	Query     string            `json:"query"`
+	Type      string            `json:"type"`
+	Health    string            `json:"health"`
+	LastError string            `json:"lastError"`
+	State     string            `json:"state"` // 알림 규칙만: inactive, pending, firing
+	Labels    map[string]string `json:"labels"`
+	Alerts    []Alert           `json:"alerts"`
+}
+
+// Rules 로드된 규칙 그룹 조회
+func (c *Client) Rules(ctx context.Context) ([]RuleGroup, error) {
+	var data struct {
+		Groups []RuleGroup `json:"groups"`
+	}
+	if err := c.get(ctx, "rules", nil, &data); err != nil {
+		return nil, err
+	}
+	return data.Groups, nil
+}
+
+// 알림 상태
+const (
+	AlertPending = "pending"
+	AlertFiring  = "firing"
+)
+
+// Alert 활성 알림
+type Alert struct {
+	Labels      map[string]string `json:"labels"`
+	Annotations map[string]string `json:"annotations"`
+	State       string            `json:"state"`
+	ActiveAt    *time.Time        `json:"activeAt,omitempty"`
+	Value       string            `json:"value"`
+}
+
+// Name alertname 레이블
+func (a Alert) Name() string {
+	return a.Labels["alertname"]
+}
+
+// Alerts 활성(pending/firing) 알림 조회
+func (c *Client) Alerts(ctx context.Context) ([]Alert, error) {
+	var data struct {
+		Alerts []Alert `json:"alerts"`
+	}
+	if err := c.get(ctx, "alerts", nil, &data); err != nil {
+		return nil, err
+	}
+	return data.Alerts, nil
+}
+
+func formatTime(t time.Time) string {
+	return strconv.FormatFloat(float64(t.UnixNano())/float64(time.Second), 'f', -1, 64)
+}
@@ -0,0 +1,49 @@
+package promexpr
+
+import (
+	"regexp"
+
+	"github.com/prometheus/common/model"
+	"github.com/prometheus/prometheus/promql/parser"
+)
+
+// EC2 프라이빗 DNS 이름(ip-10-0-1-34) 또는 IPv4 주소 (정규식 매처의 \. 이스케이프 포함)
+var hardcodedNodePattern = regexp.MustCompile(`ip-\d{1,3}-\d{1,3}-\d{1,3}-\d{1,3}|\b\d{1,3}(?:\\?\.\d{1,3}){3}\b`)
+
+// IsHardcodedNode 매처 값이 특정 노드 이름이나 IP를 가리키는지 여부
+func IsHardcodedNode(value string) bool {
+	return hardcodedNodePattern.MatchString(value)
+}
+
+// HardcodedNodeMatchers 노드 이름/IP가 고정된 레이블 매처 목록 (labels가 비어 있으면 메트릭 이름을 뺀 모든 레이블 검사)
+func HardcodedNodeMatchers(expr parser.Expr, labels ...string) []string {
+	var matchers []string
+	parser.Inspect(expr, func(node parser.Node, _ []parser.Node) error {
+		selector, ok := node.(*parser.VectorSelector)
+		if !ok {
+			return nil
+		}
+		for _, matcher := range selector.LabelMatchers {
+			if matcher.Name == model.MetricNameLabel || !checksLabel(labels, matcher.Name) {
+				continue
+			}
+			if IsHardcodedNode(matcher.Value) {
+				matchers = append(matchers, matcher.String())
+			}
+		}
+		return nil
+	})
+	return matchers
+}
+
+func checksLabel(labels []string, name string) bool {
+	if len(labels) == 0 {
+		return true
+	}
+	for _, label := range labels {
+		if label == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,160 @@
+package helpers
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gruntwork-io/terratest/modules/aws"
+	"github.com/gruntwork-io/terratest/modules/random"
+	"github.com/gruntwork-io/terratest/modules/ssh"
+	"github.com/gruntwork-io/terratest/modules/terraform"
+)
+
+// RootStackTestConfig 루트 스택(vpc → security → master → worker) 테스트 설정
+type RootStackTestConfig struct {
+	Region             string
+	UniqueID           string
+	TestFolder         string
+	Environment        string
+	ProjectName        string
+	AvailabilityZone   string
+	AMIID              string
+	MasterInstanceType string
+	NodeInstanceType   string
+	WorkerCount        int
+	VPCCIDR            string
+	Tags               map[string]string
+}
+
+// NewRootStackTestConfig 새로운 루트 스택 테스트 설정 생성
+//
+// 리소스 이름이 project_name에서 만들어지므로 프로젝트 이름에 고유 ID를 붙여 동시 실행과 충돌하지 않게 합니다.
+func NewRootStackTestConfig() *RootStackTestConfig {
+	uniqueID := strings.ToLower(random.UniqueId())
+
+	return &RootStackTestConfig{
+		Region:             "ap-northeast-2",
+		UniqueID:           uniqueID,
+		TestFolder:         "../../..",
+		Environment:        "test",
+		ProjectName:        fmt.Sprintf("k8s-obs-%s", uniqueID),
+		AvailabilityZone:   "ap-northeast-2a",
+		AMIID:              "ami-08943a151bd468f4e", // Ubuntu 22.04 LTS
+		MasterInstanceType: "t3.small",
+		NodeInstanceType:   "t3.micro",
+		WorkerCount:        2,
+		VPCCIDR:            "10.0.0.0/16",
+		Tags: map[string]string{
+			"TestType": "RootStack",
+			"UniqueID": uniqueID,
+		},
+	}
+}
+
+// TerraformVars 루트 스택 변수 (키 페어와 계정 정보는 apply 시점에 정해짐)
+func (c *RootStackTestConfig) TerraformVars(keyName, privateKeyPath, accountID, userARN string) map[string]interface{} {
+	return map[string]interface{}{
+		"region":                c.Region,
+		"project_name":          c.ProjectName,
+		"environment":           c.Environment,
+		"availability_zone":     c.AvailabilityZone,
+		"master_instance_type":  c.MasterInstanceType,
+		"node_instance_type":    c.NodeInstanceType,
+		"worker_instance_count": c.WorkerCount,
+		"ami_id":                c.AMIID,
+		"key_name":              keyName,
+		"private_key_path":      privateKeyPath,
+		"aws_account_id":        accountID,
+		"terraform_user_arn":    userARN,
+		"vpc_cidr":              c.VPCCIDR,
+		"default_tags":          c.Tags,
+	}
+}
+
+// RootStack apply된 루트 스택의 출력
+type RootStack struct {
+	Options           *terraform.Options
+	KeyPair           *aws.Ec2Keypair
+	PrivateKeyPath    string
+	VPCID             string
+	PublicSubnetID    string
+	PrivateSubnetID   string
+	SecurityGroupID   string
+	MasterInstanceID  string
+	MasterPublicIP    string
+	MasterPrivateIP   string
+	WorkerInstanceIDs []string
+	WorkerPrivateIPs  []string
+}
+
+// DeployRootStack 테스트용 키 페어를 만들고 루트 스택 전체를 apply
+//
+// destroy와 키 페어 삭제는 apply 전에 t.Cleanup으로 등록하므로 apply가 중간에 실패해도
+// 이미 만들어진 리소스가 정리됩니다. awsClient가 역할을 위임받았으면 키 페어, apply, destroy가
+// 모두 같은 역할 세션으로 실행되고, apply와 destroy 직전에 세션을 갱신합니다.
+func DeployRootStack(t *testing.T, awsClient *AWSTestClient, config *RootStackTestConfig) *RootStack {
+	identity, err := awsClient.GetCallerIdentity()
+	if err != nil {
+		t.Fatalf("AWS 자격 증명 조회 실패: %v", err)
+	}
+
+	// 키 파일 디렉터리를 먼저 만들어 destroy보다 늦게 지워지도록 합니다 (Cleanup은 역순 실행)
+	keyDir := t.TempDir()
+	rsaKeyPair, err := ssh.GenerateRSAKeyPairE(t, 2048)
+	if err != nil {
+		t.Fatalf("키 페어 생성 실패: %v", err)
+	}
+	keyPair := &aws.Ec2Keypair{
+		KeyPair: rsaKeyPair,
+		Name:    fmt.Sprintf("%s-key", config.ProjectName),
+		Region:  config.Region,
+	}
+	if err := awsClient.ImportEC2KeyPair(keyPair.Name, keyPair.PublicKey); err != nil {
+		t.Fatalf("키 페어 등록 실패: %v", err)
+	}
+	t.Cleanup(func() {
+		if err := awsClient.DeleteEC2KeyPair(keyPair.Name); err != nil {
+			t.Errorf("키 페어 %s 삭제 실패: %v", keyPair.Name, err)
+		}
+	})
+	privateKeyPath := filepath.Join(keyDir, keyPair.Name+".pem")
+	if err := os.WriteFile(privateKeyPath, []byte(keyPair.KeyPair.PrivateKey), 0o600); err != nil {
+		t.Fatalf("프라이빗 키 저장 실패: %v", err)
+	}
+
+	terraformOptions := SetupTerraform(t, TerraformConfig{
+		ModulePath: config.TestFolder,
+		Vars:       config.TerraformVars(keyPair.Name, privateKeyPath, *identity.Account, *identity.Arn),
+		EnvVars: map[string]string{
+			"AWS_DEFAULT_REGION": config.Region,
+		},
+		AWSClient: awsClient,
+	})
+	t.Cleanup(func() {
+		t.Logf("🧹 루트 스택 정리 중: %s", config.ProjectName)
+		if err := awsClient.RefreshTerraformCredentials(terraformOptions); err != nil {
+			t.Errorf("destroy 전 역할 위임 확인 실패: %v", err)
+		}
+		DestroyTerraform(t, terraformOptions)
+	})
+
+	ApplyTerraform(t, terraformOptions)
+
+	return &RootStack{
+		Options:           terraformOptions,
+		KeyPair:           keyPair,
+		PrivateKeyPath:    privateKeyPath,
+		VPCID:             terraform.Output(t, terraformOptions, "vpc_id"),
+		PublicSubnetID:    terraform.Output(t, terraformOptions, "public_subnet_id"),
+		PrivateSubnetID:   terraform.Output(t, terraformOptions, "private_subnet_id"),
+		SecurityGroupID:   terraform.Output(t, terraformOptions, "security_group_id"),
+		MasterInstanceID:  terraform.Output(t, terraformOptions, "master_instance_id"),
+		MasterPublicIP:    terraform.Output(t, terraformOptions, "master_public_ip"),
+		MasterPrivateIP:   terraform.Output(t, terraformOptions, "master_private_ip"),
+		WorkerInstanceIDs: terraform.OutputList(t, terraformOptions, "worker_instance_ids"),
+		WorkerPrivateIPs:  terraform.OutputList(t, terraformOptions, "worker_private_ips"),
+	}
+}
@@ -0,0 +1,114 @@
+package scripttest
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+)
+
+// 테스트 바이너리가 가짜 명령으로 실행될 때 전달되는 환경 변수
+const (
+	goFakeEnv    = "SCRIPTTEST_GO_FAKE"
+	goFakePIDDir = "SCRIPTTEST_PID_DIR"
+)
+
+// FakeFunc Go로 구현한 가짜 명령 (인자를 받아 종료 코드 반환)
+type FakeFunc func(args []string) int
+
+// RunFakeIfRequested 테스트 바이너리가 GoFake로 실행된 경우 가짜 명령을 실행하고 종료
+//
+// 패키지의 TestMain 맨 앞에서 호출해야 합니다.
+//
+//	func TestMain(m *testing.M) {
+//		scripttest.RunFakeIfRequested(map[string]scripttest.FakeFunc{"kubectl": fakeKubectl})
+//		os.Exit(m.Run())
+//	}
+func RunFakeIfRequested(fakes map[string]FakeFunc) {
+	name := os.Getenv(goFakeEnv)
+	if name == "" {
+		return
+	}
+	fake, ok := fakes[name]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "scripttest: 등록되지 않은 가짜 명령 %q\n", name)
+		os.Exit(127)
+	}
+
+	var pidFile string
+	if dir := os.Getenv(goFakePIDDir); dir != "" {
+		pidFile = filepath.Join(dir, strconv.Itoa(os.Getpid()))
+		_ = os.WriteFile(pidFile, []byte(name), 0o644)
+	}
+	code := fake(os.Args[1:])
+	if pidFile != "" {
+		_ = os.Remove(pidFile)
+	}
+	os.Exit(code)
+}
+
+// GoFake 현재 테스트 바이너리를 가짜 명령으로 등록 (동작은 RunFakeIfRequested에 넘긴 함수)
+//
+// argv[0]을 명령 이름으로 바꿔 실행하므로 pgrep/pkill -f 패턴에도 실제 명령처럼 보입니다.
+// 테스트가 끝나면 아직 살아 있는 가짜 프로세스를 모두 종료합니다.
+func (h *Harness) GoFake(name string) {
+	h.t.Helper()
+	executable, err := os.Executable()
+	if err != nil {
+		h.t.Fatalf("테스트 바이너리 경로 조회 실패: %v", err)
+	}
+	h.trackGoFakes()
+	h.Script(name, fmt.Sprintf("export %s=%s %s=%s\nexec -a %s %s \"$@\"\n",
+		goFakeEnv, shellQuote(name), goFakePIDDir, shellQuote(h.pidDir()), shellQuote(name), shellQuote(executable)))
+}
+
+// FakeProcesses 아직 실행 중인 GoFake 프로세스의 PID
+func (h *Harness) FakeProcesses() []int {
+	entries, err := os.ReadDir(h.pidDir())
+	if err != nil {
+		return nil
+	}
+	var pids []int
+	for _, entry := range entries {
+		pid, err := strconv.Atoi(entry.Name())
+		if err != nil {
+			continue
+		}
+		if ProcessAlive(pid) {
+			pids = append(pids, pid)
+		}
+	}
+	return pids
+}
+
+// ProcessAlive 프로세스가 실행 중인지 (좀비는 종료된 것으로 간주)
+func ProcessAlive(pid int) bool {
+	if stat, err := os.ReadFile(fmt.Sprintf("/proc/%d/stat", pid)); err == nil {
+		// /proc/<pid>/stat: "pid (comm) state ..." (comm에 공백이 있을 수 있어 마지막 ')' 기준)
+		if i := bytes.LastIndexByte(stat, ')'); i >= 0 {
+			fields := strings.Fields(string(stat[i+1:]))
+			return len(fields) > 0 && fields[0] != "Z" && fields[0] != "X"
+		}
+	}
+	return syscall.Kill(pid, 0) == nil
+}
+
+func (h *Harness) pidDir() string {
+	return filepath.Join(h.Dir, "pids")
+}
+
+func (h *Harness) trackGoFakes() {
+	h.trackOnce.Do(func() {
+		if err := os.MkdirAll(h.pidDir(), 0o755); err != nil {
+			h.t.Fatalf("가짜 프로세스 기록 디렉터리 생성 실패: %v", err)
+		}
+		h.t.Cleanup(func() {
+			for _, pid := range h.FakeProcesses() {
+				_ = syscall.Kill(pid, syscall.SIGKILL)
+			}
+		})
+	})
+}
@@ -0,0 +1,272 @@
+package scripttest
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// 호출 기록 구분자 (인자에 줄바꿈이 들어갈 수 있어 제어 문자를 사용)
+const (
+	argSeparator  = "\x1f"
+	callSeparator = "\x1e"
+)
+
+// DefaultTimeout 스크립트 실행 제한 시간
+const DefaultTimeout = 60 * time.Second
+
+// Response 가짜 명령의 응답 규칙
+//
+// Args는 인자 전체("$*")에 대한 bash glob 패턴이며, 위에서부터 처음 일치하는 규칙을 사용합니다.
+// Times가 0보다 크면 그 횟수만큼만 사용하고 다음 규칙으로 넘어가므로,
+// 같은 조회가 호출마다 다른 클러스터 상태를 돌려주도록 만들 수 있습니다.
+type Response struct {
+	Args     string
+	Stdout   string
+	Stderr   string
+	ExitCode int
+	Times    int
+}
+
+// Call 가짜 명령 호출 기록
+type Call struct {
+	Name string
+	Args []string
+}
+
+// Line 호출을 공백으로 이은 문자열
+func (c Call) Line() string {
+	return strings.Join(append([]string{c.Name}, c.Args...), " ")
+}
+
+// Result 스크립트 실행 결과
+type Result struct {
+	Stdout   string
+	Stderr   string
+	ExitCode int
+	Calls    []Call
+}
+
+// CallsTo 특정 명령의 호출 기록
+func (r *Result) CallsTo(name string) []Call {
+	var calls []Call
+	for _, call := range r.Calls {
+		if call.Name == name {
+			calls = append(calls, call)
+		}
+	}
+	return calls
+}
+
+// Harness 가짜 명령을 PATH 앞에 두고 셸 스크립트를 실행하는 테스트 도구
+type Harness struct {
+	t       *testing.T
+	Dir     string // 임시 작업 디렉터리 (스크립트의 HOME, TMPDIR로도 사용)
+	binDir  string
+	logPath string
+	env     map[string]string
+	fakes   int
+	// GoFake 프로세스 정리 등록 (한 번만)
+	trackOnce sync.Once
+	// Timeout 스크립트 실행 제한 시간 (기본 DefaultTimeout)
+	Timeout time.Duration
+	// WorkDir 스크립트 실행 디렉터리 (기본 Dir)
+	WorkDir string
+}
+
+// New 임시 디렉터리에 하네스 생성
+func New(t *testing.T) *Harness {
+	dir := t.TempDir()
+	h := &Harness{
+		t:       t,
+		Dir:     dir,
+		binDir:  filepath.Join(dir, "bin"),
+		logPath: filepath.Join(dir, "calls.log"),
+		env:     make(map[string]string),
+		Timeout: DefaultTimeout,
+	}
+	if err := os.MkdirAll(h.binDir, 0o755); err != nil {
+		t.Fatalf("가짜 명령 디렉터리 생성 실패: %v", err)
+	}
+	return h
+}
+
+// Setenv 스크립트 환경 변수 설정
+func (h *Harness) Setenv(key, value string) {
+	h.env[key] = value
+}
+
+// Fake 응답 규칙으로 동작하는 가짜 명령 등록
+//
+// 일치하는 규칙이 없으면 출력 없이 0으로 종료합니다.
+func (h *Harness) Fake(name string, responses ...Response) {
+	h.t.Helper()
+	h.fakes++
+	dataDir := filepath.Join(h.Dir, "fakes", fmt.Sprintf("%d-%s", h.fakes, name))
+	if err := os.MkdirAll(dataDir, 0o755); err != nil {
+		h.t.Fatalf("가짜 명령 데이터 디렉터리 생성 실패: %v", err)
+	}
+
+	var body strings.Builder
+	body.WriteString("args=\"$*\"\n")
+	body.WriteString("take() {\n  local file=" + shellQuote(dataDir) + "/count.$1 n=0\n  [[ -f \"$file\" ]] && n=$(cat \"$file\")\n  (( n < $2 )) || return 1\n  echo $((n + 1)) > \"$file\"\n}\n")
+	for i, response := range responses {
+		stdout := filepath.Join(dataDir, fmt.Sprintf("stdout.%d", i))
+		stderr := filepath.Join(dataDir, fmt.Sprintf("stderr.%d", i))
+		h.writeFile(stdout, response.Stdout, 0o644)
+		h.writeFile(stderr, response.Stderr, 0o644)
+
+		condition := "[[ $args == " + bashGlob(response.Args) + " ]]"
+		if response.Times > 0 {
+			condition += fmt.Sprintf(" && take %d %d", i, response.Times)
+		}
+		fmt.Fprintf(&body, "if %s; then\n  cat %s\n  cat %s >&2\n  exit %d\nfi\n", condition, shellQuote(stdout), shellQuote(stderr), response.ExitCode)
+	}
+	body.WriteString("exit 0\n")
+
+	h.Script(name, body.String())
+}
+
+// Script 임의의 bash 본문으로 가짜 명령 등록 (호출 기록은 자동으로 추가)
+func (h *Harness) Script(name, body string) {
+	h.t.Helper()
+	record := fmt.Sprintf("{ printf '%%s' %s; for a in \"$@\"; do printf '%s%%s' \"$a\"; done; printf '%s'; } >> %s\n",
+		shellQuote(name), `\037`, `\036`, shellQuote(h.logPath))
+	h.writeFile(filepath.Join(h.binDir, name), "#!/usr/bin/env bash\n"+record+body, 0o755)
+}
+
+// Run 스크립트를 bash로 실행하고 결과와 가짜 명령 호출 기록 반환
+//
+// 비정상 종료는 ExitCode로 돌려주며, 실행 자체가 실패하거나 제한 시간을 넘기면 테스트를 중단합니다.
+func (h *Harness) Run(script string, args ...string) *Result {
+	h.t.Helper()
+	if err := os.WriteFile(h.logPath, nil, 0o644); err != nil {
+		h.t.Fatalf("호출 기록 초기화 실패: %v", err)
+	}
+
+	// 실행 디렉터리가 바뀌므로 상대 경로는 미리 절대 경로로 변환
+	script, err := filepath.Abs(script)
+	if err != nil {
+		h.t.Fatalf("스크립트 경로 변환 실패: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), h.Timeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, "bash", append([]string{script}, args...)...)
+	cmd.Dir = h.WorkDir
+	if cmd.Dir == "" {
+		cmd.Dir = h.Dir
+	}
+	cmd.Env = h.environ()
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	err = cmd.Run()
+	if ctx.Err() != nil {
+		h.t.Fatalf("%s 실행이 %s 안에 끝나지 않았습니다\nstdout:\n%s\nstderr:\n%s", script, h.Timeout, stdout.String(), stderr.String())
+	}
+	result := &Result{Stdout: stdout.String(), Stderr: stderr.String()}
+	var exitErr *exec.ExitError
+	switch {
+	case errors.As(err, &exitErr):
+		result.ExitCode = exitErr.ExitCode()
+	case err != nil:
+		h.t.Fatalf("%s 실행 실패: %v", script, err)
+	}
+
+	result.Calls = h.calls()
+	return result
+}
+
+// RunFunction 스크립트를 source로 불러온 뒤 함수 하나만 실행
+//
+// 스크립트는 BASH_SOURCE 검사 등으로 source 시 main을 실행하지 않아야 합니다.
+func (h *Harness) RunFunction(script, function string, args ...string) *Result {
+	h.t.Helper()
+	absScript, err := filepath.Abs(script)
+	if err != nil {
+		h.t.Fatalf("스크립트 경로 변환 실패: %v", err)
+	}
+	wrapper := filepath.Join(h.Dir, "run-"+function+".sh")
+	h.writeFile(wrapper, fmt.Sprintf("source %s\n%s \"$@\"\n", shellQuote(absScript), function), 0o644)
+	return h.Run(wrapper, args...)
+}
+
+func (h *Harness) environ() []string {
+	env := map[string]string{
+		"PATH":   h.binDir + string(os.PathListSeparator) + os.Getenv("PATH"),
+		"HOME":   h.Dir,
+		"TMPDIR": h.Dir,
+		"LANG":   "C.UTF-8",
+	}
+	for key, value := range h.env {
+		env[key] = value
+	}
+	list := make([]string, 0, len(env))
+	for key, value := range env {
+		list = append(list, key+"="+value)
+	}
+	return list
+}
+
+func (h *Harness) calls() []Call {
+	data, err := os.ReadFile(h.logPath)
+	if err != nil {
+		h.t.Fatalf("호출 기록 읽기 실패: %v", err)
+	}
+	var calls []Call
+	for _, record := range strings.Split(string(data), callSeparator) {
+		if record == "" {
+			continue
+		}
+		fields := strings.Split(record, argSeparator)
+		calls = append(calls, Call{Name: fields[0], Args: fields[1:]})
+	}
+	return calls
+}
+
+func (h *Harness) writeFile(path, content string, mode os.FileMode) {
+	if err := os.WriteFile(path, []byte(content), mode); err != nil {
+		h.t.Fatalf("%s 작성 실패: %v", path, err)
+	}
+}
+
+// 작은따옴표로 감싼 셸 문자열
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// *, ?는 glob으로 두고 나머지는 리터럴로 인용한 bash 패턴
+func bashGlob(pattern string) string {
+	if pattern == "" {
+		return "''"
+	}
+	var out strings.Builder
+	literal := strings.Builder{}
+	flush := func() {
+		if literal.Len() > 0 {
+			out.WriteString(shellQuote(literal.String()))
+			literal.Reset()
+		}
+	}
+	for _, r := range pattern {
+		if r == '*' || r == '?' {
+			flush()
+			out.WriteRune(r)
+			continue
+		}
+		literal.WriteRune(r)
+	}
+	flush()
+	return out.String()
+}
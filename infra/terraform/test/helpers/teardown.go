@@ -0,0 +1,512 @@
+package helpers
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"sync"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/gruntwork-io/terratest/modules/terraform"
+)
+
+const (
+	// EnvTeardownJournal 정리 저널 파일 경로 (기본은 테스트 패키지 디렉터리의 .teardown-journal.json)
+	EnvTeardownJournal = "TEST_TEARDOWN_JOURNAL"
+	// TeardownFromJournalFlag 테스트 대신 저널에 남은 Terraform 설정을 destroy하는 플래그
+	//
+	//	go test . -args --teardown-from-journal
+	TeardownFromJournalFlag = "teardown-from-journal"
+
+	defaultTeardownJournal = ".teardown-journal.json"
+
+	// teardownWaitTimeout 중단 정리가 실행 중인 apply의 종료를 기다리는 최대 시간
+	//
+	// SIGINT는 terraform 자식 프로세스에도 전달되어 진행 중인 리소스 작업을 마치고 종료합니다.
+	teardownWaitTimeout  = 10 * time.Minute
+	teardownPollInterval = 500 * time.Millisecond
+)
+
+// errTeardownStarted 중단 정리가 시작된 뒤 새 Terraform 실행을 거부할 때의 오류
+var errTeardownStarted = errors.New("중단 신호를 받아 새 Terraform 실행을 시작하지 않습니다")
+
+// journalExcludedEnvVars 저널에 남기지 않는 환경 변수
+//
+// 자격 증명과, 실행마다 임시 디렉터리에 만드는 역할 프로필(TeardownJournal이 다시 설정)은 기록하지 않습니다.
+var journalExcludedEnvVars = map[string]bool{
+	"AWS_ACCESS_KEY_ID":     true,
+	"AWS_SECRET_ACCESS_KEY": true,
+	"AWS_SESSION_TOKEN":     true,
+	"AWS_CONFIG_FILE":       true,
+	"AWS_PROFILE":           true,
+}
+
+// TeardownEntry 저널에 기록되는 apply된 Terraform 설정
+type TeardownEntry struct {
+	// Name apply한 테스트 이름
+	Name         string                 `json:"name"`
+	TerraformDir string                 `json:"terraform_dir"`
+	Vars         map[string]interface{} `json:"vars,omitempty"`
+	VarFiles     []string               `json:"var_files,omitempty"`
+	// EnvVars 자격 증명을 뺀 환경 변수
+	EnvVars      map[string]string `json:"env_vars,omitempty"`
+	RegisteredAt time.Time         `json:"registered_at"`
+}
+
+// Options 저널 항목으로 destroy할 Terraform 옵션
+func (e TeardownEntry) Options() *terraform.Options {
+	envVars := make(map[string]string, len(e.EnvVars))
+	for key, value := range e.EnvVars {
+		envVars[key] = value
+	}
+	return &terraform.Options{
+		TerraformDir: e.TerraformDir,
+		Vars:         e.Vars,
+		VarFiles:     e.VarFiles,
+		EnvVars:      envVars,
+		NoColor:      true,
+		Lock:         true,
+		LockTimeout:  defaultStateLockTimeout,
+	}
+}
+
+func newTeardownEntry(name string, options *terraform.Options) TeardownEntry {
+	envVars := make(map[string]string, len(options.EnvVars))
+	for key, value := range options.EnvVars {
+		if !journalExcludedEnvVars[key] {
+			envVars[key] = value
+		}
+	}
+	terraformDir, err := filepath.Abs(options.TerraformDir)
+	if err != nil {
+		terraformDir = options.TerraformDir
+	}
+	return TeardownEntry{
+		Name:         name,
+		TerraformDir: terraformDir,
+		Vars:         options.Vars,
+		VarFiles:     options.VarFiles,
+		EnvVars:      envVars,
+		RegisteredAt: time.Now().UTC(),
+	}
+}
+
+// teardownItem 등록 순서대로 보관하는 정리 대상 (Terraform 설정 또는 메모리 안의 정리 함수)
+type teardownItem struct {
+	options *terraform.Options
+	entry   TeardownEntry
+	name    string
+	fn      func()
+}
+
+var (
+	defaultTeardownRegistryOnce sync.Once
+	defaultTeardownRegistry     *TeardownRegistry
+)
+
+// DefaultTeardownRegistry 프로세스 전체가 공유하는 정리 레지스트리 (TEST_TEARDOWN_JOURNAL, 기본 .teardown-journal.json)
+func DefaultTeardownRegistry() *TeardownRegistry {
+	defaultTeardownRegistryOnce.Do(func() {
+		path := os.Getenv(EnvTeardownJournal)
+		if path == "" {
+			path = defaultTeardownJournal
+		}
+		defaultTeardownRegistry = NewTeardownRegistry(path)
+	})
+	return defaultTeardownRegistry
+}
+
+// TeardownRegistry apply한 Terraform 설정을 디스크 저널에 기록하고 중단 시 역순으로 destroy
+//
+// 정상 종료에서는 각 테스트의 destroy가 항목을 지웁니다. SIGINT/SIGTERM이나 panic으로 테스트의 정리 코드가
+// 실행되지 않을 때 남은 항목을 역순으로 destroy하고, SIGKILL처럼 그마저 못 한 경우에는 저널이 남아
+// --teardown-from-journal로 이어서 정리할 수 있습니다.
+type TeardownRegistry struct {
+	mu    sync.Mutex
+	path  string
+	items []*teardownItem
+	// stale 이전 실행이 남긴 저널 항목 (덮어쓰지 않고 유지)
+	stale []TeardownEntry
+	// interrupted 중단 정리가 시작된 뒤에는 새 apply를 막음
+	interrupted bool
+	// running options별 실행 중인 Terraform 명령 수
+	running map[*terraform.Options]int
+}
+
+// NewTeardownRegistry 새로운 정리 레지스트리 생성 (저널에 남은 이전 항목은 그대로 유지)
+func NewTeardownRegistry(path string) *TeardownRegistry {
+	registry := &TeardownRegistry{path: path}
+	if entries, err := ReadTeardownJournal(path); err == nil {
+		registry.stale = entries
+	}
+	return registry
+}
+
+// ReadTeardownJournal 저널 파일 읽기 (파일이 없으면 빈 목록)
+func ReadTeardownJournal(path string) ([]TeardownEntry, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var entries []TeardownEntry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, fmt.Errorf("저널 %s 파싱 실패: %w", path, err)
+	}
+	return entries, nil
+}
+
+// Path 저널 파일 경로
+func (r *TeardownRegistry) Path() string {
+	return r.path
+}
+
+// Stale 이전 실행에서 정리되지 않은 저널 항목
+func (r *TeardownRegistry) Stale() []TeardownEntry {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]TeardownEntry(nil), r.stale...)
+}
+
+// Register apply 전에 Terraform 설정을 저널에 기록 (같은 options는 한 번만)
+//
+// *testing.T로 등록하면 그 테스트가 panic으로 끝날 때 등록된 모든 항목을 정리합니다.
+// 중단 정리가 시작된 뒤에는 오류를 돌려줍니다.
+func (r *TeardownRegistry) Register(t TestingT, options *terraform.Options) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.interrupted {
+		return errTeardownStarted
+	}
+	for _, item := range r.items {
+		if item.options == options {
+			return nil
+		}
+	}
+	r.items = append(r.items, &teardownItem{options: options, entry: newTeardownEntry(t.Name(), options)})
+	if err := r.writeLocked(); err != nil {
+		r.items = r.items[:len(r.items)-1]
+		return err
+	}
+	if tt, ok := t.(*testing.T); ok {
+		tt.Cleanup(func() {
+			if panicking() {
+				_ = r.TeardownAll(tt.Name() + " panic")
+			}
+		})
+	}
+	return nil
+}
+
+// Forget destroy에 성공한 Terraform 설정을 저널에서 제거
+func (r *TeardownRegistry) Forget(options *terraform.Options) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i, item := range r.items {
+		if item.options == options {
+			r.items = append(r.items[:i], r.items[i+1:]...)
+			return r.writeLocked()
+		}
+	}
+	return nil
+}
+
+// OnInterrupt 중단이나 panic 때 실행할 정리 함수 등록 (저널에는 남지 않음), 등록 해제 함수 반환
+func (r *TeardownRegistry) OnInterrupt(name string, fn func()) func() {
+	item := &teardownItem{name: name, fn: fn}
+	r.mu.Lock()
+	r.items = append(r.items, item)
+	r.mu.Unlock()
+	return func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		for i, registered := range r.items {
+			if registered == item {
+				r.items = append(r.items[:i], r.items[i+1:]...)
+				return
+			}
+		}
+	}
+}
+
+// Begin options로 Terraform 명령을 시작함을 기록하고 종료 함수 반환 (중단 정리가 시작됐으면 오류)
+//
+// TeardownAll은 이렇게 기록된 명령이 끝난 뒤에 그 options를 destroy합니다. ApplyTerraformE가 시도마다 호출합니다.
+func (r *TeardownRegistry) Begin(options *terraform.Options) (func(), error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.interrupted {
+		return nil, errTeardownStarted
+	}
+	if r.running == nil {
+		r.running = make(map[*terraform.Options]int)
+	}
+	r.running[options]++
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			r.mu.Lock()
+			defer r.mu.Unlock()
+			if r.running[options]--; r.running[options] == 0 {
+				delete(r.running, options)
+			}
+		})
+	}, nil
+}
+
+// waitIdle options로 실행 중인 명령이 끝날 때까지 대기 (timeout이 지나면 false)
+func (r *TeardownRegistry) waitIdle(options *terraform.Options, timeout time.Duration) bool {
+	deadline := time.Now().Add(timeout)
+	for {
+		r.mu.Lock()
+		running := r.running[options]
+		r.mu.Unlock()
+		if running == 0 {
+			return true
+		}
+		if time.Now().After(deadline) {
+			return false
+		}
+		time.Sleep(teardownPollInterval)
+	}
+}
+
+// Interrupted 중단 정리가 시작됐는지
+func (r *TeardownRegistry) Interrupted() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.interrupted
+}
+
+// TeardownAll 등록된 항목을 역순으로 정리 (이후 새 apply는 거부)
+//
+// 항목마다 실행 중인 apply가 끝나기를 기다린 뒤 destroy하며, destroy는 상태 잠금을 기다립니다.
+// destroy에 실패한 항목은 저널에 남습니다.
+func (r *TeardownRegistry) TeardownAll(reason string) error {
+	r.mu.Lock()
+	r.interrupted = true
+	items := append([]*teardownItem(nil), r.items...)
+	r.mu.Unlock()
+
+	if len(items) > 0 {
+		fmt.Fprintf(os.Stderr, "🧹 %s: 등록된 정리 대상 %d개를 역순으로 정리합니다\n", reason, len(items))
+	}
+	var errs []error
+	for i := len(items) - 1; i >= 0; i-- {
+		item := items[i]
+		if item.fn != nil {
+			item.fn()
+			continue
+		}
+		if !r.registered(item) {
+			// 앞선 정리 함수(공유 픽스처 등)가 이미 destroy함
+			continue
+		}
+		t := &fixtureT{name: item.entry.Name}
+		if !r.waitIdle(item.options, teardownWaitTimeout) {
+			t.Logf("⚠️ 실행 중인 Terraform 명령이 %s 안에 끝나지 않아 상태 잠금을 기다리며 destroy합니다", teardownWaitTimeout)
+		}
+		err := t.run(func() error {
+			if err := destroyTerraform(t, item.options); err != nil {
+				return err
+			}
+			return r.Forget(item.options)
+		})
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s (%s): %w", item.entry.Name, item.entry.TerraformDir, err))
+		}
+	}
+	if len(errs) > 0 {
+		fmt.Fprintf(os.Stderr, "❌ 정리 실패 %d개, 저널 %s에 남겨 둡니다\n", len(errs), r.path)
+	}
+	return errors.Join(errs...)
+}
+
+func (r *TeardownRegistry) registered(item *teardownItem) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, registered := range r.items {
+		if registered == item {
+			return true
+		}
+	}
+	return false
+}
+
+// TeardownJournal 저널에 남은 항목(이전 실행 포함)을 역순으로 destroy하고 성공한 항목을 저널에서 제거
+//
+// 주변 자격 증명으로 실행하며, TEST_ASSUME_ROLE_ARN이 있으면 그 역할 세션을 씁니다.
+func (r *TeardownRegistry) TeardownJournal() error {
+	r.mu.Lock()
+	entries := append([]TeardownEntry(nil), r.stale...)
+	r.mu.Unlock()
+	if len(entries) == 0 {
+		fmt.Printf("저널 %s에 정리할 항목이 없습니다\n", r.path)
+		return nil
+	}
+
+	var role *AWSTestClient
+	if config := AssumeRoleConfigFromEnv("teardown"); config != nil {
+		role = newRoleClient(defaultJournalRegion(), config)
+		defer role.RemoveRoleProfile()
+	}
+
+	var errs []error
+	for i := len(entries) - 1; i >= 0; i-- {
+		entry := entries[i]
+		t := &fixtureT{name: entry.Name}
+		err := t.run(func() error {
+			if _, err := os.Stat(entry.TerraformDir); err != nil {
+				return fmt.Errorf("작업 디렉터리를 찾을 수 없어 상태 파일 없이 정리할 수 없습니다: %w", err)
+			}
+			options := entry.Options()
+			if role != nil {
+				if err := role.RefreshTerraformCredentials(options); err != nil {
+					return err
+				}
+			}
+			t.Logf("🧹 %s 정리 중 (%s, %s 기록)", entry.Name, entry.TerraformDir, entry.RegisteredAt.Format(time.RFC3339))
+			return destroyTerraform(t, options)
+		})
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s (%s): %w", entry.Name, entry.TerraformDir, err))
+			continue
+		}
+		r.mu.Lock()
+		r.stale = removeEntry(r.stale, entry)
+		writeErr := r.writeLocked()
+		r.mu.Unlock()
+		if writeErr != nil {
+			errs = append(errs, writeErr)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func removeEntry(entries []TeardownEntry, target TeardownEntry) []TeardownEntry {
+	for i, entry := range entries {
+		if entry.TerraformDir == target.TerraformDir && entry.RegisteredAt.Equal(target.RegisteredAt) {
+			return append(entries[:i:i], entries[i+1:]...)
+		}
+	}
+	return entries
+}
+
+// defaultJournalRegion 저널 정리용 역할 세션의 리전 (AWS_DEFAULT_REGION, 없으면 ap-northeast-2)
+func defaultJournalRegion() string {
+	if region := os.Getenv("AWS_DEFAULT_REGION"); region != "" {
+		return region
+	}
+	return "ap-northeast-2"
+}
+
+// writeLocked 이전 항목과 현재 항목을 저널에 원자적으로 기록 (비면 파일 삭제)
+func (r *TeardownRegistry) writeLocked() error {
+	entries := append([]TeardownEntry(nil), r.stale...)
+	for _, item := range r.items {
+		if item.options != nil {
+			entries = append(entries, item.entry)
+		}
+	}
+	if len(entries) == 0 {
+		if err := os.Remove(r.path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("저널 삭제 실패: %w", err)
+		}
+		return nil
+	}
+	data, err := json.MarshalIndent(entries, "", "  ")
+	if err != nil {
+		return fmt.Errorf("저널 직렬화 실패: %w", err)
+	}
+	// 변수에 키 페어 경로 같은 값이 들어 있으므로 소유자만 읽도록 기록
+	tmp := r.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return fmt.Errorf("저널 기록 실패: %w", err)
+	}
+	if err := os.Rename(tmp, r.path); err != nil {
+		return fmt.Errorf("저널 기록 실패: %w", err)
+	}
+	return nil
+}
+
+// TrapSignals SIGINT/SIGTERM을 받으면 등록된 항목을 정리하고 종료
+//
+// 같은 신호를 다시 받으면 정리 없이 바로 종료합니다. 다른 신호는 무시하고 정리를 계속하는데,
+// GitHub Actions는 취소할 때 SIGINT 뒤 몇 초 만에 SIGTERM을 보내므로 이를 두 번째 신호로 보면
+// destroy 도중에 종료됩니다.
+func (r *TeardownRegistry) TrapSignals() func() {
+	signals := make(chan os.Signal, 2)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	done := make(chan struct{})
+	go func() {
+		select {
+		case sig := <-signals:
+			go func() {
+				for again := range signals {
+					if again == sig {
+						fmt.Fprintf(os.Stderr, "⚠️ %s를 다시 받아 정리하지 않고 종료합니다. 남은 리소스는 저널 %s로 정리하세요\n", sig, r.path)
+						os.Exit(1)
+					}
+					fmt.Fprintf(os.Stderr, "⚠️ 정리 중에 %s를 받았지만 계속합니다 (%s를 다시 보내면 정리 없이 종료)\n", again, sig)
+				}
+			}()
+			if err := r.TeardownAll(sig.String()); err != nil {
+				fmt.Fprintln(os.Stderr, err)
+			}
+			os.Exit(1)
+		case <-done:
+		}
+	}()
+	return func() {
+		signal.Stop(signals)
+		close(done)
+	}
+}
+
+// RunTests TestMain에서 m.Run을 감싸 중단 신호와 panic에도 apply한 리소스를 정리하고 종료 코드 반환
+//
+// --teardown-from-journal을 주면 테스트 대신 저널에 남은 항목을 정리합니다.
+//
+//	func TestMain(m *testing.M) {
+//		os.Exit(helpers.RunTests(m))
+//	}
+func RunTests(m *testing.M) int {
+	fromJournal := flag.Lookup(TeardownFromJournalFlag)
+	if fromJournal == nil {
+		flag.Bool(TeardownFromJournalFlag, false, "테스트 대신 정리 저널에 남은 Terraform 설정을 destroy")
+		fromJournal = flag.Lookup(TeardownFromJournalFlag)
+	}
+	flag.Parse()
+
+	registry := DefaultTeardownRegistry()
+	if fromJournal.Value.String() == "true" {
+		if err := registry.TeardownJournal(); err != nil {
+			fmt.Fprintf(os.Stderr, "❌ 저널 정리 실패:\n%v\n", err)
+			return 1
+		}
+		return 0
+	}
+	if stale := registry.Stale(); len(stale) > 0 {
+		names := make([]string, len(stale))
+		for i, entry := range stale {
+			names[i] = entry.Name
+		}
+		fmt.Fprintf(os.Stderr, "⚠️ 이전 실행에서 정리되지 않은 항목 %d개가 저널 %s에 있습니다 (%s). go test -args --%s로 정리하세요\n",
+			len(stale), registry.Path(), strings.Join(names, ", "), TeardownFromJournalFlag)
+	}
+
+	// 테스트 panic은 m.Run이 아니라 각 테스트 고루틴에서 나므로 여기서 recover할 수 없습니다.
+	// panic 정리는 Register와 SharedFixture.Acquire가 등록한 테스트별 Cleanup이 맡습니다.
+	stop := registry.TrapSignals()
+	defer stop()
+	return m.Run()
+}
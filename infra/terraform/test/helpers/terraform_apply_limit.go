@@ -0,0 +1,144 @@
+package helpers
+
+import (
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gruntwork-io/terratest/modules/terraform"
+)
+
+const (
+	// EnvMaxParallelApply 프로세스에서 동시에 실행할 terraform apply/destroy 수
+	EnvMaxParallelApply = "TEST_MAX_PARALLEL_APPLY"
+	// defaultMaxParallelApply provider가 생성 직후 Describe를 반복하므로 API 호출이 가장 몰리는 구간을 두 개로 제한
+	defaultMaxParallelApply = 2
+	// defaultStateLockTimeout 상태 잠금을 기다리는 시간 (중단 정리의 destroy가 종료 중인 apply를 기다림)
+	defaultStateLockTimeout = "5m"
+)
+
+var (
+	defaultApplyLimiterOnce sync.Once
+	defaultApplyLimiter     *ApplyLimiter
+)
+
+// DefaultApplyLimiter 프로세스 전체가 공유하는 apply 제한기 (TEST_MAX_PARALLEL_APPLY, 기본 2)
+func DefaultApplyLimiter() *ApplyLimiter {
+	defaultApplyLimiterOnce.Do(func() {
+		limit := defaultMaxParallelApply
+		if value, err := strconv.Atoi(os.Getenv(EnvMaxParallelApply)); err == nil && value > 0 {
+			limit = value
+		}
+		defaultApplyLimiter = NewApplyLimiter(limit)
+	})
+	return defaultApplyLimiter
+}
+
+// ApplyLimiter 동시에 실행하는 Terraform 명령 수 제한
+//
+// 병렬 테스트의 Go SDK 호출은 RateGovernor가 조절하지만 Terraform provider는 별도 프로세스라서
+// 명령 수 자체를 제한합니다. 같은 go test 프로세스 안에서만 조정되며, 워크플로의 작업 간에는 조정하지 않습니다.
+type ApplyLimiter struct {
+	slots chan struct{}
+}
+
+// NewApplyLimiter 새로운 apply 제한기 생성
+func NewApplyLimiter(limit int) *ApplyLimiter {
+	return &ApplyLimiter{slots: make(chan struct{}, limit)}
+}
+
+// Limit 동시 실행 가능한 명령 수
+func (l *ApplyLimiter) Limit() int {
+	return cap(l.slots)
+}
+
+// Acquire 실행 슬롯을 얻을 때까지 대기하고 반납 함수를 돌려줌
+func (l *ApplyLimiter) Acquire(t TestingT) func() {
+	start := time.Now()
+	l.slots <- struct{}{}
+	if waited := time.Since(start); waited >= time.Second {
+		t.Logf("⏳ Terraform 실행 슬롯 대기 %s (동시 실행 %d개 제한)", waited.Round(time.Second), l.Limit())
+	}
+	var once sync.Once
+	return func() {
+		once.Do(func() { <-l.slots })
+	}
+}
+
+// ApplyTerraform 실행 슬롯을 얻은 뒤 terraform init과 apply 실행 (일시적인 오류만 재시도)
+func ApplyTerraform(t TestingT, options *terraform.Options) {
+	if err := ApplyTerraformE(t, options); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// ApplyTerraformE 실행 슬롯을 얻은 뒤 terraform init과 apply 실행하고 분류된 오류(*TerraformError) 반환
+//
+// apply 전에 options를 DefaultTeardownRegistry 저널에 기록하므로 중단되더라도 정리할 수 있습니다.
+func ApplyTerraformE(t TestingT, options *terraform.Options) error {
+	registry := DefaultTeardownRegistry()
+	if err := registry.Register(t, options); err != nil {
+		return err
+	}
+	useStateLock(options)
+	return runLimited(t, "apply", func() (string, error) {
+		// 중단 정리가 시작된 뒤의 재시도는 정리한 리소스를 다시 만들 수 있음
+		done, err := registry.Begin(options)
+		if err != nil {
+			return "", err
+		}
+		defer done()
+		// InitAndApplyE는 init 실패 시 출력을 버리므로 나눠서 실행
+		terraformInitMu.Lock()
+		output, err := terraform.InitE(t, options)
+		terraformInitMu.Unlock()
+		if err != nil {
+			return output, err
+		}
+		return terraform.ApplyE(t, options)
+	})
+}
+
+// DestroyTerraform 실행 슬롯을 얻은 뒤 terraform destroy 실행 (일시적인 오류만 재시도)
+func DestroyTerraform(t TestingT, options *terraform.Options) {
+	if err := DestroyTerraformE(t, options); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// DestroyTerraformE 실행 슬롯을 얻은 뒤 terraform destroy 실행하고 분류된 오류(*TerraformError) 반환
+//
+// 성공하면 DefaultTeardownRegistry 저널에서 options를 지웁니다.
+func DestroyTerraformE(t TestingT, options *terraform.Options) error {
+	if err := destroyTerraform(t, options); err != nil {
+		return err
+	}
+	return DefaultTeardownRegistry().Forget(options)
+}
+
+// destroyTerraform 저널은 건드리지 않고 destroy (레지스트리가 자기 저널을 직접 정리할 때 사용)
+func destroyTerraform(t TestingT, options *terraform.Options) error {
+	useStateLock(options)
+	return runLimited(t, "destroy", func() (string, error) {
+		return terraform.DestroyE(t, options)
+	})
+}
+
+// useStateLock 상태 잠금을 켜고 잠금 대기 시간 설정 (terratest는 Lock이 false면 -lock=false로 실행)
+func useStateLock(options *terraform.Options) {
+	options.Lock = true
+	if options.LockTimeout == "" {
+		options.LockTimeout = defaultStateLockTimeout
+	}
+}
+
+// runLimited 시도마다 실행 슬롯을 얻어 재시도 대기 중에는 다른 테스트가 슬롯을 쓰게 함
+func runLimited(t TestingT, command string, run func() (string, error)) error {
+	_, err := RunTerraformWithRetry(t, command, DefaultTerraformRetryPolicy, func() (string, error) {
+		release := DefaultApplyLimiter().Acquire(t)
+		defer release()
+		return run()
+	})
+	return err
+}
@@ -0,0 +1,241 @@
+package helpers
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// TerraformErrorCategory Terraform 오류 분류
+type TerraformErrorCategory string
+
+const (
+	// TerraformErrorThrottling API 호출 속도 제한
+	TerraformErrorThrottling TerraformErrorCategory = "throttling"
+	// TerraformErrorEventualConsistency 방금 만든 리소스가 아직 전파되지 않음 (IAM 역할, ENI 해제 등)
+	TerraformErrorEventualConsistency TerraformErrorCategory = "eventual-consistency"
+	// TerraformErrorCapacity 가용 영역에 인스턴스 용량 부족
+	TerraformErrorCapacity TerraformErrorCategory = "capacity"
+	// TerraformErrorNetwork provider 다운로드나 API 연결의 일시적인 네트워크 오류
+	TerraformErrorNetwork TerraformErrorCategory = "network"
+	// TerraformErrorAuth 자격 증명 또는 권한 부족
+	TerraformErrorAuth TerraformErrorCategory = "auth"
+	// TerraformErrorValidation 설정, 변수, 요청 값 오류
+	TerraformErrorValidation TerraformErrorCategory = "validation"
+	// TerraformErrorQuota 계정 서비스 한도 초과
+	TerraformErrorQuota TerraformErrorCategory = "quota"
+	// TerraformErrorUnknown 분류할 수 없는 오류
+	TerraformErrorUnknown TerraformErrorCategory = "unknown"
+)
+
+// Retryable 다시 실행하면 성공할 수 있는 일시적인 오류인지
+func (c TerraformErrorCategory) Retryable() bool {
+	switch c {
+	case TerraformErrorThrottling, TerraformErrorEventualConsistency, TerraformErrorCapacity, TerraformErrorNetwork:
+		return true
+	default:
+		return false
+	}
+}
+
+// terraformErrorPatterns 분류 규칙 (위에서부터 먼저 일치하는 분류 사용)
+//
+// RequestLimitExceeded가 한도 초과보다, 역할 전파 지연(InvalidParameterValueException)이
+// 요청 값 오류보다, 없는 AMI(InvalidAMIID.NotFound)나 서브넷/VPC/보안 그룹이 전파 지연보다 먼저 검사되도록
+// 순서를 유지해야 합니다.
+var terraformErrorPatterns = []struct {
+	category TerraformErrorCategory
+	pattern  *regexp.Regexp
+}{
+	{TerraformErrorThrottling, regexp.MustCompile(`(?i)Throttl|RequestLimitExceeded|TooManyRequestsException|Rate exceeded|SlowDown|PriorRequestNotComplete`)},
+	{TerraformErrorCapacity, regexp.MustCompile(`InsufficientInstanceCapacity|InsufficientHostCapacity|InsufficientCapacity|InsufficientFreeAddressesInSubnet|capacity-not-available`)},
+	{TerraformErrorQuota, regexp.MustCompile(`LimitExceeded|ServiceQuotaExceeded|QuotaExceeded|TooManyBuckets|MaxSpotInstanceCountExceeded`)},
+	{TerraformErrorAuth, regexp.MustCompile(`(?i)AccessDenied|UnauthorizedOperation|UnrecognizedClientException|InvalidClientTokenId|ExpiredToken|AuthFailure|SignatureDoesNotMatch|not authorized to perform|No valid credential sources`)},
+	// 없는 AMI/키 페어와 변수로 넘긴 서브넷/VPC/보안 그룹 ID는 전파 지연이 아니라 설정 오류
+	{TerraformErrorValidation, regexp.MustCompile(`InvalidAMIID\.(NotFound|Malformed)|InvalidKeyPair\.NotFound|(InvalidSubnetID|InvalidVpcID|InvalidGroup|InvalidSecurityGroupID)\.NotFound`)},
+	{TerraformErrorEventualConsistency, regexp.MustCompile(`(?i)\.NotFound\b|DependencyViolation|cannot be assumed|Invalid IAM Instance Profile|NoSuchBucket|has not yet been propagated|OperationAbortedException|ConcurrentModificationException`)},
+	{TerraformErrorNetwork, regexp.MustCompile(`(?i)connection reset by peer|i/o timeout|TLS handshake timeout|no such host|Failed to install provider|could not query provider registry|RequestError: send request failed`)},
+	{TerraformErrorValidation, regexp.MustCompile(`(?i)Invalid value for|Unsupported argument|Missing required argument|Reference to undeclared|Value for undeclared variable|No value for required variable|Invalid reference|Invalid function argument|Incorrect attribute value type|Unsupported attribute|Inconsistent conditional result types|ValidationException|ValidationError|InvalidParameter|MalformedPolicyDocument|InvalidRequest|expected .* to be`)},
+}
+
+// ClassifyTerraformMessage 오류 메시지 분류
+func ClassifyTerraformMessage(message string) TerraformErrorCategory {
+	for _, rule := range terraformErrorPatterns {
+		if rule.pattern.MatchString(message) {
+			return rule.category
+		}
+	}
+	return TerraformErrorUnknown
+}
+
+// TerraformDiagnostic Terraform 출력의 Error 진단 하나
+type TerraformDiagnostic struct {
+	Category TerraformErrorCategory
+	Summary  string
+	// Address 실패한 리소스 주소 (예: module.kms.aws_kms_alias.k8s_key_alias, 리소스가 아니면 빈 문자열)
+	Address string
+	Detail  string
+}
+
+// String 분류, 리소스 주소, 요약을 한 줄로 표시
+func (d TerraformDiagnostic) String() string {
+	target := d.Address
+	if target == "" {
+		target = "(리소스 없음)"
+	}
+	return fmt.Sprintf("[%s] %s: %s", d.Category, target, d.Summary)
+}
+
+var (
+	ansiEscape = regexp.MustCompile(`\x1b\[[0-9;]*m`)
+	sourceLine = regexp.MustCompile(`^\d+:`)
+)
+
+// ParseTerraformErrors plan/apply/destroy 출력에서 Error 진단을 추출해 분류
+//
+// 색상 코드와 진단 상자(╷ │ ╵)가 있는 출력과 -no-color 출력 모두 처리합니다.
+func ParseTerraformErrors(output string) []TerraformDiagnostic {
+	var diagnostics []TerraformDiagnostic
+	var current *TerraformDiagnostic
+	var detail []string
+	flush := func() {
+		if current == nil {
+			return
+		}
+		current.Detail = strings.Join(detail, "\n")
+		current.Category = ClassifyTerraformMessage(current.Summary + "\n" + current.Detail)
+		diagnostics = append(diagnostics, *current)
+		current, detail = nil, nil
+	}
+
+	for _, raw := range strings.Split(ansiEscape.ReplaceAllString(output, ""), "\n") {
+		line := strings.TrimSpace(raw)
+		if strings.HasPrefix(line, "╷") || strings.HasPrefix(line, "╵") {
+			flush()
+			continue
+		}
+		line = strings.TrimSpace(strings.TrimPrefix(line, "│"))
+		switch {
+		case strings.HasPrefix(line, "Error: "):
+			flush()
+			current = &TerraformDiagnostic{Summary: strings.TrimPrefix(line, "Error: ")}
+		case current == nil:
+			// 진단 밖의 일반 출력
+		case strings.HasPrefix(line, "Warning: "):
+			flush()
+		case strings.HasPrefix(line, "with ") && current.Address == "":
+			current.Address = strings.TrimSuffix(strings.TrimPrefix(line, "with "), ",")
+		case strings.HasPrefix(line, "on ") && strings.Contains(line, " line "), sourceLine.MatchString(line), line == "":
+			// 위치와 소스 줄은 분류에 쓰지 않음
+		default:
+			detail = append(detail, line)
+		}
+	}
+	flush()
+	return diagnostics
+}
+
+// TerraformError 분류된 Terraform 명령 실패
+type TerraformError struct {
+	Command     string
+	Attempts    int
+	Diagnostics []TerraformDiagnostic
+	Err         error
+}
+
+// Error 실패한 리소스와 분류를 줄마다 표시
+func (e *TerraformError) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "terraform %s 실패 (%d회 시도)", e.Command, e.Attempts)
+	if len(e.Diagnostics) == 0 {
+		fmt.Fprintf(&b, ": %v", e.Err)
+	}
+	for _, diagnostic := range e.Diagnostics {
+		b.WriteString("\n  ")
+		b.WriteString(diagnostic.String())
+	}
+	return b.String()
+}
+
+// Unwrap 원래 명령 오류
+func (e *TerraformError) Unwrap() error {
+	return e.Err
+}
+
+// Retryable 진단이 있고 모두 일시적인 오류인지 (진단이 없으면 원인을 알 수 없으므로 재시도하지 않음)
+func (e *TerraformError) Retryable() bool {
+	if len(e.Diagnostics) == 0 {
+		return false
+	}
+	for _, diagnostic := range e.Diagnostics {
+		if !diagnostic.Category.Retryable() {
+			return false
+		}
+	}
+	return true
+}
+
+// Categories 진단에 나온 분류 (중복 제거, 출력 순서)
+func (e *TerraformError) Categories() []TerraformErrorCategory {
+	seen := make(map[TerraformErrorCategory]bool)
+	var categories []TerraformErrorCategory
+	for _, diagnostic := range e.Diagnostics {
+		if !seen[diagnostic.Category] {
+			seen[diagnostic.Category] = true
+			categories = append(categories, diagnostic.Category)
+		}
+	}
+	return categories
+}
+
+// TerraformRetryPolicy 일시적인 오류의 재시도 정책
+type TerraformRetryPolicy struct {
+	MaxRetries int
+	// Delay 첫 재시도 전 대기 시간 (재시도마다 두 배, MaxDelay까지)
+	Delay    time.Duration
+	MaxDelay time.Duration
+}
+
+// DefaultTerraformRetryPolicy ApplyTerraform/DestroyTerraform이 쓰는 재시도 정책
+var DefaultTerraformRetryPolicy = TerraformRetryPolicy{
+	MaxRetries: 4,
+	Delay:      10 * time.Second,
+	MaxDelay:   2 * time.Minute,
+}
+
+func (p TerraformRetryPolicy) delay(retry int) time.Duration {
+	delay := p.Delay
+	for i := 1; i < retry && delay < p.MaxDelay; i++ {
+		delay *= 2
+	}
+	if p.MaxDelay > 0 && delay > p.MaxDelay {
+		delay = p.MaxDelay
+	}
+	return delay
+}
+
+// RunTerraformWithRetry Terraform 명령을 실행하고 일시적인 오류만 재시도
+//
+// run은 명령 출력과 오류를 돌려줍니다 (terraform.InitAndApplyE 등). 실패하면 마지막 시도의 진단을 담은
+// *TerraformError를 돌려줍니다.
+func RunTerraformWithRetry(t TestingT, command string, policy TerraformRetryPolicy, run func() (string, error)) (string, error) {
+	for attempt := 1; ; attempt++ {
+		output, err := run()
+		if err == nil {
+			return output, nil
+		}
+		terraformErr := &TerraformError{
+			Command:     command,
+			Attempts:    attempt,
+			Diagnostics: ParseTerraformErrors(output),
+			Err:         err,
+		}
+		if !terraformErr.Retryable() || attempt > policy.MaxRetries {
+			return output, terraformErr
+		}
+		delay := policy.delay(attempt)
+		t.Logf("🔁 terraform %s 일시적인 오류 %v, %s 후 재시도 (%d/%d)", command, terraformErr.Categories(), delay, attempt, policy.MaxRetries)
+		time.Sleep(delay)
+	}
+}
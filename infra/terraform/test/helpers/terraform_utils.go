@@ -1,25 +1,81 @@
 package helpers
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/gruntwork-io/terratest/modules/terraform"
+	"github.com/k8s-ec2-observability/test/helpers/tfcontract"
 )
 
+// StandaloneEC2SkipReason ec2-master/ec2-worker 모듈을 단독 루트로 apply하는 테스트를 건너뛰는 이유
+//
+// 두 모듈은 ${path.root}/../../scripts의 부트스트랩 스크립트를 읽으므로 infra/terraform 루트에서만
+// plan할 수 있고, private_key_path·scripts_bucket·보안 그룹도 루트 스택이 만들어 넘깁니다.
+const StandaloneEC2SkipReason = "ec2-master/ec2-worker 모듈은 단독 루트로 apply할 수 없습니다 " +
+	"(${path.root}/../../scripts와 루트 스택이 넘기는 필수 변수 필요). 노드 검증은 integration/rootstack에서 합니다"
+
 // TerraformConfig Terraform 설정을 위한 구조체
 type TerraformConfig struct {
 	ModulePath string
 	Vars       map[string]interface{}
 	EnvVars    map[string]string
+	// AWSClient 역할을 위임받은 클라이언트면 provider도 같은 역할을 위임받음 (nil이면 주변 자격 증명)
+	AWSClient *AWSTestClient
+	// Isolated 모듈을 테스트별 임시 디렉터리에 복사해 실행 (같은 모듈을 병렬로 테스트할 때 사용)
+	//
+	// 복사본은 SetupTerraform을 부른 t가 끝나면 지워지므로 상위 테스트에서 destroy하는 경우에는 쓰지 마세요.
+	Isolated bool
 }
 
 // SetupTerraform Terraform 테스트 설정
+//
+// AWSClient가 역할 세션을 쓰면 provider도 같은 역할을 위임받도록 역할 프로필을 EnvVars에 넣습니다.
+//
+// Isolated면 CopyTerraformModule로 복사한 디렉터리에서 공유 플러그인 캐시를 써서 실행합니다.
+//
+// Vars가 모듈 변수와 맞지 않으면(필수 변수 누락, 없는 변수, 타입 불일치) apply 전에 테스트를 중단합니다.
 func SetupTerraform(t *testing.T, config TerraformConfig) *terraform.Options {
-	return terraform.WithDefaultRetryableErrors(t, &terraform.Options{
-		TerraformDir: config.ModulePath,
+	checkModuleVars(t, config)
+	envVars := make(map[string]string, len(config.EnvVars))
+	for key, value := range config.EnvVars {
+		envVars[key] = value
+	}
+	terraformDir := config.ModulePath
+	if config.Isolated {
+		terraformDir = CopyTerraformModule(t, config.ModulePath)
+		usePluginCache(t, envVars)
+	}
+	// terratest의 RetryableTerraformErrors는 쓰지 않음 (재시도는 RunTerraformWithRetry의 오류 분류만 따름)
+	options := &terraform.Options{
+		TerraformDir: terraformDir,
 		Vars:         config.Vars,
-		EnvVars:      config.EnvVars,
-	})
+		EnvVars:      envVars,
+	}
+	if config.AWSClient != nil {
+		if err := config.AWSClient.RefreshTerraformCredentials(options); err != nil {
+			t.Fatalf("Terraform 자격 증명 설정 실패: %v", err)
+		}
+	}
+	return options
+}
+
+func checkModuleVars(t *testing.T, config TerraformConfig) {
+	t.Helper()
+	module, err := tfcontract.LoadModule(config.ModulePath)
+	if err != nil {
+		t.Logf("모듈 계약 검사 생략 (%s): %v", config.ModulePath, err)
+		return
+	}
+	errors := tfcontract.Errors(tfcontract.CheckVars(module, config.Vars, config.EnvVars))
+	if len(errors) == 0 {
+		return
+	}
+	messages := make([]string, len(errors))
+	for i, finding := range errors {
+		messages[i] = finding.String()
+	}
+	t.Fatalf("%s 모듈 변수 배선 오류:\n%s", config.ModulePath, strings.Join(messages, "\n"))
 }
 
 // DefaultKMSVars 기본 KMS 변수 설정
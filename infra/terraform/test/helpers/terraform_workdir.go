@@ -0,0 +1,225 @@
+package helpers
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/k8s-ec2-observability/test/helpers/tfcontract"
+)
+
+const (
+	// EnvPluginCacheDir Terraform provider 플러그인 캐시 디렉터리 (Terraform이 직접 읽는 환경 변수)
+	EnvPluginCacheDir = "TF_PLUGIN_CACHE_DIR"
+	// envPluginCacheMayBreakLockFile 잠금 파일이 없는 모듈도 캐시를 쓰도록 허용 (Terraform 1.4+)
+	//
+	// 저장소에 .terraform.lock.hcl을 두지 않으므로 이 값이 없으면 복사본마다 provider를 다시 받습니다.
+	envPluginCacheMayBreakLockFile = "TF_PLUGIN_CACHE_MAY_BREAK_DEPENDENCY_LOCK_FILE"
+)
+
+// terraformInitMu 플러그인 캐시는 동시 init에 안전하지 않으므로 프로세스 안에서 init을 하나씩 실행
+var terraformInitMu sync.Mutex
+
+// TerraformPluginCacheDir 테스트가 공유하는 provider 플러그인 캐시 디렉터리 (없으면 생성)
+//
+// TF_PLUGIN_CACHE_DIR이 있으면 그 경로를, 없으면 사용자 캐시 디렉터리 아래 경로를 씁니다.
+func TerraformPluginCacheDir() (string, error) {
+	dir := os.Getenv(EnvPluginCacheDir)
+	if dir == "" {
+		base, err := os.UserCacheDir()
+		if err != nil {
+			base = os.TempDir()
+		}
+		dir = filepath.Join(base, "k8s-ec2-observability", "terraform-plugins")
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", fmt.Errorf("플러그인 캐시 디렉터리 생성 실패: %w", err)
+	}
+	return dir, nil
+}
+
+// usePluginCache Terraform 환경 변수에 공유 플러그인 캐시 설정 (이미 지정한 값은 유지)
+func usePluginCache(t TestingT, envVars map[string]string) {
+	if _, ok := envVars[EnvPluginCacheDir]; ok {
+		return
+	}
+	dir, err := TerraformPluginCacheDir()
+	if err != nil {
+		t.Logf("플러그인 캐시 없이 실행: %v", err)
+		return
+	}
+	envVars[EnvPluginCacheDir] = dir
+	envVars[envPluginCacheMayBreakLockFile] = "true"
+}
+
+// CopyTerraformModule 모듈과 로컬 source로 참조하는 모듈을 테스트별 임시 디렉터리에 복사하고 복사된 모듈 경로 반환
+//
+// 복사본은 t가 끝날 때 지워지므로 destroy도 같은 t(또는 그 하위 테스트)에서 등록해야 합니다.
+func CopyTerraformModule(t *testing.T, moduleDir string) string {
+	dir, err := CopyTerraformModuleE(moduleDir, t.TempDir())
+	if err != nil {
+		t.Fatalf("Terraform 모듈 복사 실패: %v", err)
+	}
+	return dir
+}
+
+// CopyTerraformModuleE 모듈과 로컬 source로 참조하는 모듈을 destRoot 아래에 복사하고 복사된 모듈 경로 반환
+//
+// 모듈 사이의 상대 경로(../../modules/kms 등)가 유지되도록 공통 상위 디렉터리 기준으로 배치합니다.
+// .terraform, 상태 파일, 숨김 파일과 참조되지 않은 하위 모듈은 복사하지 않습니다.
+// ${path.root}/${path.module} 경로로 모듈 트리 밖의 파일(../../scripts 등)을 읽는 모듈은 복사본에서 찾을 수 없으므로 오류를 반환합니다.
+func CopyTerraformModuleE(moduleDir, destRoot string) (string, error) {
+	tree, err := tfcontract.LoadTree(moduleDir)
+	if err != nil {
+		return "", err
+	}
+	dirs := make([]string, 0, len(tree.Modules))
+	for _, dir := range tree.Dirs() {
+		abs, err := filepath.Abs(dir)
+		if err != nil {
+			return "", err
+		}
+		dirs = append(dirs, abs)
+	}
+	root, err := filepath.Abs(moduleDir)
+	if err != nil {
+		return "", err
+	}
+	if err := checkExternalFiles(root, dirs); err != nil {
+		return "", err
+	}
+	base := commonDir(dirs)
+	for _, dir := range dirs {
+		rel, err := filepath.Rel(base, dir)
+		if err != nil {
+			return "", err
+		}
+		if err := copyModuleDir(dir, filepath.Join(destRoot, rel)); err != nil {
+			return "", fmt.Errorf("%s 복사 실패: %w", dir, err)
+		}
+	}
+
+	rel, err := filepath.Rel(base, root)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(destRoot, rel), nil
+}
+
+// pathReference ${path.root}/${path.module}로 시작하는 파일 경로 (다음 보간이나 따옴표 전까지)
+var pathReference = regexp.MustCompile(`\$\{path\.(root|module)\}(/[^"$}\s]*)?`)
+
+// checkExternalFiles 복사할 모듈들이 복사 대상 밖의 파일을 참조하는지 검사 (path.root는 root 기준)
+func checkExternalFiles(root string, dirs []string) error {
+	var external []string
+	seen := make(map[string]bool)
+	for _, dir := range dirs {
+		files, err := filepath.Glob(filepath.Join(dir, "*.tf"))
+		if err != nil {
+			return err
+		}
+		for _, file := range files {
+			data, err := os.ReadFile(file)
+			if err != nil {
+				return err
+			}
+			for _, match := range pathReference.FindAllStringSubmatch(string(data), -1) {
+				base := dir
+				if match[1] == "root" {
+					base = root
+				}
+				target := filepath.Join(base, match[2])
+				reference := fmt.Sprintf("%s: %s → %s", file, match[0], target)
+				if !withinAnyDir(dirs, target) && !seen[reference] {
+					seen[reference] = true
+					external = append(external, reference)
+				}
+			}
+		}
+	}
+	if len(external) > 0 {
+		return fmt.Errorf("모듈 트리 밖의 파일을 참조하므로 복사해서 실행할 수 없습니다:\n%s", strings.Join(external, "\n"))
+	}
+	return nil
+}
+
+func withinAnyDir(dirs []string, path string) bool {
+	for _, dir := range dirs {
+		if withinDir(dir, path) {
+			return true
+		}
+	}
+	return false
+}
+
+// commonDir 디렉터리들의 가장 가까운 공통 상위 디렉터리
+func commonDir(dirs []string) string {
+	base := dirs[0]
+	for _, dir := range dirs[1:] {
+		for !withinDir(base, dir) {
+			base = filepath.Dir(base)
+		}
+	}
+	return base
+}
+
+func withinDir(base, dir string) bool {
+	rel, err := filepath.Rel(base, dir)
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
+// copyModuleDir 모듈 디렉터리 복사 (functions 같은 일반 하위 디렉터리는 포함, .tf가 있는 하위 모듈은 제외)
+func copyModuleDir(src, dst string) error {
+	return filepath.WalkDir(src, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dst, rel)
+		if entry.IsDir() {
+			if path != src && (strings.HasPrefix(entry.Name(), ".") || entry.Name() == "terraform.tfstate.d" || hasTerraformFiles(path)) {
+				return filepath.SkipDir
+			}
+			return os.MkdirAll(target, 0o755)
+		}
+		if skipModuleFile(entry.Name()) {
+			return nil
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		return os.WriteFile(target, data, info.Mode().Perm())
+	})
+}
+
+func hasTerraformFiles(dir string) bool {
+	matches, _ := filepath.Glob(filepath.Join(dir, "*.tf"))
+	return len(matches) > 0
+}
+
+// skipModuleFile 실행 결과물(상태, 크래시 로그)과 잠금 파일을 뺀 숨김 파일
+func skipModuleFile(name string) bool {
+	switch {
+	case name == ".terraform.lock.hcl":
+		return false
+	case strings.HasPrefix(name, "."):
+		return true
+	case strings.HasSuffix(name, ".tfstate"), strings.HasSuffix(name, ".tfstate.backup"), name == "crash.log":
+		return true
+	default:
+		return false
+	}
+}
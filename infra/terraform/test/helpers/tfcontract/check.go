@@ -0,0 +1,417 @@
+package tfcontract
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/agext/levenshtein"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/convert"
+	ctyjson "github.com/zclconf/go-cty/cty/json"
+)
+
+// Severity 계약 위반 심각도
+type Severity string
+
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+)
+
+// 검사 항목
+const (
+	CheckUnknownArgument  = "unknown-argument"
+	CheckMissingArgument  = "missing-argument"
+	CheckTypeMismatch     = "type-mismatch"
+	CheckUnsafeConversion = "unsafe-conversion"
+	CheckUnknownVariable  = "unknown-variable"
+	CheckUnknownModule    = "unknown-module"
+	CheckUnknownOutput    = "unknown-output"
+	CheckUnresolvedSource = "unresolved-source"
+	CheckCycle            = "cycle"
+)
+
+// 오타 후보로 제안할 최대 편집 거리 (짧은 이름은 길이의 절반까지)
+const maxSuggestionDistance = 3
+
+// Finding 모듈 간 계약 위반 항목
+type Finding struct {
+	Path     string
+	Line     int
+	Module   string // 호출한 쪽 모듈 디렉터리
+	Call     string // module 블록 이름 (참조 검사는 빈 문자열)
+	Name     string // 변수/출력/참조 이름
+	Check    string
+	Severity Severity
+	Message  string
+}
+
+func (f Finding) String() string {
+	return fmt.Sprintf("%s:%d: [%s] %s: %s", f.Path, f.Line, f.Severity, f.Check, f.Message)
+}
+
+// Errors error 심각도 항목만
+func Errors(findings []Finding) []Finding {
+	var errors []Finding
+	for _, finding := range findings {
+		if finding.Severity == SeverityError {
+			errors = append(errors, finding)
+		}
+	}
+	return errors
+}
+
+// Check 트리의 모든 module 호출과 var./module. 참조를 피호출 모듈의 변수/출력과 대조
+func Check(tree *Tree) []Finding {
+	var findings []Finding
+	for _, dir := range tree.Dirs() {
+		module := tree.Modules[dir]
+		findings = append(findings, checkReferences(tree, module)...)
+		for _, name := range module.CallNames() {
+			findings = append(findings, checkCall(tree, module, module.Calls[name])...)
+		}
+		if _, err := BuildGraph(module).Order(); err != nil {
+			findings = append(findings, Finding{
+				Path: dir, Module: dir, Check: CheckCycle, Severity: SeverityError, Message: err.Error(),
+			})
+		}
+	}
+	sortFindings(findings)
+	return findings
+}
+
+// CheckVars terratest Vars(와 TF_VAR_ 환경 변수)를 모듈 변수와 대조
+//
+// 통합 테스트가 모듈 출력 값을 다른 모듈 변수로 직접 넘기는 경우 apply 전에 배선 오류를 찾습니다.
+func CheckVars(module *Module, vars map[string]interface{}, envVars map[string]string) []Finding {
+	var findings []Finding
+	report := func(name, check string, severity Severity, message string) {
+		findings = append(findings, Finding{
+			Path: module.Dir, Module: module.Dir, Name: name, Check: check, Severity: severity, Message: message,
+		})
+	}
+
+	provided := make(map[string]bool)
+	for key := range envVars {
+		if name := strings.TrimPrefix(key, "TF_VAR_"); name != key {
+			provided[name] = true
+		}
+	}
+
+	names := make([]string, 0, len(vars))
+	for name := range vars {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		variable, ok := module.Variables[name]
+		if !ok {
+			report(name, CheckUnknownArgument, SeverityError, unknownMessage("변수", name, module.Dir, variableNames(module)))
+			continue
+		}
+		value, err := goValue(vars[name])
+		if err != nil {
+			report(name, CheckTypeMismatch, SeverityError, fmt.Sprintf("%s 값을 변환할 수 없습니다: %v", name, err))
+			continue
+		}
+		if value.IsNull() {
+			continue
+		}
+		provided[name] = true
+		if _, err := convert.Convert(value, variable.Type); err != nil {
+			report(name, CheckTypeMismatch, SeverityError,
+				fmt.Sprintf("%s: %s 값을 %s 타입으로 변환할 수 없습니다: %v", name, value.Type().FriendlyName(), variable.Type.FriendlyName(), err))
+		}
+	}
+
+	for _, name := range module.RequiredVariables() {
+		if !provided[name] {
+			report(name, CheckMissingArgument, SeverityError, fmt.Sprintf("필수 변수 %s가 없습니다", name))
+		}
+	}
+	return findings
+}
+
+func checkCall(tree *Tree, caller *Module, call *ModuleCall) []Finding {
+	var findings []Finding
+	report := func(rng hcl.Range, name, check string, severity Severity, message string) {
+		findings = append(findings, Finding{
+			Path: rng.Filename, Line: rng.Start.Line, Module: caller.Dir, Call: call.Name,
+			Name: name, Check: check, Severity: severity, Message: message,
+		})
+	}
+
+	callee := tree.Callee(caller, call)
+	if callee == nil {
+		if call.LocalSource() {
+			report(call.Range, call.Source, CheckUnresolvedSource, SeverityError,
+				fmt.Sprintf("module %q의 source %s에 .tf 파일이 없습니다", call.Name, call.Source))
+		}
+		return findings
+	}
+
+	for _, name := range sortedArguments(call) {
+		argument := call.Arguments[name]
+		variable, ok := callee.Variables[name]
+		if !ok {
+			report(argument.Range, name, CheckUnknownArgument, SeverityError,
+				fmt.Sprintf("module %q: %s", call.Name, unknownMessage("변수", name, callee.Dir, variableNames(callee))))
+			continue
+		}
+
+		from, value, literal := tree.exprType(caller, argument.Expr)
+		if literal {
+			if value.IsNull() && variable.Required {
+				report(argument.Range, name, CheckMissingArgument, SeverityError,
+					fmt.Sprintf("module %q: 필수 변수 %s에 null을 넘겼습니다", call.Name, name))
+			} else if _, err := convert.Convert(value, variable.Type); err != nil {
+				report(argument.Range, name, CheckTypeMismatch, SeverityError,
+					fmt.Sprintf("module %q: %s 값을 %s 타입으로 변환할 수 없습니다: %v", call.Name, name, variable.Type.FriendlyName(), err))
+			}
+			continue
+		}
+		switch conversion(from, variable.Type) {
+		case conversionNone:
+			report(argument.Range, name, CheckTypeMismatch, SeverityError,
+				fmt.Sprintf("module %q: %s에 %s 타입을 넘겼지만 %s 타입이 필요합니다", call.Name, name, from.FriendlyName(), variable.Type.FriendlyName()))
+		case conversionUnsafe:
+			report(argument.Range, name, CheckUnsafeConversion, SeverityWarning,
+				fmt.Sprintf("module %q: %s의 %s → %s 변환은 값에 따라 실패할 수 있습니다", call.Name, name, from.FriendlyName(), variable.Type.FriendlyName()))
+		}
+	}
+
+	for _, name := range callee.RequiredVariables() {
+		if _, ok := call.Arguments[name]; !ok {
+			report(call.Range, name, CheckMissingArgument, SeverityError,
+				fmt.Sprintf("module %q: 필수 변수 %s가 없습니다 (%s)", call.Name, name, callee.Dir))
+		}
+	}
+	return findings
+}
+
+func checkReferences(tree *Tree, module *Module) []Finding {
+	var findings []Finding
+	report := func(traversal hcl.Traversal, check, message string) {
+		rng := traversal.SourceRange()
+		findings = append(findings, Finding{
+			Path: rng.Filename, Line: rng.Start.Line, Module: module.Dir,
+			Name: traversalString(traversal), Check: check, Severity: SeverityError, Message: message,
+		})
+	}
+
+	for _, traversal := range module.References {
+		switch traversal.RootName() {
+		case "var":
+			name := ""
+			if len(traversal) > 1 {
+				if attr, ok := traversal[1].(hcl.TraverseAttr); ok {
+					name = attr.Name
+				}
+			}
+			if _, ok := module.Variables[name]; !ok {
+				report(traversal, CheckUnknownVariable, unknownMessage("변수", name, module.Dir, variableNames(module)))
+			}
+		case "module":
+			name, output := moduleRef(traversal)
+			call, ok := module.Calls[name]
+			if !ok {
+				report(traversal, CheckUnknownModule, unknownMessage("module", name, module.Dir, module.CallNames()))
+				continue
+			}
+			callee := tree.Callee(module, call)
+			if callee == nil || output == "" {
+				continue
+			}
+			if _, ok := callee.Outputs[output]; !ok {
+				report(traversal, CheckUnknownOutput,
+					fmt.Sprintf("module %q: %s", name, unknownMessage("출력", output, callee.Dir, outputNames(callee))))
+			}
+		}
+	}
+	return findings
+}
+
+// exprType 인자 식의 정적 타입 (리터럴이면 값도 반환, 알 수 없으면 cty.DynamicPseudoType)
+//
+// 리터럴로만 이루어진 local 값은 리터럴과 같이 취급합니다.
+func (t *Tree) exprType(caller *Module, expr hcl.Expression) (cty.Type, cty.Value, bool) {
+	if scope, ok := expr.(*hclsyntax.ScopeTraversalExpr); ok && scope.Traversal.RootName() == "local" && len(scope.Traversal) == 2 {
+		if attr, ok := scope.Traversal[1].(hcl.TraverseAttr); ok {
+			if local, ok := caller.Locals[attr.Name]; ok {
+				expr = local
+			}
+		}
+	}
+	if len(expr.Variables()) == 0 {
+		if value, diags := expr.Value(nil); !diags.HasErrors() {
+			return value.Type(), value, true
+		}
+		return cty.DynamicPseudoType, cty.NilVal, false
+	}
+	return t.referenceType(caller, expr), cty.NilVal, false
+}
+
+// 단일 참조 식의 타입 (var.X, module.M.O, module.M)
+func (t *Tree) referenceType(caller *Module, expr hcl.Expression) cty.Type {
+
+	scope, ok := expr.(*hclsyntax.ScopeTraversalExpr)
+	if !ok {
+		return cty.DynamicPseudoType
+	}
+	traversal := scope.Traversal
+	switch traversal.RootName() {
+	case "var":
+		if len(traversal) == 2 {
+			if attr, ok := traversal[1].(hcl.TraverseAttr); ok {
+				if variable, ok := caller.Variables[attr.Name]; ok {
+					return variable.Type
+				}
+			}
+		}
+	case "module":
+		name, output := moduleRef(traversal)
+		call, ok := caller.Calls[name]
+		if !ok || call.Repeated {
+			break
+		}
+		callee := t.Callee(caller, call)
+		if callee == nil {
+			break
+		}
+		if len(traversal) == 2 {
+			// 모듈 전체를 넘기면 출력으로 이루어진 object
+			attrs := make(map[string]cty.Type, len(callee.Outputs))
+			for name := range callee.Outputs {
+				attrs[name] = cty.DynamicPseudoType
+			}
+			return cty.Object(attrs)
+		}
+		if len(traversal) == 3 && output != "" {
+			return outputType(callee, output)
+		}
+	}
+	return cty.DynamicPseudoType
+}
+
+// 출력 값이 그 모듈의 변수를 그대로 내보내는 경우만 타입을 알 수 있음
+func outputType(module *Module, name string) cty.Type {
+	output, ok := module.Outputs[name]
+	if !ok || output.Value == nil {
+		return cty.DynamicPseudoType
+	}
+	scope, ok := output.Value.(*hclsyntax.ScopeTraversalExpr)
+	if !ok || scope.Traversal.RootName() != "var" || len(scope.Traversal) != 2 {
+		return cty.DynamicPseudoType
+	}
+	if attr, ok := scope.Traversal[1].(hcl.TraverseAttr); ok {
+		if variable, ok := module.Variables[attr.Name]; ok {
+			return variable.Type
+		}
+	}
+	return cty.DynamicPseudoType
+}
+
+type conversionKind int
+
+const (
+	conversionSafe conversionKind = iota
+	conversionUnsafe
+	conversionNone
+)
+
+func conversion(from, to cty.Type) conversionKind {
+	switch {
+	case from.Equals(to), from == cty.DynamicPseudoType, to == cty.DynamicPseudoType:
+		return conversionSafe
+	case convert.GetConversion(from, to) != nil:
+		return conversionSafe
+	case convert.GetConversionUnsafe(from, to) != nil:
+		return conversionUnsafe
+	}
+	return conversionNone
+}
+
+// Go 값을 JSON을 거쳐 cty 값으로 변환 (terratest가 넘기는 값과 같은 형태)
+func goValue(v interface{}) (cty.Value, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return cty.NilVal, err
+	}
+	ty, err := ctyjson.ImpliedType(data)
+	if err != nil {
+		return cty.NilVal, err
+	}
+	return ctyjson.Unmarshal(data, ty)
+}
+
+func unknownMessage(kind, name, dir string, candidates []string) string {
+	message := fmt.Sprintf("%s에 %s %s가 없습니다", dir, kind, name)
+	limit := len(name) / 2
+	if limit > maxSuggestionDistance {
+		limit = maxSuggestionDistance
+	}
+	best, bestDistance := "", limit+1
+	for _, candidate := range candidates {
+		if distance := levenshtein.Distance(name, candidate, nil); distance < bestDistance {
+			best, bestDistance = candidate, distance
+		}
+	}
+	if best != "" {
+		message += fmt.Sprintf(" (%s의 오타?)", best)
+	}
+	return message
+}
+
+func variableNames(module *Module) []string {
+	names := make([]string, 0, len(module.Variables))
+	for name := range module.Variables {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func outputNames(module *Module) []string {
+	names := make([]string, 0, len(module.Outputs))
+	for name := range module.Outputs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func sortedArguments(call *ModuleCall) []string {
+	names := make([]string, 0, len(call.Arguments))
+	for name := range call.Arguments {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func traversalString(traversal hcl.Traversal) string {
+	var builder strings.Builder
+	builder.WriteString(traversal.RootName())
+	for _, step := range traversal[1:] {
+		switch step := step.(type) {
+		case hcl.TraverseAttr:
+			builder.WriteString("." + step.Name)
+		case hcl.TraverseIndex:
+			builder.WriteString("[...]")
+		}
+	}
+	return builder.String()
+}
+
+func sortFindings(findings []Finding) {
+	sort.SliceStable(findings, func(i, j int) bool {
+		if findings[i].Path != findings[j].Path {
+			return findings[i].Path < findings[j].Path
+		}
+		return findings[i].Line < findings[j].Line
+	})
+}
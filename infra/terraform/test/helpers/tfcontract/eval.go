@@ -0,0 +1,83 @@
+package tfcontract
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/convert"
+	"github.com/zclconf/go-cty/cty/function"
+	"github.com/zclconf/go-cty/cty/function/stdlib"
+)
+
+// evalFunctions 정적 평가에 쓰는 Terraform 내장 함수 일부
+var evalFunctions = map[string]function.Function{
+	"concat":     stdlib.ConcatFunc,
+	"format":     stdlib.FormatFunc,
+	"join":       stdlib.JoinFunc,
+	"jsondecode": stdlib.JSONDecodeFunc,
+	"jsonencode": stdlib.JSONEncodeFunc,
+	"length":     stdlib.LengthFunc,
+	"lower":      stdlib.LowerFunc,
+	"merge":      stdlib.MergeFunc,
+	"upper":      stdlib.UpperFunc,
+}
+
+// EvalContext var.와 local.을 채운 평가 컨텍스트
+//
+// vars에 없는 변수는 default를 쓰고, default도 없으면 오류입니다. 리소스/모듈 참조와 위 함수 외의 호출은
+// 평가할 수 없으므로 호출하는 쪽에서 참조를 따로 해석해야 합니다.
+func (m *Module) EvalContext(vars map[string]interface{}) (*hcl.EvalContext, error) {
+	values := make(map[string]cty.Value, len(m.Variables))
+	for _, name := range variableNames(m) {
+		variable := m.Variables[name]
+		raw, ok := vars[name]
+		if !ok {
+			if variable.Required {
+				return nil, fmt.Errorf("%s: 필수 변수 %s의 값이 없습니다", m.Dir, name)
+			}
+			values[name] = variable.Default
+			continue
+		}
+		value, err := goValue(raw)
+		if err == nil {
+			value, err = convert.Convert(value, variable.Type)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%s: 변수 %s 변환 실패: %w", m.Dir, name, err)
+		}
+		values[name] = value
+	}
+
+	ctx := &hcl.EvalContext{
+		Variables: map[string]cty.Value{"var": cty.ObjectVal(values)},
+		Functions: evalFunctions,
+	}
+	m.evalLocals(ctx)
+	return ctx, nil
+}
+
+// 다른 local을 참조하는 local이 있으므로 더 풀리지 않을 때까지 반복
+//
+// 리소스를 참조하는 local은 정적으로 평가할 수 없으므로 local.에서 빠집니다.
+func (m *Module) evalLocals(ctx *hcl.EvalContext) {
+	locals := make(map[string]cty.Value, len(m.Locals))
+	pending := make(map[string]hcl.Expression, len(m.Locals))
+	for name, expr := range m.Locals {
+		pending[name] = expr
+	}
+	for progressed := true; progressed && len(pending) > 0; {
+		ctx.Variables["local"] = cty.ObjectVal(locals)
+		progressed = false
+		for name, expr := range pending {
+			value, diags := expr.Value(ctx)
+			if diags.HasErrors() {
+				continue
+			}
+			locals[name] = value
+			delete(pending, name)
+			progressed = true
+		}
+	}
+	ctx.Variables["local"] = cty.ObjectVal(locals)
+}
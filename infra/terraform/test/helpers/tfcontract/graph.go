@@ -0,0 +1,164 @@
+package tfcontract
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Wire 의존 모듈의 출력이 연결된 인자 (Output이 빈 값이면 모듈 전체 또는 depends_on)
+type Wire struct {
+	Output   string
+	Argument string
+}
+
+func (w Wire) String() string {
+	output := w.Output
+	if output == "" {
+		output = "*"
+	}
+	return output + " → " + w.Argument
+}
+
+// Edge From 모듈이 먼저 만들어져야 To 모듈을 만들 수 있는 의존 관계
+type Edge struct {
+	From  string
+	To    string
+	Wires []Wire
+}
+
+// Graph module 호출 간 의존 그래프
+type Graph struct {
+	Nodes []string
+	Edges []*Edge
+}
+
+// BuildGraph 모듈 안의 module 블록들이 서로의 출력을 참조하는 관계로 그래프 구성
+func BuildGraph(module *Module) *Graph {
+	graph := &Graph{Nodes: module.CallNames()}
+	edges := make(map[[2]string]*Edge)
+	connect := func(from, to string, wire Wire) {
+		if _, ok := module.Calls[from]; !ok {
+			return
+		}
+		key := [2]string{from, to}
+		edge, ok := edges[key]
+		if !ok {
+			edge = &Edge{From: from, To: to}
+			edges[key] = edge
+			graph.Edges = append(graph.Edges, edge)
+		}
+		for _, existing := range edge.Wires {
+			if existing == wire {
+				return
+			}
+		}
+		edge.Wires = append(edge.Wires, wire)
+	}
+
+	for _, name := range graph.Nodes {
+		call := module.Calls[name]
+		for _, argument := range sortedArguments(call) {
+			for _, traversal := range call.Arguments[argument].Expr.Variables() {
+				if from, output := moduleRef(traversal); from != "" {
+					connect(from, name, Wire{Output: output, Argument: argument})
+				}
+			}
+		}
+		for _, traversal := range call.DependsOn {
+			if from, _ := moduleRef(traversal); from != "" {
+				connect(from, name, Wire{Argument: "depends_on"})
+			}
+		}
+	}
+
+	sort.Slice(graph.Edges, func(i, j int) bool {
+		if graph.Edges[i].From != graph.Edges[j].From {
+			return graph.Edges[i].From < graph.Edges[j].From
+		}
+		return graph.Edges[i].To < graph.Edges[j].To
+	})
+	return graph
+}
+
+// DependenciesOf 모듈이 직접 의존하는 모듈 (정렬)
+func (g *Graph) DependenciesOf(name string) []string {
+	var dependencies []string
+	for _, edge := range g.Edges {
+		if edge.To == name {
+			dependencies = append(dependencies, edge.From)
+		}
+	}
+	sort.Strings(dependencies)
+	return dependencies
+}
+
+// Edge 두 모듈 사이의 의존 관계 (없으면 nil)
+func (g *Graph) Edge(from, to string) *Edge {
+	for _, edge := range g.Edges {
+		if edge.From == from && edge.To == to {
+			return edge
+		}
+	}
+	return nil
+}
+
+// Order 의존 순서대로 정렬한 모듈 (같은 단계는 이름순, 순환이 있으면 오류)
+func (g *Graph) Order() ([]string, error) {
+	indegree := make(map[string]int, len(g.Nodes))
+	for _, node := range g.Nodes {
+		indegree[node] = 0
+	}
+	for _, edge := range g.Edges {
+		indegree[edge.To]++
+	}
+
+	var order []string
+	for len(order) < len(g.Nodes) {
+		var ready []string
+		for _, node := range g.Nodes {
+			if indegree[node] == 0 {
+				ready = append(ready, node)
+			}
+		}
+		if len(ready) == 0 {
+			var cycle []string
+			for _, node := range g.Nodes {
+				if indegree[node] > 0 {
+					cycle = append(cycle, node)
+				}
+			}
+			return order, fmt.Errorf("module 순환 의존: %s", strings.Join(cycle, ", "))
+		}
+		for _, node := range ready {
+			order = append(order, node)
+			indegree[node] = -1
+			for _, edge := range g.Edges {
+				if edge.From == node {
+					indegree[edge.To]--
+				}
+			}
+		}
+	}
+	return order, nil
+}
+
+// DOT Graphviz 형식
+//
+//	go run ./cmd/tfcontract -graph | dot -Tsvg > modules.svg
+func (g *Graph) DOT() string {
+	var builder strings.Builder
+	builder.WriteString("digraph modules {\n  rankdir=LR;\n  node [shape=box];\n")
+	for _, node := range g.Nodes {
+		fmt.Fprintf(&builder, "  %q;\n", node)
+	}
+	for _, edge := range g.Edges {
+		labels := make([]string, len(edge.Wires))
+		for i, wire := range edge.Wires {
+			labels[i] = wire.String()
+		}
+		fmt.Fprintf(&builder, "  %q -> %q [label=%q];\n", edge.From, edge.To, strings.Join(labels, "\n"))
+	}
+	builder.WriteString("}\n")
+	return builder.String()
+}
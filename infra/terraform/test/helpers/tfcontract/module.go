@@ -0,0 +1,310 @@
+package tfcontract
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/ext/typeexpr"
+	"github.com/hashicorp/hcl/v2/hclparse"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// module 블록에서 입력 변수가 아닌 메타 인자
+var moduleMetaArguments = map[string]bool{
+	"source": true, "version": true, "count": true, "for_each": true, "providers": true, "depends_on": true,
+}
+
+// Variable 모듈 입력 변수
+type Variable struct {
+	Name     string
+	Type     cty.Type // type이 없으면 cty.DynamicPseudoType
+	Default  cty.Value
+	Required bool // default가 없는 변수 (default = null은 선택)
+	Range    hcl.Range
+}
+
+// Output 모듈 출력
+type Output struct {
+	Name  string
+	Value hcl.Expression
+	Range hcl.Range
+}
+
+// Argument module 블록에 전달한 입력 값
+type Argument struct {
+	Name  string
+	Expr  hcl.Expression
+	Range hcl.Range
+}
+
+// ModuleCall module 블록
+type ModuleCall struct {
+	Name      string
+	Source    string
+	Arguments map[string]*Argument
+	DependsOn []hcl.Traversal
+	Repeated  bool // count/for_each 사용 (출력이 목록/맵으로 감싸짐)
+	Range     hcl.Range
+}
+
+// Resource resource 블록 (최상위 속성 식만 보관)
+type Resource struct {
+	Type       string
+	Name       string
+	Attributes map[string]*Argument
+	Range      hcl.Range
+}
+
+// Address type.name 형태의 리소스 주소
+func (r *Resource) Address() string {
+	return r.Type + "." + r.Name
+}
+
+// Module 디렉터리 하나의 .tf 파일 집합
+type Module struct {
+	Dir       string
+	Variables map[string]*Variable
+	Outputs   map[string]*Output
+	Calls     map[string]*ModuleCall
+	// Resources 주소(type.name)별 resource 블록
+	Resources map[string]*Resource
+	Locals    map[string]hcl.Expression
+	// References 모듈 전체에서 var., module.로 시작하는 참조
+	References []hcl.Traversal
+}
+
+// LocalSource 로컬 경로 source 여부 (레지스트리/원격 모듈은 검사하지 않음)
+func (c *ModuleCall) LocalSource() bool {
+	return strings.HasPrefix(c.Source, "./") || strings.HasPrefix(c.Source, "../")
+}
+
+// RequiredVariables 값을 반드시 넘겨야 하는 변수 이름 (정렬)
+func (m *Module) RequiredVariables() []string {
+	var names []string
+	for name, variable := range m.Variables {
+		if variable.Required {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ResourcesOfType 타입이 같은 resource 블록 (주소순)
+func (m *Module) ResourcesOfType(resourceType string) []*Resource {
+	var resources []*Resource
+	for _, resource := range m.Resources {
+		if resource.Type == resourceType {
+			resources = append(resources, resource)
+		}
+	}
+	sort.Slice(resources, func(i, j int) bool { return resources[i].Name < resources[j].Name })
+	return resources
+}
+
+// CallNames module 블록 이름 (정렬)
+func (m *Module) CallNames() []string {
+	names := make([]string, 0, len(m.Calls))
+	for name := range m.Calls {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// LoadModule 디렉터리의 모든 .tf 파일을 파싱
+func LoadModule(dir string) (*Module, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.tf"))
+	if err != nil {
+		return nil, err
+	}
+	if len(paths) == 0 {
+		return nil, fmt.Errorf("%s: .tf 파일이 없습니다", dir)
+	}
+	sort.Strings(paths)
+
+	files := make(map[string][]byte, len(paths))
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		files[path] = data
+	}
+	return ParseModule(dir, files)
+}
+
+// ParseModule 파일 이름과 내용으로 모듈 파싱
+func ParseModule(dir string, files map[string][]byte) (*Module, error) {
+	module := &Module{
+		Dir:       dir,
+		Variables: make(map[string]*Variable),
+		Outputs:   make(map[string]*Output),
+		Calls:     make(map[string]*ModuleCall),
+		Resources: make(map[string]*Resource),
+		Locals:    make(map[string]hcl.Expression),
+	}
+
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	parser := hclparse.NewParser()
+	var diags hcl.Diagnostics
+	for _, name := range names {
+		file, fileDiags := parser.ParseHCL(files[name], name)
+		diags = append(diags, fileDiags...)
+		if fileDiags.HasErrors() {
+			continue
+		}
+		body := file.Body.(*hclsyntax.Body)
+		diags = append(diags, module.addBody(body)...)
+		module.References = append(module.References, references(body)...)
+	}
+	if diags.HasErrors() {
+		return nil, diags
+	}
+	return module, nil
+}
+
+func (m *Module) addBody(body *hclsyntax.Body) hcl.Diagnostics {
+	var diags hcl.Diagnostics
+	for _, block := range body.Blocks {
+		if block.Type == "locals" {
+			for name, attr := range block.Body.Attributes {
+				m.Locals[name] = attr.Expr
+			}
+			continue
+		}
+		if block.Type == "resource" && len(block.Labels) == 2 {
+			resource := &Resource{Type: block.Labels[0], Name: block.Labels[1], Attributes: make(map[string]*Argument), Range: block.DefRange()}
+			for attrName, attr := range block.Body.Attributes {
+				resource.Attributes[attrName] = &Argument{Name: attrName, Expr: attr.Expr, Range: attr.SrcRange}
+			}
+			m.Resources[resource.Address()] = resource
+			continue
+		}
+		if len(block.Labels) != 1 {
+			continue
+		}
+		name := block.Labels[0]
+		switch block.Type {
+		case "variable":
+			variable, varDiags := parseVariable(name, block)
+			diags = append(diags, varDiags...)
+			m.Variables[name] = variable
+		case "output":
+			output := &Output{Name: name, Range: block.DefRange()}
+			if attr, ok := block.Body.Attributes["value"]; ok {
+				output.Value = attr.Expr
+			}
+			m.Outputs[name] = output
+		case "module":
+			call, callDiags := parseModuleCall(name, block)
+			diags = append(diags, callDiags...)
+			m.Calls[name] = call
+		}
+	}
+	return diags
+}
+
+func parseVariable(name string, block *hclsyntax.Block) (*Variable, hcl.Diagnostics) {
+	variable := &Variable{Name: name, Type: cty.DynamicPseudoType, Required: true, Range: block.DefRange()}
+	var diags hcl.Diagnostics
+	if attr, ok := block.Body.Attributes["type"]; ok {
+		// optional() 속성을 쓰는 object 타입도 허용
+		ty, _, typeDiags := typeexpr.TypeConstraintWithDefaults(attr.Expr)
+		diags = append(diags, typeDiags...)
+		if !typeDiags.HasErrors() {
+			variable.Type = ty
+		}
+	}
+	if attr, ok := block.Body.Attributes["default"]; ok {
+		variable.Required = false
+		value, valueDiags := attr.Expr.Value(nil)
+		diags = append(diags, valueDiags...)
+		variable.Default = value
+	}
+	return variable, diags
+}
+
+func parseModuleCall(name string, block *hclsyntax.Block) (*ModuleCall, hcl.Diagnostics) {
+	call := &ModuleCall{Name: name, Arguments: make(map[string]*Argument), Range: block.DefRange()}
+	var diags hcl.Diagnostics
+	for attrName, attr := range block.Body.Attributes {
+		switch attrName {
+		case "source":
+			value, valueDiags := attr.Expr.Value(nil)
+			diags = append(diags, valueDiags...)
+			if !valueDiags.HasErrors() && value.Type() == cty.String && value.IsKnown() && !value.IsNull() {
+				call.Source = value.AsString()
+			}
+		case "depends_on":
+			call.DependsOn = attr.Expr.Variables()
+		case "count", "for_each":
+			call.Repeated = true
+		}
+		if moduleMetaArguments[attrName] {
+			continue
+		}
+		call.Arguments[attrName] = &Argument{Name: attrName, Expr: attr.Expr, Range: attr.SrcRange}
+	}
+	if call.Source == "" {
+		diags = append(diags, &hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  "module source 누락",
+			Detail:   fmt.Sprintf("module %q에 문자열 source가 없습니다", name),
+			Subject:  block.DefRange().Ptr(),
+		})
+	}
+	return call, diags
+}
+
+// 본문 전체(중첩 블록 포함)에서 var./module. 참조 수집
+func references(body *hclsyntax.Body) []hcl.Traversal {
+	var traversals []hcl.Traversal
+	attrs := make([]*hclsyntax.Attribute, 0, len(body.Attributes))
+	for _, attr := range body.Attributes {
+		attrs = append(attrs, attr)
+	}
+	sort.Slice(attrs, func(i, j int) bool { return attrs[i].SrcRange.Start.Byte < attrs[j].SrcRange.Start.Byte })
+	for _, attr := range attrs {
+		for _, traversal := range attr.Expr.Variables() {
+			switch traversal.RootName() {
+			case "var", "module":
+				traversals = append(traversals, traversal)
+			}
+		}
+	}
+	for _, block := range body.Blocks {
+		traversals = append(traversals, references(block.Body)...)
+	}
+	return traversals
+}
+
+// moduleRef module.<name>[<key>].<output> 참조의 모듈 이름과 출력 이름 (모듈 전체 참조면 출력은 빈 문자열)
+func moduleRef(traversal hcl.Traversal) (name, output string) {
+	if traversal.RootName() != "module" || len(traversal) < 2 {
+		return "", ""
+	}
+	if attr, ok := traversal[1].(hcl.TraverseAttr); ok {
+		name = attr.Name
+	}
+	for _, step := range traversal[2:] {
+		switch step := step.(type) {
+		case hcl.TraverseIndex, hcl.TraverseSplat:
+			continue
+		case hcl.TraverseAttr:
+			return name, step.Name
+		}
+		break
+	}
+	return name, ""
+}
@@ -0,0 +1,76 @@
+package tfcontract
+
+import (
+	"path/filepath"
+	"sort"
+)
+
+// Tree 루트 모듈과 로컬 source로 호출되는 하위 모듈
+type Tree struct {
+	Root *Module
+	// Modules 정리된 디렉터리 경로별 모듈 (루트 포함)
+	Modules map[string]*Module
+}
+
+// LoadTree 루트 모듈에서 시작해 로컬 source 모듈을 재귀적으로 파싱
+//
+// 존재하지 않는 source 디렉터리는 오류로 중단하지 않고 Check에서 unresolved-source로 보고합니다.
+func LoadTree(rootDir string) (*Tree, error) {
+	root, err := LoadModule(rootDir)
+	if err != nil {
+		return nil, err
+	}
+	tree := &Tree{Root: root, Modules: map[string]*Module{filepath.Clean(rootDir): root}}
+	if err := tree.loadCalls(root); err != nil {
+		return nil, err
+	}
+	return tree, nil
+}
+
+func (t *Tree) loadCalls(module *Module) error {
+	for _, name := range module.CallNames() {
+		call := module.Calls[name]
+		if !call.LocalSource() {
+			continue
+		}
+		dir := calleeDir(module, call)
+		if _, loaded := t.Modules[dir]; loaded {
+			continue
+		}
+		matches, _ := filepath.Glob(filepath.Join(dir, "*.tf"))
+		if len(matches) == 0 {
+			continue
+		}
+		callee, err := LoadModule(dir)
+		if err != nil {
+			return err
+		}
+		t.Modules[dir] = callee
+		if err := t.loadCalls(callee); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Callee 호출 대상 모듈 (원격 source이거나 디렉터리가 없으면 nil)
+func (t *Tree) Callee(caller *Module, call *ModuleCall) *Module {
+	if !call.LocalSource() {
+		return nil
+	}
+	return t.Modules[calleeDir(caller, call)]
+}
+
+// Dirs 트리에 포함된 모듈 디렉터리 (정렬)
+func (t *Tree) Dirs() []string {
+	dirs := make([]string, 0, len(t.Modules))
+	for dir := range t.Modules {
+		dirs = append(dirs, dir)
+	}
+	sort.Strings(dirs)
+	return dirs
+}
+
+func calleeDir(caller *Module, call *ModuleCall) string {
+	return filepath.Clean(filepath.Join(caller.Dir, call.Source))
+}
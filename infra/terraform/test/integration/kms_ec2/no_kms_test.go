@@ -21,6 +21,8 @@ var (
 
 // KMS 없이 EC2만 테스트하는 안정적인 통합 테스트
 func TestEC2WithoutKMS(t *testing.T) {
+	t.Skip(helpers.StandaloneEC2SkipReason)
+
 	// 통합 테스트는 순차 실행 (리소스 의존성 때문에)
 	// t.Parallel()
 
@@ -53,11 +55,11 @@ func TestEC2WithoutKMS(t *testing.T) {
 		t.Logf("🧹 리소스 정리 시작...")
 		if noKmsWorkerOptions != nil {
 			t.Logf("Worker 노드들 정리 중...")
-			terraform.Destroy(t, noKmsWorkerOptions)
+			helpers.DestroyTerraform(t, noKmsWorkerOptions)
 		}
 		if noKmsMasterOptions != nil {
 			t.Logf("Master 노드 정리 중...")
-			terraform.Destroy(t, noKmsMasterOptions)
+			helpers.DestroyTerraform(t, noKmsMasterOptions)
 		}
 		t.Logf("✅ 모든 리소스 정리 완료!")
 	})
@@ -94,7 +96,7 @@ func testMasterWithoutKMS(t *testing.T, awsClient *helpers.AWSTestClient, awsReg
 	noKmsMasterOptions = terraformOptions // 전역 변수에 저장
 
 	// Master 노드 생성
-	terraform.InitAndApply(t, terraformOptions)
+	helpers.ApplyTerraform(t, terraformOptions)
 
 	// Master 인스턴스 검증
 	instanceID := terraform.Output(t, terraformOptions, "instance_id")
@@ -150,7 +152,7 @@ func testWorkerWithoutKMS(t *testing.T, awsClient *helpers.AWSTestClient, awsReg
 	noKmsWorkerOptions = terraformOptions // 전역 변수에 저장
 
 	// Worker 노드들 생성
-	terraform.InitAndApply(t, terraformOptions)
+	helpers.ApplyTerraform(t, terraformOptions)
 
 	// Worker 인스턴스들 검증
 	workerIDs := terraform.OutputList(t, terraformOptions, "instance_ids")
@@ -37,10 +37,10 @@ func TestSimpleKMSCreation(t *testing.T) {
 	terraformOptions := helpers.SetupTerraform(t, tfConfig)
 
 	// 정리 설정
-	defer terraform.Destroy(t, terraformOptions)
+	defer helpers.DestroyTerraform(t, terraformOptions)
 
 	// Terraform 실행
-	terraform.InitAndApply(t, terraformOptions)
+	helpers.ApplyTerraform(t, terraformOptions)
 
 	// 기본 출력만 확인
 	kmsKeyId := terraform.Output(t, terraformOptions, "key_id")
@@ -36,10 +36,10 @@ func TestKMSOnly(t *testing.T) {
 	}
 
 	terraformOptions := helpers.SetupTerraform(t, tfConfig)
-	defer terraform.Destroy(t, terraformOptions)
+	defer helpers.DestroyTerraform(t, terraformOptions)
 
 	// KMS 리소스 생성
-	terraform.InitAndApply(t, terraformOptions)
+	helpers.ApplyTerraform(t, terraformOptions)
 
 	// KMS 키 검증
 	kmsKeyId := terraform.Output(t, terraformOptions, "key_id")
@@ -62,6 +62,8 @@ func TestKMSOnly(t *testing.T) {
 
 // 단계별 테스트 - Master 노드만 검증
 func TestMasterNodeOnly(t *testing.T) {
+	t.Skip(helpers.StandaloneEC2SkipReason)
+
 	awsRegion := "ap-northeast-2"
 	projectName := "k8s-ec2-observability"
 	environment := "test"
@@ -90,10 +92,10 @@ func TestMasterNodeOnly(t *testing.T) {
 	}
 
 	terraformOptions := helpers.SetupTerraform(t, tfConfig)
-	defer terraform.Destroy(t, terraformOptions)
+	defer helpers.DestroyTerraform(t, terraformOptions)
 
 	// Master 노드 생성
-	terraform.InitAndApply(t, terraformOptions)
+	helpers.ApplyTerraform(t, terraformOptions)
 
 	// Master 인스턴스 검증
 	instanceID := terraform.Output(t, terraformOptions, "instance_id")
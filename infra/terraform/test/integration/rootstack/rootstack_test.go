@@ -0,0 +1,104 @@
+package integration
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/k8s-ec2-observability/test/helpers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// 루트 구성(main.tf)을 통째로 apply해서 모듈 사이의 배선이 실제 리소스에 반영됐는지 검증
+func TestRootStack(t *testing.T) {
+	// 스택 하나를 공유하는 하위 테스트이므로 순차 실행
+	config := helpers.NewRootStackTestConfig()
+	// TEST_ASSUME_ROLE_ARN이 있으면 terraform_execution_role 권한만으로 배포되는지 검증
+	role := helpers.AssumeRoleConfigFromEnv(config.UniqueID)
+	awsClient := helpers.NewAWSTestClientWithRole(t, config.Region, role)
+
+	t.Logf("🚀 루트 스택 테스트 시작: %s", config.ProjectName)
+	stack := helpers.DeployRootStack(t, awsClient, config)
+
+	if role != nil {
+		t.Run("Assumed_Role_Session", func(t *testing.T) {
+			identity, err := awsClient.GetCallerIdentity()
+			require.NoError(t, err)
+			assert.Contains(t, aws.StringValue(identity.Arn), ":assumed-role/", "역할 세션으로 호출해야 합니다")
+			assert.True(t, strings.HasSuffix(aws.StringValue(identity.Arn), "/"+role.SessionName), "세션 이름이 고유 테스트 ID와 연결되어야 합니다: %s", aws.StringValue(identity.Arn))
+		})
+	}
+
+	t.Run("Worker_Subnet_In_VPC", func(t *testing.T) {
+		subnet, err := awsClient.ValidateSubnet(stack.PrivateSubnetID)
+		require.NoError(t, err)
+		require.NotNil(t, subnet, "프라이빗 서브넷이 존재해야 합니다")
+		assert.Equal(t, stack.VPCID, aws.StringValue(subnet.VpcId), "워커 서브넷은 생성된 VPC에 속해야 합니다")
+		assert.Equal(t, config.AvailabilityZone, aws.StringValue(subnet.AvailabilityZone))
+
+		for _, instanceID := range stack.WorkerInstanceIDs {
+			instance, err := awsClient.ValidateEC2Instance(instanceID)
+			require.NoError(t, err)
+			require.NotNil(t, instance, "워커 인스턴스 %s가 존재해야 합니다", instanceID)
+			assert.Equal(t, stack.PrivateSubnetID, aws.StringValue(instance.SubnetId), "워커 %s는 프라이빗 서브넷에 있어야 합니다", instanceID)
+			assert.Equal(t, stack.VPCID, aws.StringValue(instance.VpcId))
+		}
+	})
+
+	t.Run("VPC_Topology", func(t *testing.T) {
+		topology, err := awsClient.GetVPCTopology(stack.VPCID)
+		require.NoError(t, err)
+		assert.Equal(t, config.VPCCIDR, topology.CIDR)
+
+		// 루트 스택은 enable_nat_gateway 기본값(true)을 사용
+		assert.Empty(t, helpers.CheckNATGateway(topology, true))
+		assert.Empty(t, helpers.CheckPublicSubnet(topology, stack.PublicSubnetID), "퍼블릭 서브넷은 0.0.0.0/0을 인터넷 게이트웨이로 보내야 합니다")
+		assert.Empty(t, helpers.CheckPrivateSubnetEgressViaNAT(topology, stack.PrivateSubnetID), "프라이빗 서브넷은 NAT 게이트웨이로 나가야 합니다")
+	})
+
+	t.Run("Security_Group_Attached_To_Master", func(t *testing.T) {
+		group, err := awsClient.ValidateSecurityGroup(stack.SecurityGroupID)
+		require.NoError(t, err)
+		require.NotNil(t, group, "security 모듈 보안 그룹이 존재해야 합니다")
+		assert.Equal(t, stack.VPCID, aws.StringValue(group.VpcId), "보안 그룹은 생성된 VPC에 속해야 합니다")
+
+		master, err := awsClient.ValidateEC2Instance(stack.MasterInstanceID)
+		require.NoError(t, err)
+		require.NotNil(t, master, "마스터 인스턴스가 존재해야 합니다")
+		assert.Equal(t, "running", aws.StringValue(master.State.Name))
+		assert.Equal(t, stack.PublicSubnetID, aws.StringValue(master.SubnetId), "마스터는 퍼블릭 서브넷에 있어야 합니다")
+		assert.Equal(t, stack.MasterPrivateIP, aws.StringValue(master.PrivateIpAddress))
+
+		var attached []string
+		for _, group := range master.SecurityGroups {
+			attached = append(attached, aws.StringValue(group.GroupId))
+		}
+		assert.Contains(t, attached, stack.SecurityGroupID, "security 모듈 보안 그룹이 마스터에 연결되어야 합니다")
+	})
+
+	t.Run("Worker_Count", func(t *testing.T) {
+		assert.Len(t, stack.WorkerInstanceIDs, config.WorkerCount, "worker_instance_count만큼 워커가 생성되어야 합니다")
+		assert.Len(t, stack.WorkerPrivateIPs, config.WorkerCount)
+
+		// 출력 값만 믿지 않고 태그로 실제 인스턴스 수를 확인
+		instances, err := awsClient.FindEC2InstancesByTags(map[string]string{
+			"Project":  config.ProjectName,
+			"UniqueID": config.UniqueID,
+		})
+		require.NoError(t, err)
+
+		var workers []string
+		for _, instance := range instances {
+			for _, tag := range instance.Tags {
+				if aws.StringValue(tag.Key) == "Role" && strings.HasPrefix(aws.StringValue(tag.Value), "worker") {
+					workers = append(workers, aws.StringValue(instance.InstanceId))
+					assert.Equal(t, "running", aws.StringValue(instance.State.Name), "워커 %s", aws.StringValue(instance.InstanceId))
+				}
+			}
+		}
+		assert.ElementsMatch(t, stack.WorkerInstanceIDs, workers, "태그로 찾은 워커와 출력의 워커가 같아야 합니다")
+	})
+
+	t.Logf("✅ 루트 스택 검증 완료: %s", config.ProjectName)
+}
@@ -0,0 +1,170 @@
+package alertmanager
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/k8s-ec2-observability/test/helpers/alertmanager"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// 요청 횟수에 따라 알림 목록을 바꾸는 Alertmanager 대역 서버
+type fakeAlertmanager struct {
+	mu       sync.Mutex
+	requests []*http.Request
+	// states[i]는 i번째 요청의 응답 (마지막 상태를 계속 유지)
+	states [][]alertmanager.Alert
+}
+
+func (f *fakeAlertmanager) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if r.URL.Path != "/api/v2/alerts" {
+		http.Error(w, "not found", http.StatusNotFound)
+		return
+	}
+	index := len(f.requests)
+	if index >= len(f.states) {
+		index = len(f.states) - 1
+	}
+	f.requests = append(f.requests, r)
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(f.states[index])
+}
+
+func (f *fakeAlertmanager) requestCount() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return len(f.requests)
+}
+
+func newClient(t *testing.T, fake *fakeAlertmanager) *alertmanager.Client {
+	server := httptest.NewServer(fake)
+	t.Cleanup(server.Close)
+
+	client, err := alertmanager.NewClient(server.URL, nil)
+	require.NoError(t, err)
+	return client
+}
+
+func podRestartLoop(pod string) alertmanager.Alert {
+	return alertmanager.Alert{
+		Labels: map[string]string{"alertname": "PodRestartLoop", "namespace": "bookinfo", "pod": pod, "severity": "warning"},
+		Annotations: map[string]string{
+			"summary":     "⚠️ Pod 재시작 루프 감지",
+			"description": "bookinfo/" + pod + " Pod가 지난 15분간 4번 재시작했습니다.",
+		},
+		StartsAt: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		Status:   alertmanager.AlertStatus{State: alertmanager.StateActive},
+	}
+}
+
+func TestAlertsSendsFiltersAndMatchesLocally(t *testing.T) {
+	t.Parallel()
+
+	other := podRestartLoop("reviews-v1")
+	other.Labels["namespace"] = "default"
+	fake := &fakeAlertmanager{states: [][]alertmanager.Alert{{podRestartLoop("ratings-v1"), other}}}
+	client := newClient(t, fake)
+
+	matchers, err := alertmanager.ParseMatchers(`alertname="PodRestartLoop"`, `namespace=~"book.*"`)
+	require.NoError(t, err)
+
+	alertList, err := client.Alerts(context.Background(), alertmanager.AlertsOptions{Matchers: matchers})
+	require.NoError(t, err)
+	require.Len(t, alertList, 1, "대역 서버가 필터를 무시해도 로컬에서 거름")
+	assert.Equal(t, "ratings-v1", alertList[0].Labels["pod"])
+
+	query := fake.requests[0].URL.Query()
+	assert.Equal(t, []string{`alertname="PodRestartLoop"`, `namespace=~"book.*"`}, query["filter"])
+	assert.Equal(t, "true", query.Get("active"))
+	assert.Equal(t, "false", query.Get("silenced"))
+	assert.Equal(t, "false", query.Get("inhibited"))
+}
+
+func TestParseMatchersInvalid(t *testing.T) {
+	t.Parallel()
+
+	_, err := alertmanager.ParseMatchers(`alertname=PodRestartLoop`)
+	assert.Error(t, err)
+}
+
+func TestAlertsHTTPError(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "bad matcher", http.StatusBadRequest)
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := alertmanager.NewClient(server.URL, nil)
+	require.NoError(t, err)
+	_, err = client.Alerts(context.Background(), alertmanager.AlertsOptions{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "HTTP 400")
+}
+
+func TestWaitForFiringThenResolved(t *testing.T) {
+	t.Parallel()
+
+	fake := &fakeAlertmanager{states: [][]alertmanager.Alert{
+		{},
+		{},
+		{podRestartLoop("ratings-v1")},
+		{podRestartLoop("ratings-v1")},
+		{},
+	}}
+	client := newClient(t, fake)
+	matchers, err := alertmanager.ParseMatchers(`alertname="PodRestartLoop"`)
+	require.NoError(t, err)
+
+	firing, err := alertmanager.WaitForFiring(t, client, matchers, time.Second, 10*time.Millisecond)
+	require.NoError(t, err)
+	require.Len(t, firing, 1)
+	assert.Equal(t, 3, fake.requestCount())
+
+	require.NoError(t, alertmanager.WaitForResolved(t, client, matchers, time.Second, 10*time.Millisecond))
+	assert.Equal(t, 5, fake.requestCount())
+	query := fake.requests[4].URL.Query()
+	assert.Equal(t, "true", query.Get("silenced"), "해제 대기는 사일런스된 알림도 활성으로 간주")
+}
+
+func TestWaitForFiringTimeout(t *testing.T) {
+	t.Parallel()
+
+	fake := &fakeAlertmanager{states: [][]alertmanager.Alert{{}}}
+	client := newClient(t, fake)
+	matchers, err := alertmanager.ParseMatchers(`alertname="PrometheusDown"`)
+	require.NoError(t, err)
+
+	_, err = alertmanager.WaitForFiring(t, client, matchers, 30*time.Millisecond, 10*time.Millisecond)
+	assert.Error(t, err)
+	assert.Equal(t, 4, fake.requestCount())
+}
+
+func TestCheckAnnotations(t *testing.T) {
+	t.Parallel()
+
+	alert := podRestartLoop("ratings-v1")
+	assert.Empty(t, alertmanager.CheckAnnotations(alert, map[string]string{
+		"summary":     "",
+		"description": `ratings-v1 Pod가 .* [0-9]+번 재시작`,
+	}))
+
+	alert.Annotations["description"] = "{{ $labels.pod }} Pod가 <no value>번 재시작했습니다."
+	problems := alertmanager.CheckAnnotations(alert, map[string]string{
+		"description": `ratings-v1`,
+		"runbook_url": "",
+	})
+	require.Len(t, problems, 3)
+	assert.Contains(t, problems[0], "맞지 않습니다")
+	assert.Contains(t, problems[1], "runbook_url 어노테이션이 없습니다")
+	assert.Contains(t, problems[2], "렌더링되지 않았습니다")
+}
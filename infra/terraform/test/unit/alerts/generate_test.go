@@ -0,0 +1,172 @@
+package alerts
+
+import (
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/k8s-ec2-observability/test/helpers/alerts"
+	"github.com/prometheus/common/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const customAlertRulesTemplatePath = customAlertRulesPath + ".tmpl"
+
+const (
+	masterNode     = "ip-10-0-1-34"
+	masterInstance = "10.0.1.34:9100"
+	workerNode     = "ip-10-0-1-57"
+	workerInstance = "10.0.1.57:9100"
+)
+
+func TestCommittedRulesMatchTemplate(t *testing.T) {
+	t.Parallel()
+
+	templateText, err := os.ReadFile(customAlertRulesTemplatePath)
+	require.NoError(t, err)
+	committed, err := os.ReadFile(customAlertRulesPath)
+	require.NoError(t, err)
+
+	rendered, err := alerts.RenderPrometheusRule(string(templateText), alerts.RoleNodeTopology())
+	require.NoError(t, err)
+	assert.Equal(t, string(rendered), string(committed), "custom-alert-rules.yaml을 다시 생성하세요 (go run ./cmd/alertrules)")
+}
+
+func TestNodeNameFromAddress(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]string{
+		"10.0.1.34": "ip-10-0-1-34",
+		"ip-10-0-1-34.ap-northeast-2.compute.internal": "ip-10-0-1-34",
+		" ip-10-0-2-7 ": "ip-10-0-2-7",
+	}
+	for address, expected := range cases {
+		name, err := alerts.NodeNameFromAddress(address)
+		require.NoError(t, err, address)
+		assert.Equal(t, expected, name, address)
+	}
+
+	_, err := alerts.NodeNameFromAddress("")
+	assert.Error(t, err)
+	_, err = alerts.NodeNameFromAddress("fd00::1")
+	assert.Error(t, err)
+}
+
+func TestRenderRequiresNodeNames(t *testing.T) {
+	t.Parallel()
+
+	topology, err := alerts.NodeTopologyFromAddresses("10.0.1.34", nil)
+	require.NoError(t, err)
+
+	_, err = alerts.RenderPrometheusRule("kind: PrometheusRule\n", topology)
+	assert.Error(t, err, "워커 노드가 없으면 렌더링하지 않아야 합니다")
+}
+
+func TestRenderedRulesSelectNodes(t *testing.T) {
+	t.Parallel()
+
+	templateText, err := os.ReadFile(customAlertRulesTemplatePath)
+	require.NoError(t, err)
+
+	byName, err := alerts.NodeTopologyFromAddresses("10.0.1.34", []string{"ip-10-0-1-57.ap-northeast-2.compute.internal"})
+	require.NoError(t, err)
+
+	topologies := map[string]alerts.NodeTopology{
+		"role": alerts.RoleNodeTopology(),
+		"name": byName,
+	}
+	for name, topology := range topologies {
+		topology := topology
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			rendered, err := alerts.RenderPrometheusRule(string(templateText), topology)
+			require.NoError(t, err)
+			rule, err := alerts.ParsePrometheusRule(rendered)
+			require.NoError(t, err)
+
+			findings := alerts.Lint(rule, alerts.DefaultLintOptions())
+			assert.Empty(t, alerts.Errors(findings))
+
+			for _, err := range alerts.RunRuleTests(t, rule, nodeSelectionTests()) {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+// 마스터와 워커가 모두 과부하 상태일 때 마스터 알림은 마스터 노드에서만 발생해야 함
+func nodeSelectionTests() *alerts.RuleTestFile {
+	series := []alerts.InputSeries{
+		{Series: fmt.Sprintf(`node_uname_info{instance=%q,nodename=%q}`, masterInstance, masterNode), Values: "1x40"},
+		{Series: fmt.Sprintf(`node_uname_info{instance=%q,nodename=%q}`, workerInstance, workerNode), Values: "1x40"},
+		{Series: fmt.Sprintf(`kube_node_info{node=%q}`, masterNode), Values: "1x40"},
+		{Series: fmt.Sprintf(`kube_node_info{node=%q}`, workerNode), Values: "1x40"},
+		{Series: fmt.Sprintf(`kube_node_role{node=%q,role="control-plane"}`, masterNode), Values: "1x40"},
+	}
+	for _, instance := range []string{masterInstance, workerInstance} {
+		series = append(series,
+			alerts.InputSeries{Series: fmt.Sprintf(`node_cpu_seconds_total{instance=%q,cpu="0",mode="idle"}`, instance), Values: "0+1x40"},
+			alerts.InputSeries{Series: fmt.Sprintf(`node_memory_MemAvailable_bytes{instance=%q}`, instance), Values: "100x40"},
+			alerts.InputSeries{Series: fmt.Sprintf(`node_memory_MemTotal_bytes{instance=%q}`, instance), Values: "1000x40"},
+		)
+	}
+	// 마스터 46개, 워커 2개 Pod
+	for i := 0; i < 48; i++ {
+		node := masterNode
+		if i >= 46 {
+			node = workerNode
+		}
+		series = append(series, alerts.InputSeries{
+			Series: fmt.Sprintf(`kube_pod_info{namespace="default",pod="pod-%d",node=%q}`, i, node),
+			Values: "1x40",
+		})
+	}
+
+	masterLabels := func(extra map[string]string) map[string]string {
+		labels := map[string]string{"instance": masterInstance, "component": "node", "node_type": "master"}
+		for key, value := range extra {
+			labels[key] = value
+		}
+		return labels
+	}
+
+	return &alerts.RuleTestFile{
+		EvaluationInterval: model.Duration(time.Minute),
+		Tests: []alerts.RuleTest{{
+			Name:        "node-selection",
+			Interval:    model.Duration(time.Minute),
+			InputSeries: series,
+			AlertRuleTests: []alerts.AlertTestCase{
+				{
+					EvalTime:  model.Duration(5 * time.Minute),
+					Alertname: "MasterNodeHighCPU",
+					ExpAlerts: []alerts.ExpectedAlert{{ExpLabels: masterLabels(map[string]string{"severity": "critical"})}},
+				},
+				{
+					EvalTime:  model.Duration(5 * time.Minute),
+					Alertname: "MasterNodeHighMemory",
+					ExpAlerts: []alerts.ExpectedAlert{{ExpLabels: masterLabels(map[string]string{"severity": "critical"})}},
+				},
+				{
+					EvalTime:  model.Duration(12 * time.Minute),
+					Alertname: "MasterNodeOverloaded",
+					ExpAlerts: []alerts.ExpectedAlert{{
+						ExpLabels:      map[string]string{"severity": "warning", "component": "self-managed"},
+						ExpAnnotations: map[string]string{"summary": "🏗️ 마스터노드 과부하 (46개 Pod)"},
+					}},
+				},
+				{
+					EvalTime:  model.Duration(32 * time.Minute),
+					Alertname: "WorkerNodeUnderutilized",
+					ExpAlerts: []alerts.ExpectedAlert{{
+						ExpLabels:      map[string]string{"severity": "info", "component": "self-managed"},
+						ExpAnnotations: map[string]string{"summary": "🏗️ 워커노드 저사용률 (2개 Pod만 실행)"},
+					}},
+				},
+			},
+		}},
+	}
+}
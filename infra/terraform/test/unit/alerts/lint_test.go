@@ -0,0 +1,181 @@
+package alerts
+
+import (
+	"testing"
+
+	"github.com/k8s-ec2-observability/test/helpers/alerts"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const customAlertRulesPath = "../../../../../manifests/observability/custom-alert-rules.yaml"
+
+func TestCustomAlertRulesLint(t *testing.T) {
+	t.Parallel()
+
+	rule, err := alerts.LoadPrometheusRule(customAlertRulesPath)
+	require.NoError(t, err)
+	require.NotEmpty(t, rule.AlertingRules())
+
+	findings := alerts.Lint(rule, alerts.DefaultLintOptions())
+	for _, finding := range alerts.Warnings(findings) {
+		t.Logf("경고: %s", finding)
+	}
+	assert.Empty(t, alerts.Errors(findings), "custom-alert-rules.yaml에 오류가 없어야 합니다")
+	for _, finding := range findings {
+		assert.NotEqual(t, alerts.CheckHardcodedInstance, finding.Check, "노드 이름은 템플릿으로 생성해야 합니다: %s", finding)
+	}
+}
+
+func TestLintDetectsProblems(t *testing.T) {
+	t.Parallel()
+
+	rule, err := alerts.ParsePrometheusRule([]byte(`apiVersion: monitoring.coreos.com/v1
+kind: PrometheusRule
+metadata:
+  name: broken
+spec:
+  groups:
+  - name: broken-group
+    interval: 30x
+    rules:
+    - alert: BrokenExpr
+      expr: sum(rate(http_requests_total[5m]) > 1
+      for: 2m
+      labels:
+        severity: critical
+        component: app
+      annotations:
+        summary: "broken"
+        description: "broken"
+    - alert: HardcodedNode
+      expr: node_load1{instance=~".*ip-10-0-1-34.*"} > 4
+      for: 1x
+      labels:
+        severity: page
+      annotations:
+        summary: "{{ $labels.instance"
+    - alert: RestartDelta
+      expr: (count(kube_pod_container_status_restarts_total) - count(kube_pod_container_status_restarts_total offset 10m)) > 5
+      labels:
+        severity: info
+        component: auto-recovery
+      annotations:
+        summary: "restarts"
+        description: "{{ $value }}"
+    - alert: LostLabel
+      expr: sum by(namespace) (kube_pod_info) > 10
+      labels:
+        severity: warning
+        component: pod
+      annotations:
+        summary: "too many pods"
+        description: "{{ $labels.namespace }} / {{ $labels.pod }}"
+    - alert: RestartDelta
+      expr: vector(1)
+      labels:
+        severity: info
+        component: auto-recovery
+      annotations:
+        summary: "duplicate"
+        description: "duplicate"
+`))
+	require.NoError(t, err)
+
+	findings := alerts.Lint(rule, alerts.DefaultLintOptions())
+	for _, finding := range findings {
+		t.Log(finding)
+	}
+
+	expected := []struct {
+		rule     string
+		check    string
+		severity alerts.Severity
+	}{
+		{"", alerts.CheckDuration, alerts.SeverityError},
+		{"BrokenExpr", alerts.CheckPromQLParse, alerts.SeverityError},
+		{"HardcodedNode", alerts.CheckDuration, alerts.SeverityError},
+		{"HardcodedNode", alerts.CheckMissingLabel, alerts.SeverityError},
+		{"HardcodedNode", alerts.CheckInvalidSeverity, alerts.SeverityError},
+		{"HardcodedNode", alerts.CheckMissingAnnotation, alerts.SeverityError},
+		{"HardcodedNode", alerts.CheckTemplate, alerts.SeverityError},
+		{"HardcodedNode", alerts.CheckHardcodedInstance, alerts.SeverityWarning},
+		{"RestartDelta", alerts.CheckCountOffsetDelta, alerts.SeverityWarning},
+		{"LostLabel", alerts.CheckTemplateLabel, alerts.SeverityWarning},
+		{"RestartDelta", alerts.CheckDuplicateAlert, alerts.SeverityError},
+	}
+	for _, want := range expected {
+		assert.True(t, hasFinding(findings, want.rule, want.check, want.severity), "%s/%s 검출 필요", want.rule, want.check)
+	}
+
+	// 집계 결과에 남는 레이블은 경고하지 않음
+	for _, finding := range findings {
+		if finding.Check == alerts.CheckTemplateLabel {
+			assert.Contains(t, finding.Message, "$labels.pod")
+			assert.NotContains(t, finding.Message, "$labels.namespace")
+		}
+	}
+}
+
+func TestLintDetectsEscapedNodeIP(t *testing.T) {
+	t.Parallel()
+
+	rule, err := alerts.ParsePrometheusRule([]byte(`apiVersion: monitoring.coreos.com/v1
+kind: PrometheusRule
+metadata:
+  name: escaped
+spec:
+  groups:
+  - name: escaped-group
+    rules:
+    - alert: EscapedNodeIP
+      expr: node_load1{instance=~"10\\.0\\.1\\.34:9100"} > 4
+      labels:
+        severity: warning
+        component: node
+      annotations:
+        summary: "escaped"
+        description: "escaped"
+`))
+	require.NoError(t, err)
+
+	findings := alerts.Lint(rule, alerts.DefaultLintOptions())
+	assert.True(t, hasFinding(findings, "EscapedNodeIP", alerts.CheckHardcodedInstance, alerts.SeverityWarning), "정규식 이스케이프된 IP도 검출해야 합니다")
+}
+
+func TestLintFindingLineNumbers(t *testing.T) {
+	t.Parallel()
+
+	rule, err := alerts.LoadPrometheusRule(customAlertRulesPath)
+	require.NoError(t, err)
+
+	autoRecovery, ok := rule.FindAlert("AutoRecoveryTrigger")
+	require.True(t, ok)
+	assert.Equal(t, 180, autoRecovery.Line)
+
+	var deltas []alerts.Finding
+	for _, finding := range alerts.Lint(rule, alerts.DefaultLintOptions()) {
+		if finding.Check == alerts.CheckCountOffsetDelta {
+			deltas = append(deltas, finding)
+		}
+	}
+	require.Len(t, deltas, 1)
+	assert.Equal(t, "AutoRecoveryTrigger", deltas[0].Rule)
+	assert.Equal(t, autoRecovery.Line, deltas[0].Line)
+}
+
+func TestParsePrometheusRuleRejectsOtherKinds(t *testing.T) {
+	t.Parallel()
+
+	_, err := alerts.ParsePrometheusRule([]byte("apiVersion: v1\nkind: ConfigMap\n"))
+	assert.Error(t, err)
+}
+
+func hasFinding(findings []alerts.Finding, rule, check string, severity alerts.Severity) bool {
+	for _, finding := range findings {
+		if finding.Rule == rule && finding.Check == check && finding.Severity == severity {
+			return true
+		}
+	}
+	return false
+}
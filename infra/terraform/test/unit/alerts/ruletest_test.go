@@ -0,0 +1,65 @@
+package alerts
+
+import (
+	"testing"
+
+	"github.com/k8s-ec2-observability/test/helpers/alerts"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCustomAlertRulesUnitTests(t *testing.T) {
+	t.Parallel()
+
+	rule, err := alerts.LoadPrometheusRule(customAlertRulesPath)
+	require.NoError(t, err)
+	file, err := alerts.LoadRuleTestFile("testdata/custom-alert-rules.test.yaml")
+	require.NoError(t, err)
+
+	for _, err := range alerts.RunRuleTests(t, rule, file) {
+		t.Error(err)
+	}
+}
+
+func TestRunRuleTestsReportsMismatch(t *testing.T) {
+	t.Parallel()
+
+	rule, err := alerts.LoadPrometheusRule(customAlertRulesPath)
+	require.NoError(t, err)
+
+	file := &alerts.RuleTestFile{
+		Tests: []alerts.RuleTest{{
+			Name:        "wrong-expectation",
+			InputSeries: []alerts.InputSeries{{Series: `up{job="prometheus-kube-prometheus-prometheus"}`, Values: "0x5"}},
+			AlertRuleTests: []alerts.AlertTestCase{{
+				EvalTime:  0,
+				Alertname: "PrometheusDown",
+				ExpAlerts: []alerts.ExpectedAlert{{ExpLabels: map[string]string{
+					"severity": "critical", "component": "monitoring", "job": "prometheus-kube-prometheus-prometheus",
+				}}},
+			}},
+		}},
+	}
+
+	errs := alerts.RunRuleTests(t, rule, file)
+	require.Len(t, errs, 1, "for: 1m 이전에는 알림이 발생하지 않아야 합니다")
+	assert.Contains(t, errs[0].Error(), "wrong-expectation")
+}
+
+func TestRunRuleTestsUnknownAlert(t *testing.T) {
+	t.Parallel()
+
+	rule, err := alerts.LoadPrometheusRule(customAlertRulesPath)
+	require.NoError(t, err)
+
+	file := &alerts.RuleTestFile{
+		Tests: []alerts.RuleTest{{
+			InputSeries:    []alerts.InputSeries{{Series: "up", Values: "1"}},
+			AlertRuleTests: []alerts.AlertTestCase{{Alertname: "NoSuchAlert"}},
+		}},
+	}
+
+	errs := alerts.RunRuleTests(t, rule, file)
+	require.Len(t, errs, 1)
+	assert.Contains(t, errs[0].Error(), "NoSuchAlert")
+}
@@ -0,0 +1,279 @@
+package assumerole
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/sts"
+	"github.com/gruntwork-io/terratest/modules/terraform"
+	"github.com/k8s-ec2-observability/test/helpers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const roleARN = "arn:aws:iam::123456789012:role/Terraform-Execution-Role"
+
+// fakeSTS 호출마다 새 세션을 발급하고 요청을 기록하는 STS
+type fakeSTS struct {
+	mu       sync.Mutex
+	lifetime time.Duration
+	err      error
+	inputs   []*sts.AssumeRoleInput
+}
+
+func (f *fakeSTS) AssumeRole(input *sts.AssumeRoleInput) (*sts.AssumeRoleOutput, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.inputs = append(f.inputs, input)
+	if f.err != nil {
+		return nil, f.err
+	}
+	n := len(f.inputs)
+	return &sts.AssumeRoleOutput{
+		Credentials: &sts.Credentials{
+			AccessKeyId:     aws.String("ASIA" + strings.Repeat("X", n)),
+			SecretAccessKey: aws.String("secret"),
+			SessionToken:    aws.String("token-" + strings.Repeat("x", n)),
+			Expiration:      aws.Time(time.Now().Add(f.lifetime)),
+		},
+	}, nil
+}
+
+func (f *fakeSTS) calls() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return len(f.inputs)
+}
+
+// roleClient fake STS로 역할을 위임받는 클라이언트 (기록한 역할 프로필은 테스트가 끝나면 삭제)
+func roleClient(t *testing.T, role *helpers.AssumeRoleConfig, fake *fakeSTS) *helpers.AWSTestClient {
+	client := &helpers.AWSTestClient{
+		Role:        role,
+		Credentials: helpers.NewRoleCredentials(fake, role),
+	}
+	t.Cleanup(func() { assert.NoError(t, client.RemoveRoleProfile()) })
+	return client
+}
+
+func TestRoleSessionName(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		uniqueID string
+		want     string
+	}{
+		{"고유 ID", "a1b2c3", "terratest-a1b2c3"},
+		{"허용되지 않는 문자", "ab cd/ef:gh", "terratest-ab-cd-ef-gh"},
+		{"허용되는 특수문자", "a+b=c,d.e@f-g_h", "terratest-a+b=c,d.e@f-g_h"},
+		{"최대 길이", strings.Repeat("a", 80), "terratest-" + strings.Repeat("a", 54)},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := helpers.RoleSessionName(tt.uniqueID)
+			assert.Equal(t, tt.want, got)
+			assert.LessOrEqual(t, len(got), 64, "STS 세션 이름은 64자를 넘을 수 없습니다")
+		})
+	}
+}
+
+func TestAssumeRoleConfigFromEnv(t *testing.T) {
+	t.Setenv(helpers.EnvAssumeRoleARN, "")
+	assert.Nil(t, helpers.AssumeRoleConfigFromEnv("abc123"), "역할 ARN이 없으면 주변 자격 증명을 써야 합니다")
+
+	t.Setenv(helpers.EnvAssumeRoleARN, roleARN)
+	t.Setenv(helpers.EnvAssumeRoleExternalID, "k8s-obs-test")
+	role := helpers.AssumeRoleConfigFromEnv("abc123")
+	require.NotNil(t, role)
+	assert.Equal(t, roleARN, role.RoleARN)
+	assert.Equal(t, "k8s-obs-test", role.ExternalID)
+	assert.Equal(t, "terratest-abc123", role.SessionName)
+	assert.Less(t, role.MinValidity, role.Duration, "최소 유효 시간은 세션 시간보다 짧아야 합니다")
+}
+
+func TestRoleCredentialsRequest(t *testing.T) {
+	t.Parallel()
+
+	fake := &fakeSTS{lifetime: time.Hour}
+	role := helpers.NewAssumeRoleConfig(roleARN, "k8s-obs-test", "abc123")
+	_, err := helpers.NewRoleCredentials(fake, role).Get()
+	require.NoError(t, err)
+
+	require.Len(t, fake.inputs, 1)
+	input := fake.inputs[0]
+	assert.Equal(t, roleARN, aws.StringValue(input.RoleArn))
+	assert.Equal(t, "k8s-obs-test", aws.StringValue(input.ExternalId))
+	assert.Equal(t, "terratest-abc123", aws.StringValue(input.RoleSessionName))
+	assert.Equal(t, int64(time.Hour/time.Second), aws.Int64Value(input.DurationSeconds))
+
+	// external ID가 없으면 요청에도 넣지 않음
+	fake = &fakeSTS{lifetime: time.Hour}
+	_, err = helpers.NewRoleCredentials(fake, helpers.NewAssumeRoleConfig(roleARN, "", "abc123")).Get()
+	require.NoError(t, err)
+	assert.Nil(t, fake.inputs[0].ExternalId)
+}
+
+// readProfile Terraform에 넘긴 설정 파일 읽기
+func readProfile(t *testing.T, envVars map[string]string) string {
+	t.Helper()
+	data, err := os.ReadFile(envVars["AWS_CONFIG_FILE"])
+	require.NoError(t, err)
+	return string(data)
+}
+
+// t.Setenv을 쓰므로 병렬로 실행하지 않음
+func TestTerraformEnvVarsUseRoleProfile(t *testing.T) {
+	base := filepath.Join(t.TempDir(), "config")
+	require.NoError(t, os.WriteFile(base, []byte("[profile dev]\nregion = ap-northeast-2\n"), 0o600))
+	t.Setenv("AWS_CONFIG_FILE", base)
+	t.Setenv("AWS_ACCESS_KEY_ID", "AKIAEXAMPLE")
+
+	fake := &fakeSTS{lifetime: time.Hour}
+	client := roleClient(t, helpers.NewAssumeRoleConfig(roleARN, "k8s-obs-test", "abc123"), fake)
+	envVars, err := client.TerraformEnvVars()
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, fake.calls(), "넘기기 전에 역할을 위임할 수 있는지 확인해야 합니다")
+	for _, key := range []string{"AWS_ACCESS_KEY_ID", "AWS_SECRET_ACCESS_KEY", "AWS_SESSION_TOKEN"} {
+		assert.NotContains(t, envVars, key, "만료되는 세션 자격 증명 대신 역할 프로필을 넘겨야 합니다")
+	}
+	assert.Equal(t, "terratest-abc123", envVars["AWS_PROFILE"])
+	assert.NotEqual(t, base, envVars["AWS_CONFIG_FILE"])
+
+	profile := readProfile(t, envVars)
+	assert.Contains(t, profile, "[profile dev]\nregion = ap-northeast-2\n", "기존 프로필을 유지해야 합니다")
+	assert.Contains(t, profile, "[profile terratest-abc123]\n"+
+		"role_arn = "+roleARN+"\n"+
+		"role_session_name = terratest-abc123\n"+
+		"duration_seconds = 3600\n"+
+		"external_id = k8s-obs-test\n"+
+		"credential_source = Environment\n")
+}
+
+// clearCredentialEnv 역할 프로필의 원본 자격 증명 선택에 쓰이는 환경 변수를 모두 비움
+func clearCredentialEnv(t *testing.T) {
+	t.Setenv("AWS_CONFIG_FILE", filepath.Join(t.TempDir(), "missing"))
+	t.Setenv("AWS_SHARED_CREDENTIALS_FILE", filepath.Join(t.TempDir(), "missing"))
+	for _, key := range []string{
+		"AWS_ACCESS_KEY_ID", "AWS_PROFILE", "AWS_ROLE_ARN", "AWS_WEB_IDENTITY_TOKEN_FILE", "AWS_ROLE_SESSION_NAME",
+		"AWS_CONTAINER_CREDENTIALS_RELATIVE_URI", "AWS_CONTAINER_CREDENTIALS_FULL_URI",
+	} {
+		t.Setenv(key, "")
+	}
+}
+
+func TestRoleProfileSourceProfile(t *testing.T) {
+	clearCredentialEnv(t)
+	client := roleClient(t, helpers.NewAssumeRoleConfig(roleARN, "", "source-profile"), &fakeSTS{lifetime: time.Hour})
+
+	t.Setenv("AWS_PROFILE", "dev")
+	envVars, err := client.TerraformEnvVars()
+	require.NoError(t, err)
+	profile := readProfile(t, envVars)
+	assert.Contains(t, profile, "source_profile = dev\n", "환경 변수 키가 없으면 주변 프로필에서 위임해야 합니다")
+	assert.NotContains(t, profile, "external_id")
+
+	t.Setenv("AWS_PROFILE", "")
+	credentialsFile := filepath.Join(t.TempDir(), "credentials")
+	require.NoError(t, os.WriteFile(credentialsFile, []byte("[default]\naws_access_key_id = AKIAEXAMPLE\n"), 0o600))
+	t.Setenv("AWS_SHARED_CREDENTIALS_FILE", credentialsFile)
+	envVars, err = client.TerraformEnvVars()
+	require.NoError(t, err)
+	assert.Contains(t, readProfile(t, envVars), "source_profile = default\n")
+}
+
+func TestRoleProfileWithoutSharedProfile(t *testing.T) {
+	clearCredentialEnv(t)
+	client := roleClient(t, helpers.NewAssumeRoleConfig(roleARN, "", "no-profile"), &fakeSTS{lifetime: time.Hour})
+
+	// 인스턴스 프로필 러너에는 default 프로필이 없음
+	envVars, err := client.TerraformEnvVars()
+	require.NoError(t, err)
+	profile := readProfile(t, envVars)
+	assert.Contains(t, profile, "credential_source = Ec2InstanceMetadata\n")
+	assert.NotContains(t, profile, "source_profile")
+
+	t.Setenv("AWS_CONTAINER_CREDENTIALS_RELATIVE_URI", "/v2/credentials/abc")
+	envVars, err = client.TerraformEnvVars()
+	require.NoError(t, err)
+	assert.Contains(t, readProfile(t, envVars), "credential_source = EcsContainer\n")
+
+	// 웹 ID 토큰(EKS IRSA 등)은 원본 프로필을 함께 기록해 체이닝
+	t.Setenv("AWS_ROLE_ARN", "arn:aws:iam::123456789012:role/runner")
+	t.Setenv("AWS_WEB_IDENTITY_TOKEN_FILE", "/var/run/secrets/token")
+	envVars, err = client.TerraformEnvVars()
+	require.NoError(t, err)
+	profile = readProfile(t, envVars)
+	assert.Contains(t, profile, "[profile terratest-no-profile-source]\n"+
+		"role_arn = arn:aws:iam::123456789012:role/runner\n"+
+		"web_identity_token_file = /var/run/secrets/token\n"+
+		"role_session_name = terratest-no-profile\n")
+	assert.Contains(t, profile, "source_profile = terratest-no-profile-source\n")
+	assert.NotContains(t, profile, "EcsContainer")
+}
+
+func TestRoleProfileRemovedAfterTest(t *testing.T) {
+	t.Parallel()
+
+	role := helpers.NewAssumeRoleConfig(roleARN, "", "cleanup")
+	var path string
+	t.Run("apply", func(t *testing.T) {
+		client := helpers.NewAWSTestClientWithRole(t, "ap-northeast-2", role)
+		client.Credentials = helpers.NewRoleCredentials(&fakeSTS{lifetime: time.Hour}, role)
+		envVars, err := client.TerraformEnvVars()
+		require.NoError(t, err)
+		path = envVars["AWS_CONFIG_FILE"]
+		require.FileExists(t, path)
+	})
+	assert.NoFileExists(t, path, "역할 ARN과 external_id가 담긴 프로필은 테스트가 끝나면 지워야 합니다")
+
+	client := roleClient(t, role, &fakeSTS{lifetime: time.Hour})
+	assert.NoError(t, client.RemoveRoleProfile(), "이미 지운 프로필은 무시해야 합니다")
+}
+
+func TestRefreshTerraformCredentials(t *testing.T) {
+	t.Parallel()
+
+	// 세션 수명(10분)이 최소 유효 시간(20분)보다 짧으므로 확인할 때마다 새로 위임받음
+	fake := &fakeSTS{lifetime: 10 * time.Minute}
+	client := roleClient(t, helpers.NewAssumeRoleConfig(roleARN, "", "abc123"), fake)
+
+	options := &terraform.Options{EnvVars: map[string]string{"AWS_DEFAULT_REGION": "ap-northeast-2"}}
+	require.NoError(t, client.RefreshTerraformCredentials(options))
+	first := options.EnvVars["AWS_CONFIG_FILE"]
+
+	require.NoError(t, client.RefreshTerraformCredentials(options))
+	assert.Equal(t, 2, fake.calls(), "destroy 전에도 위임할 수 있는지 다시 확인해야 합니다")
+	assert.Equal(t, first, options.EnvVars["AWS_CONFIG_FILE"], "같은 세션 이름이면 같은 프로필 파일을 다시 씁니다")
+	assert.Equal(t, "terratest-abc123", options.EnvVars["AWS_PROFILE"])
+	assert.Equal(t, "ap-northeast-2", options.EnvVars["AWS_DEFAULT_REGION"], "기존 환경 변수는 유지해야 합니다")
+}
+
+func TestRefreshTerraformCredentialsWithoutRole(t *testing.T) {
+	t.Parallel()
+
+	options := &terraform.Options{}
+	require.NoError(t, (&helpers.AWSTestClient{}).RefreshTerraformCredentials(options))
+	assert.Nil(t, options.EnvVars, "역할이 없으면 주변 자격 증명을 그대로 써야 합니다")
+}
+
+func TestRefreshTerraformCredentialsError(t *testing.T) {
+	t.Parallel()
+
+	fake := &fakeSTS{err: errors.New("AccessDenied: not authorized to perform sts:AssumeRole")}
+	client := roleClient(t, helpers.NewAssumeRoleConfig(roleARN, "", "abc123"), fake)
+
+	err := client.RefreshTerraformCredentials(&terraform.Options{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), roleARN)
+	assert.Contains(t, err.Error(), "AccessDenied")
+}
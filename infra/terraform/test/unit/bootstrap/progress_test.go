@@ -0,0 +1,163 @@
+package bootstrap
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/k8s-ec2-observability/test/helpers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// 마스터 노드 콘솔 출력 예시
+//
+// user data로 실행되는 system_settings.sh는 cloud-init 접두어가 붙고, remote-exec로 실행되는
+// combined_settings.sh는 log()가 /dev/console에 직접 기록하므로 접두어 없이 커널 메시지와 섞여 나타납니다.
+const masterConsoleOutput = `[   10.120000] cloud-init[1021]: [SYSTEM-SETUP] 2025-07-01 09:00:00 - 시스템 기본 설정 시작
+[   12.400000] cloud-init[1021]: [SYSTEM-SETUP] 2025-07-01 09:00:05 - 시스템 기본 설정 완료
+[K8S-SETUP] 2025-07-01 09:01:00 - 설치 스크립트 시작
+[K8S-SETUP] 2025-07-01 09:01:00 - 시스템 요구사항 검증 시작
+[K8S-SETUP] 2025-07-01 09:01:01 - 시스템 요구사항 검증 완료
+[K8S-SETUP] 2025-07-01 09:01:01 - 네트워크 설정 시작
+[K8S-SETUP] 2025-07-01 09:01:03 - 네트워크 설정 완료
+[K8S-SETUP] 2025-07-01 09:01:03 - Docker 및 Containerd 설치 시작
+[   75.310000] docker0: port 1(veth3a1f2c4) entered blocking state
+[K8S-SETUP] 2025-07-01 09:02:03 - Docker 및 Containerd 설치 완료
+[K8S-SETUP] 2025-07-01 09:02:03 - 쿠버네티스 설치 시작
+[K8S-SETUP] 2025-07-01 09:03:33 - 쿠버네티스 설치 완료
+[K8S-SETUP] 2025-07-01 09:03:33 - 마스터 노드 초기화 시작
+[K8S-SETUP] 2025-07-01 09:03:34 - kubeadm 구성 파일 마이그레이션 시도 중...
+[K8S-SETUP] 2025-07-01 09:05:00 - 마스터 노드 초기화 성공
+[K8S-SETUP] 2025-07-01 09:05:01 - Calico CNI 설치 시작
+[K8S-SETUP] 2025-07-01 09:05:01 - CNI 설치 롤백 시작
+[K8S-SETUP] 2025-07-01 09:05:02 - CNI 설치 롤백 완료
+[K8S-SETUP] 2025-07-01 09:06:30 - CNI POD가 정상적으로 실행되지 않았습니다
+[K8S-SETUP] 2025-07-01 09:08:00 - Calico CNI 설치 및 검증 완료
+[K8S-SETUP] 2025-07-01 09:08:01 - Join 명령어 생성 중...
+[K8S-SETUP] 2025-07-01 09:08:02 - 마스터 노드 초기화 완료
+[K8S-SETUP] 2025-07-01 09:08:02 - Join 명령어 생성 시작
+[K8S-SETUP] 2025-07-01 09:08:03 - Join 명령어 생성 완료
+[K8S-SETUP] 2025-07-01 09:08:03 - 설치 스크립트 완료
+`
+
+func TestParseBootstrapLogCompletedMaster(t *testing.T) {
+	t.Parallel()
+
+	timeline := helpers.ParseBootstrapLog(masterConsoleOutput)
+
+	assert.Nil(t, timeline.Failure, "verify_cni 재시도 메시지는 실패로 보지 않아야 합니다")
+	assert.True(t, timeline.Started)
+	assert.True(t, timeline.Completed)
+	assert.True(t, timeline.HasCompleted(helpers.MasterBootstrapSteps))
+
+	var order []helpers.BootstrapStep
+	for _, record := range timeline.Steps {
+		order = append(order, record.Step)
+	}
+	assert.Equal(t, []helpers.BootstrapStep{
+		helpers.StepSystemSettings,
+		helpers.StepVerifyRequirements,
+		helpers.StepSetupNetwork,
+		helpers.StepInstallDocker,
+		helpers.StepInstallKubernetes,
+		helpers.StepInitializeMaster,
+		helpers.StepInstallCNI,
+		helpers.StepGenerateJoin,
+	}, order)
+
+	assert.Equal(t, time.Minute, timeline.Step(helpers.StepInstallDocker).Duration())
+	assert.Equal(t, 3*time.Minute-time.Second, timeline.Step(helpers.StepInstallCNI).Duration())
+	assert.Equal(t, 4*time.Minute+29*time.Second, timeline.Step(helpers.StepInitializeMaster).Duration())
+	assert.Contains(t, timeline.String(), "install_docker")
+}
+
+func TestParseBootstrapLogInProgress(t *testing.T) {
+	t.Parallel()
+
+	partial := strings.Join(strings.Split(masterConsoleOutput, "\n")[:11], "\n")
+	timeline := helpers.ParseBootstrapLog(partial)
+
+	assert.Nil(t, timeline.Failure)
+	assert.False(t, timeline.Completed)
+	assert.Equal(t, helpers.StepCompleted, timeline.StepStatus(helpers.StepInstallDocker))
+	assert.Equal(t, helpers.StepRunning, timeline.StepStatus(helpers.StepInstallKubernetes))
+	assert.Equal(t, helpers.StepPending, timeline.StepStatus(helpers.StepInitializeMaster))
+	assert.False(t, timeline.HasCompleted(helpers.WorkerBootstrapSteps))
+}
+
+func TestParseBootstrapLogCheckErrorFailure(t *testing.T) {
+	t.Parallel()
+
+	output := `[K8S-SETUP] 2025-07-01 09:01:00 - 설치 스크립트 시작
+[K8S-SETUP] 2025-07-01 09:01:03 - Docker 및 Containerd 설치 시작
+E: Unable to locate package docker.io
+[K8S-SETUP] 2025-07-01 09:01:10 - 오류: Docker 설치 실패
+`
+	timeline := helpers.ParseBootstrapLog(output)
+
+	require.NotNil(t, timeline.Failure)
+	assert.Equal(t, helpers.StepInstallDocker, timeline.Failure.Step)
+	assert.Equal(t, "Docker 설치 실패", timeline.Failure.Message)
+	assert.Contains(t, timeline.Failure.Excerpt, "E: Unable to locate package docker.io")
+	assert.Equal(t, helpers.StepFailed, timeline.StepStatus(helpers.StepInstallDocker))
+}
+
+func TestParseBootstrapLogNestedCNIFailure(t *testing.T) {
+	t.Parallel()
+
+	output := `[K8S-SETUP] 2025-07-01 09:03:33 - 마스터 노드 초기화 시작
+[K8S-SETUP] 2025-07-01 09:05:01 - Calico CNI 설치 시작
+calico-node-abc   0/1   CrashLoopBackOff
+[K8S-SETUP] 2025-07-01 09:10:00 - 설치 실패 - 디버깅 정보 수집
+[K8S-SETUP] 2025-07-01 09:10:05 - Calico CNI 설치 실패
+[K8S-SETUP] 2025-07-01 09:10:06 - CNI 설치 실패
+`
+	timeline := helpers.ParseBootstrapLog(output)
+
+	// 근본 원인인 install_cni 실패가 먼저 보고되어야 합니다
+	require.NotNil(t, timeline.Failure)
+	assert.Equal(t, helpers.StepInstallCNI, timeline.Failure.Step)
+	assert.Contains(t, timeline.Failure.Excerpt, "calico-node-abc   0/1   CrashLoopBackOff")
+	assert.Equal(t, helpers.StepFailed, timeline.StepStatus(helpers.StepInitializeMaster))
+}
+
+func TestWaitForBootstrap(t *testing.T) {
+	t.Parallel()
+
+	lines := strings.Split(masterConsoleOutput, "\n")
+	polls := 0
+	fetch := func() (string, error) {
+		polls++
+		// 폴링할 때마다 콘솔 출력이 늘어나는 상황 재현
+		end := polls * 10
+		if end > len(lines) {
+			end = len(lines)
+		}
+		return strings.Join(lines[:end], "\n"), nil
+	}
+
+	timeline, err := helpers.WaitForBootstrap(t, fetch, helpers.MasterBootstrapSteps, 10, 0)
+	require.NoError(t, err)
+	assert.True(t, timeline.Completed)
+	assert.Equal(t, 3, polls)
+}
+
+func TestWaitForBootstrapFailsFast(t *testing.T) {
+	t.Parallel()
+
+	polls := 0
+	fetch := func() (string, error) {
+		polls++
+		return "[K8S-SETUP] 2025-07-01 09:03:33 - 마스터 노드 초기화 시작\n" +
+			"[K8S-SETUP] 2025-07-01 09:10:00 - 마스터 노드 초기화 최종 실패\n", nil
+	}
+
+	_, err := helpers.WaitForBootstrap(t, fetch, helpers.MasterBootstrapSteps, 10, 0)
+
+	var bootstrapErr *helpers.BootstrapError
+	require.True(t, errors.As(err, &bootstrapErr), "BootstrapError가 반환되어야 합니다: %v", err)
+	assert.Equal(t, helpers.StepInitializeMaster, bootstrapErr.Step)
+	assert.Equal(t, 1, polls, "실패 확인 후 재시도하지 않아야 합니다")
+}
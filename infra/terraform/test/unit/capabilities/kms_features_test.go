@@ -0,0 +1,70 @@
+package capabilities
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/service/iam"
+	"github.com/k8s-ec2-observability/test/helpers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKMSEnableAllowedFeatures(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		denied []string
+		want   helpers.KMSFeatures
+	}{
+		{
+			name: "모든 권한",
+			want: helpers.KMSFeatures{Monitoring: true, CloudTrail: true, Backup: true, AutoRecovery: true},
+		},
+		{
+			name:   "로그 그룹 권한 없음",
+			denied: []string{"logs:CreateLogGroup"},
+			want:   helpers.KMSFeatures{Monitoring: true, Backup: true, AutoRecovery: true},
+		},
+		{
+			name:   "EventBridge 권한 없음",
+			denied: []string{"events:PutTargets"},
+			want:   helpers.KMSFeatures{Monitoring: true, CloudTrail: true, Backup: true},
+		},
+		{
+			name:   "역할 생성 권한 없음",
+			denied: []string{"iam:CreateRole", "backup:CreateBackupPlan", "cloudwatch:PutMetricAlarm"},
+			want:   helpers.KMSFeatures{},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			denied := make(map[string]string)
+			for _, action := range tt.denied {
+				denied[action] = iam.PolicyEvaluationDecisionTypeImplicitDeny
+			}
+			set, err := helpers.ProbeCapabilities(&fakeSTS{arn: userARN}, &fakeIAM{denied: denied})
+			require.NoError(t, err)
+
+			config := helpers.NewKMSTestConfig()
+			config.EnableAllowedFeatures(t, set)
+			assert.Equal(t, tt.want, config.Features)
+
+			vars := config.TerraformVars()
+			assert.Equal(t, tt.want.Monitoring, vars["enable_monitoring"])
+			assert.Equal(t, tt.want.CloudTrail, vars["enable_cloudtrail"])
+			assert.Equal(t, tt.want.Backup, vars["enable_backup"])
+			assert.Equal(t, tt.want.AutoRecovery, vars["enable_auto_recovery"])
+		})
+	}
+}
+
+func TestKMSFeaturesDisabledByDefault(t *testing.T) {
+	t.Parallel()
+
+	// 권한 점검 없이 만든 설정은 추가 리소스를 만들지 않음
+	assert.Equal(t, helpers.KMSFeatures{}, helpers.NewKMSTestConfig().Features)
+}
@@ -0,0 +1,219 @@
+package capabilities
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/iam"
+	"github.com/aws/aws-sdk-go/service/iam/iamiface"
+	"github.com/aws/aws-sdk-go/service/sts"
+	"github.com/aws/aws-sdk-go/service/sts/stsiface"
+	"github.com/k8s-ec2-observability/test/helpers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	userARN    = "arn:aws:iam::123456789012:user/ci"
+	sessionARN = "arn:aws:sts::123456789012:assumed-role/Terraform-Execution-Role/terratest-abc123"
+	roleARN    = "arn:aws:iam::123456789012:role/ci/Terraform-Execution-Role"
+)
+
+type fakeSTS struct {
+	stsiface.STSAPI
+	arn string
+	err error
+}
+
+func (f *fakeSTS) GetCallerIdentity(*sts.GetCallerIdentityInput) (*sts.GetCallerIdentityOutput, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &sts.GetCallerIdentityOutput{Account: aws.String("123456789012"), Arn: aws.String(f.arn)}, nil
+}
+
+// fakeIAM denied에 없는 액션은 모두 허용하고, 한 페이지에 pageSize개씩 결과를 나눠 돌려주는 IAM
+type fakeIAM struct {
+	iamiface.IAMAPI
+	denied      map[string]string
+	roles       map[string]string
+	simulateErr error
+	pageSize    int
+	simulated   []string
+}
+
+func (f *fakeIAM) GetRole(input *iam.GetRoleInput) (*iam.GetRoleOutput, error) {
+	arn, ok := f.roles[aws.StringValue(input.RoleName)]
+	if !ok {
+		return nil, errors.New("AccessDenied: iam:GetRole")
+	}
+	return &iam.GetRoleOutput{Role: &iam.Role{Arn: aws.String(arn)}}, nil
+}
+
+func (f *fakeIAM) SimulatePrincipalPolicyPages(input *iam.SimulatePrincipalPolicyInput, fn func(*iam.SimulatePolicyResponse, bool) bool) error {
+	if f.simulateErr != nil {
+		return f.simulateErr
+	}
+	f.simulated = append(f.simulated, aws.StringValue(input.PolicySourceArn))
+	var results []*iam.EvaluationResult
+	for _, action := range aws.StringValueSlice(input.ActionNames) {
+		decision := iam.PolicyEvaluationDecisionTypeAllowed
+		if denied, ok := f.denied[action]; ok {
+			decision = denied
+		}
+		results = append(results, &iam.EvaluationResult{EvalActionName: aws.String(action), EvalDecision: aws.String(decision)})
+	}
+	size := f.pageSize
+	if size == 0 {
+		size = len(results)
+	}
+	for start := 0; start < len(results); start += size {
+		end := start + size
+		if end > len(results) {
+			end = len(results)
+		}
+		if !fn(&iam.SimulatePolicyResponse{EvaluationResults: results[start:end]}, end == len(results)) {
+			break
+		}
+	}
+	return nil
+}
+
+func TestProbeAllAllowed(t *testing.T) {
+	t.Parallel()
+
+	iamClient := &fakeIAM{pageSize: 7}
+	set, err := helpers.ProbeCapabilities(&fakeSTS{arn: userARN}, iamClient)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{userARN}, iamClient.simulated)
+	for _, capability := range helpers.Capabilities() {
+		assert.True(t, set.Has(capability), "%s 기능이 허용되어야 합니다 (페이지를 모두 읽어야 함)", capability)
+	}
+	assert.Empty(t, set.Reason(helpers.Capabilities()...))
+}
+
+func TestProbeDeniedActions(t *testing.T) {
+	t.Parallel()
+
+	set, err := helpers.ProbeCapabilities(&fakeSTS{arn: userARN}, &fakeIAM{denied: map[string]string{
+		"cloudtrail:CreateTrail": iam.PolicyEvaluationDecisionTypeImplicitDeny,
+		"iam:PassRole":           iam.PolicyEvaluationDecisionTypeExplicitDeny,
+	}})
+	require.NoError(t, err)
+
+	assert.True(t, set.Has(helpers.CapabilityCloudWatch, helpers.CapabilityLogs, helpers.CapabilityBackup, helpers.CapabilityEventBridge))
+	assert.False(t, set.Has(helpers.CapabilityCloudTrail))
+	assert.False(t, set.Has(helpers.CapabilityLambda), "Lambda 실행 역할 전달(iam:PassRole)도 필요합니다")
+	assert.False(t, set.Has(helpers.CapabilityLogs, helpers.CapabilityCloudTrail), "하나라도 없으면 거부되어야 합니다")
+
+	reason := set.Reason(helpers.CapabilityCloudTrail)
+	assert.Contains(t, reason, "cloudtrail:CreateTrail(implicitDeny)")
+	assert.Contains(t, reason, "iam:PassRole(explicitDeny)")
+	assert.Contains(t, set.Reason(helpers.CapabilityLambda), "iam:PassRole(explicitDeny)")
+	assert.NotContains(t, set.Reason(helpers.CapabilityLambda), "cloudtrail:")
+}
+
+func TestProbeAssumedRoleSession(t *testing.T) {
+	t.Parallel()
+
+	// 경로가 있는 역할은 GetRole로 실제 ARN을 찾음
+	iamClient := &fakeIAM{roles: map[string]string{"Terraform-Execution-Role": roleARN}}
+	set, err := helpers.ProbeCapabilities(&fakeSTS{arn: sessionARN}, iamClient)
+	require.NoError(t, err)
+	assert.Equal(t, sessionARN, set.CallerARN)
+	assert.Equal(t, roleARN, set.PrincipalARN)
+	assert.Equal(t, []string{roleARN}, iamClient.simulated)
+
+	// GetRole 권한이 없으면 경로 없는 역할 ARN으로 시뮬레이션
+	set, err = helpers.ProbeCapabilities(&fakeSTS{arn: sessionARN}, &fakeIAM{})
+	require.NoError(t, err)
+	assert.Equal(t, "arn:aws:iam::123456789012:role/Terraform-Execution-Role", set.PrincipalARN)
+}
+
+func TestProbeRootAccount(t *testing.T) {
+	t.Parallel()
+
+	iamClient := &fakeIAM{simulateErr: errors.New("시뮬레이션하면 안 됩니다")}
+	set, err := helpers.ProbeCapabilities(&fakeSTS{arn: "arn:aws:iam::123456789012:root"}, iamClient)
+	require.NoError(t, err)
+	assert.True(t, set.Has(helpers.Capabilities()...), "루트 계정은 모든 기능을 쓸 수 있습니다")
+}
+
+func TestProbeUnavailable(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		sts    *fakeSTS
+		iam    *fakeIAM
+		reason string
+	}{
+		{
+			name:   "시뮬레이션 권한 없음",
+			sts:    &fakeSTS{arn: userARN},
+			iam:    &fakeIAM{simulateErr: errors.New("AccessDenied: iam:SimulatePrincipalPolicy")},
+			reason: "권한 시뮬레이션 실패: AccessDenied: iam:SimulatePrincipalPolicy",
+		},
+		{
+			name:   "페더레이션 사용자",
+			sts:    &fakeSTS{arn: "arn:aws:sts::123456789012:federated-user/ci"},
+			iam:    &fakeIAM{},
+			reason: "권한을 시뮬레이션할 수 없는 주체입니다: arn:aws:sts::123456789012:federated-user/ci",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			set, err := helpers.ProbeCapabilities(tt.sts, tt.iam)
+			require.NoError(t, err, "권한을 확인할 수 없으면 실패가 아니라 건너뛰어야 합니다")
+			for _, capability := range helpers.Capabilities() {
+				assert.False(t, set.Has(capability))
+				assert.Contains(t, set.Reason(capability), tt.reason)
+			}
+		})
+	}
+}
+
+func TestProbeCallerIdentityError(t *testing.T) {
+	t.Parallel()
+
+	_, err := helpers.ProbeCapabilities(&fakeSTS{err: errors.New("ExpiredToken")}, &fakeIAM{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "ExpiredToken")
+}
+
+func TestRequireSkipsWithReason(t *testing.T) {
+	t.Parallel()
+
+	set, err := helpers.ProbeCapabilities(&fakeSTS{arn: userARN}, &fakeIAM{denied: map[string]string{
+		"backup:CreateBackupVault": iam.PolicyEvaluationDecisionTypeImplicitDeny,
+	}})
+	require.NoError(t, err)
+
+	skipped := &recordingTB{}
+	set.Require(skipped, helpers.CapabilityCloudWatch)
+	assert.Empty(t, skipped.skipped, "권한이 있으면 건너뛰지 않아야 합니다")
+
+	set.Require(skipped, helpers.CapabilityCloudWatch, helpers.CapabilityBackup)
+	require.Len(t, skipped.skipped, 1)
+	assert.True(t, strings.HasPrefix(skipped.skipped[0], userARN+" 권한 부족으로 건너뜀"), skipped.skipped[0])
+	assert.Contains(t, skipped.skipped[0], "backup: 허용되지 않은 액션 backup:CreateBackupVault(implicitDeny)")
+}
+
+// recordingTB Skip 사유만 기록하는 testing.TB
+type recordingTB struct {
+	testing.TB
+	skipped []string
+}
+
+func (r *recordingTB) Helper() {}
+
+func (r *recordingTB) Skipf(format string, args ...interface{}) {
+	r.skipped = append(r.skipped, fmt.Sprintf(format, args...))
+}
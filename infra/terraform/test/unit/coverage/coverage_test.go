@@ -0,0 +1,153 @@
+package coverage
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/k8s-ec2-observability/test/helpers/alerts"
+	"github.com/k8s-ec2-observability/test/helpers/coverage"
+	"github.com/k8s-ec2-observability/test/helpers/grafana"
+	"github.com/prometheus/prometheus/promql/parser"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	customAlertRulesPath = "../../../../../manifests/observability/custom-alert-rules.yaml"
+	clusterDashboardPath = "../../../../../manifests/grafana/k8s-cluster-dashboard.json"
+)
+
+// 아직 대시보드 패널이 없는 알림 (패널을 추가하면 목록에서 제거)
+var knownUncoveredAlerts = map[string]string{
+	"PrometheusDown":           "스크레이프 상태(up) 패널 없음",
+	"PodRestartLoop":           "컨테이너 재시작 패널 없음",
+	"LinkerdControlPlaneIssue": "Linkerd 대시보드는 linkerd-viz에서 제공",
+	"LowDiskSpace":             "파일시스템 패널 없음",
+	"BookinfoServiceDown":      "Bookinfo 대시보드 없음",
+	"BookinfoHighLatency":      "Bookinfo 대시보드 없음",
+	"PVCCreationFailed":        "PVC 패널 없음",
+	"AutoRecoveryTrigger":      "컨테이너 재시작 패널 없음",
+	"MonitoringHealthCheck":    "스크레이프 상태(up) 패널 없음",
+}
+
+func buildClusterReport(t *testing.T) *coverage.Report {
+	rule, err := alerts.LoadPrometheusRule(customAlertRulesPath)
+	require.NoError(t, err)
+	dashboard, err := grafana.LoadDashboard(clusterDashboardPath)
+	require.NoError(t, err)
+
+	report, err := coverage.BuildReport(rule, dashboard)
+	require.NoError(t, err)
+	return report
+}
+
+func TestEveryAlertHasPanel(t *testing.T) {
+	t.Parallel()
+
+	report := buildClusterReport(t)
+	t.Log("\n" + report.Markdown())
+
+	for _, alert := range report.Alerts {
+		_, known := knownUncoveredAlerts[alert.Alert]
+		switch {
+		case !alert.Covered() && !known:
+			t.Errorf("알림 %s를 보여주는 대시보드 패널이 없습니다 (신호: %s)", alert.Alert, strings.Join(alert.Signals, ", "))
+		case alert.Covered() && known:
+			t.Errorf("알림 %s에 패널이 생겼습니다. knownUncoveredAlerts에서 제거하세요", alert.Alert)
+		}
+	}
+}
+
+func TestClusterReportMatrix(t *testing.T) {
+	t.Parallel()
+
+	report := buildClusterReport(t)
+	require.Len(t, report.Panels, 8)
+
+	panelsOf := func(alert string) []int {
+		for _, coverage := range report.Alerts {
+			if coverage.Alert == alert {
+				var ids []int
+				for _, panel := range coverage.Panels {
+					ids = append(ids, panel.PanelID)
+				}
+				return ids
+			}
+		}
+		t.Fatalf("알림 %s가 없습니다", alert)
+		return nil
+	}
+
+	// 노드 선택 필터(and on(instance) ...)는 신호에서 제외되고 본 메트릭으로 매칭
+	assert.Equal(t, []int{1}, panelsOf("MasterNodeHighCPU"))
+	assert.Equal(t, []int{2}, panelsOf("MasterNodeHighMemory"))
+	// phase="Pending"은 phase="Running" 패널(#4)이 아닌 전체 phase 패널(#8)과 매칭
+	assert.Equal(t, []int{8}, panelsOf("MultiplePodsPending"))
+
+	var withoutAlerts []int
+	for _, panel := range report.PanelsWithoutAlerts() {
+		withoutAlerts = append(withoutAlerts, panel.PanelID)
+	}
+	assert.Equal(t, []int{3, 5, 7}, withoutAlerts)
+}
+
+func TestReportOutputs(t *testing.T) {
+	t.Parallel()
+
+	report := buildClusterReport(t)
+
+	data, err := report.JSON()
+	require.NoError(t, err)
+	var decoded coverage.Report
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	assert.Len(t, decoded.Alerts, len(report.Alerts))
+	assert.Equal(t, report.Panels[0].PanelRef, decoded.Panels[0].PanelRef)
+
+	markdown := report.Markdown()
+	assert.Contains(t, markdown, "| critical-system-alerts | MasterNodeHighCPU | critical | ✓ |")
+	assert.Contains(t, markdown, "## 패널이 없는 알림")
+	assert.Contains(t, markdown, "## 알림이 없는 패널")
+	assert.Contains(t, markdown, `\|`, "정규식의 |는 표 구분자와 겹치지 않도록 이스케이프")
+}
+
+func TestSignalOverlaps(t *testing.T) {
+	t.Parallel()
+
+	signal := func(query string) coverage.Signal {
+		expr, err := parser.ParseExpr(query)
+		require.NoError(t, err)
+		signals := coverage.ExtractSignals(expr)
+		require.Len(t, signals, 1, query)
+		return signals[0]
+	}
+
+	cases := []struct {
+		a, b     string
+		overlaps bool
+	}{
+		{`kube_pod_status_phase{phase="Pending"}`, `kube_pod_status_phase`, true},
+		{`kube_pod_status_phase{phase="Pending"}`, `kube_pod_status_phase{phase="Running"}`, false},
+		{`kube_pod_status_phase{phase="Pending"}`, `kube_pod_status_phase{phase=~"Pending|Failed"}`, true},
+		{`kube_pod_status_phase{phase="Pending"}`, `kube_pod_status_phase{phase!="Pending"}`, false},
+		{`up{job=~".*prometheus.*"}`, `up{job!~"linkerd.*"}`, true},
+		{`up`, `kube_pod_info`, false},
+		{`{__name__="kube_pod_info"}`, `kube_pod_info{namespace="grafana_variable"}`, true},
+	}
+	for _, c := range cases {
+		assert.Equal(t, c.overlaps, signal(c.a).Overlaps(signal(c.b)), "%s / %s", c.a, c.b)
+	}
+}
+
+func TestExtractSignalsSkipsFilters(t *testing.T) {
+	t.Parallel()
+
+	expr, err := parser.ParseExpr(`count(kube_pod_info and on(node) (kube_node_info unless on(node) kube_node_role{role="control-plane"})) or vector(0) > up`)
+	require.NoError(t, err)
+
+	var metrics []string
+	for _, signal := range coverage.ExtractSignals(expr) {
+		metrics = append(metrics, signal.Metric)
+	}
+	assert.Equal(t, []string{"kube_pod_info", "up"}, metrics)
+}
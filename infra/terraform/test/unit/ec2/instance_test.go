@@ -10,7 +10,13 @@ import (
 	"github.com/stretchr/testify/assert"
 )
 
+// TestEC2InstanceCreation ec2-master 모듈 단독 apply 테스트
+//
+// 필수 변수 private_key_path, scripts_bucket, security_group_id를 넘기지 않고, 모듈이 단독 루트일 때
+// ${path.root}/../../scripts가 infra/terraform/scripts를 가리켜 부트스트랩 스크립트도 찾지 못하므로
+// 건너뜁니다. 마스터 노드 검증은 integration/rootstack에서 합니다.
 func TestEC2InstanceCreation(t *testing.T) {
+	t.Skip(helpers.StandaloneEC2SkipReason)
 	t.Parallel()
 
 	t.Logf("🖥️ EC2 인스턴스 생성 테스트 시작...")
@@ -44,11 +50,11 @@ func TestEC2InstanceCreation(t *testing.T) {
 	}
 
 	terraformOptions := helpers.SetupTerraform(t, tfConfig)
-	defer terraform.Destroy(t, terraformOptions)
+	defer helpers.DestroyTerraform(t, terraformOptions)
 
 	// 리소스 생성
 	t.Logf("🚀 EC2 Master 노드 생성 중...")
-	terraform.InitAndApply(t, terraformOptions)
+	helpers.ApplyTerraform(t, terraformOptions)
 
 	// 인스턴스 ID 가져오기
 	instanceID := terraform.Output(t, terraformOptions, "instance_id")
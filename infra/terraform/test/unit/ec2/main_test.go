@@ -0,0 +1,13 @@
+package ec2
+
+import (
+	"os"
+	"testing"
+
+	"github.com/k8s-ec2-observability/test/helpers"
+)
+
+// 중단 신호나 panic에도 apply한 리소스를 정리하고, --teardown-from-journal로 남은 리소스를 정리
+func TestMain(m *testing.M) {
+	os.Exit(helpers.RunTests(m))
+}
@@ -0,0 +1,107 @@
+package fixture
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// runChild 현재 테스트 바이너리를 하위 프로세스로 실행해 지정한 테스트만 돌림
+func runChild(t *testing.T, test string, env ...string) (*exec.Cmd, string) {
+	t.Helper()
+	executable, err := os.Executable()
+	require.NoError(t, err)
+	dir := t.TempDir()
+	cmd := exec.Command(executable, "-test.run=^"+test+"$", "-test.v")
+	cmd.Env = append(append(os.Environ(), childEnv+"="+dir), env...)
+	return cmd, dir
+}
+
+func events(t *testing.T, dir string) []string {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join(dir, "events"))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	require.NoError(t, err)
+	return strings.Fields(string(data))
+}
+
+func exitCode(t *testing.T, err error) int {
+	t.Helper()
+	var exitErr *exec.ExitError
+	require.ErrorAs(t, err, &exitErr, "하위 프로세스가 실패로 끝나야 합니다")
+	return exitErr.ExitCode()
+}
+
+func TestTeardownOnPanic(t *testing.T) {
+	t.Parallel()
+
+	cmd, dir := runChild(t, "TestChildPanics")
+	output, err := cmd.CombinedOutput()
+	assert.NotZero(t, exitCode(t, err))
+	assert.Contains(t, string(output), "의도한 panic")
+	assert.Equal(t, []string{"setup", "teardown"}, events(t, dir), "panic으로 끝나도 픽스처를 정리해야 합니다")
+}
+
+func TestTeardownOnInterrupt(t *testing.T) {
+	t.Parallel()
+
+	cmd, dir := runChild(t, "TestChildBlocks")
+	require.NoError(t, cmd.Start())
+
+	// 픽스처를 잡은 뒤에 신호를 보냄
+	require.Eventually(t, func() bool {
+		return len(events(t, dir)) > 0
+	}, 30*time.Second, 50*time.Millisecond)
+	require.NoError(t, cmd.Process.Signal(os.Interrupt))
+
+	assert.Equal(t, 1, exitCode(t, cmd.Wait()))
+	assert.Equal(t, []string{"setup", "teardown"}, events(t, dir), "중단 신호를 받으면 픽스처를 정리해야 합니다")
+}
+
+func TestSetupFailureIsRemembered(t *testing.T) {
+	t.Parallel()
+
+	cmd, dir := runChild(t, "TestChildAcquiresTwice", failSetupEnv+"=1")
+	output, err := cmd.CombinedOutput()
+	assert.NotZero(t, exitCode(t, err))
+	assert.Equal(t, 2, strings.Count(string(output), "카운터 픽스처 준비 실패: 의도한 준비 실패"), "두 테스트 모두 같은 오류로 실패해야 합니다")
+	assert.Equal(t, []string{"setup"}, events(t, dir), "실패한 준비는 다시 시도하지 않아야 합니다")
+}
+
+// 아래는 하위 프로세스에서만 실행되는 테스트
+
+func skipUnlessChild(t *testing.T) {
+	if os.Getenv(childEnv) == "" {
+		t.Skip("하위 프로세스 전용")
+	}
+}
+
+func TestChildPanics(t *testing.T) {
+	skipUnlessChild(t)
+	shared.Acquire(t)
+	panic("의도한 panic")
+}
+
+func TestChildBlocks(t *testing.T) {
+	skipUnlessChild(t)
+	shared.Acquire(t)
+	time.Sleep(time.Minute)
+}
+
+func TestChildAcquiresTwice(t *testing.T) {
+	skipUnlessChild(t)
+	for _, name := range []string{"first", "second"} {
+		t.Run(name, func(t *testing.T) {
+			shared.Acquire(t)
+		})
+	}
+}
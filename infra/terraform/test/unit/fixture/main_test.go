@@ -0,0 +1,65 @@
+package fixture
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+
+	"github.com/k8s-ec2-observability/test/helpers"
+)
+
+const (
+	// childEnv 하위 프로세스로 실행할 때 기록 파일을 둘 디렉터리
+	childEnv = "FIXTURE_CHILD_DIR"
+	// failSetupEnv 설정되면 픽스처 준비를 실패시킴
+	failSetupEnv = "FIXTURE_FAIL_SETUP"
+)
+
+// resource 픽스처가 만드는 가짜 리소스
+type resource struct {
+	ID int64
+}
+
+var setups, teardowns atomic.Int64
+
+var shared = helpers.NewSharedFixture("카운터",
+	func(t helpers.TestingT) (*resource, error) {
+		id := setups.Add(1)
+		record("setup")
+		if os.Getenv(failSetupEnv) != "" {
+			t.Fatalf("의도한 준비 실패")
+		}
+		return &resource{ID: id}, nil
+	},
+	func(t helpers.TestingT, r *resource) error {
+		teardowns.Add(1)
+		record("teardown")
+		return nil
+	},
+)
+
+func TestMain(m *testing.M) {
+	code := shared.RunTests(m)
+	// 같은 프로세스에서 실행된 테스트가 모두 같은 리소스를 쓰고 끝에서 한 번만 정리했는지 확인
+	if os.Getenv(childEnv) == "" && (setups.Load() > 1 || setups.Load() != teardowns.Load()) {
+		fmt.Fprintf(os.Stderr, "픽스처 준비 %d회, 정리 %d회 (각각 최대 1회, 같은 횟수여야 함)\n", setups.Load(), teardowns.Load())
+		code = 1
+	}
+	os.Exit(code)
+}
+
+// record 하위 프로세스에서는 준비/정리 이벤트를 파일에 기록
+func record(event string) {
+	dir := os.Getenv(childEnv)
+	if dir == "" {
+		return
+	}
+	file, err := os.OpenFile(filepath.Join(dir, "events"), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		panic(err)
+	}
+	defer file.Close()
+	fmt.Fprintln(file, event)
+}
@@ -0,0 +1,27 @@
+package fixture
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSharedAcrossTests(t *testing.T) {
+	t.Parallel()
+
+	for _, name := range []string{"first", "second", "third"} {
+		name := name
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			r := shared.Acquire(t)
+			assert.Equal(t, int64(1), r.ID, "모든 테스트가 처음 만든 리소스를 같이 써야 합니다")
+		})
+	}
+}
+
+func TestSharedInSequentialTest(t *testing.T) {
+	// 병렬 테스트보다 먼저 끝나 참조를 반납해도 TestMain의 참조가 남아 있어 병렬 테스트는 같은 리소스를 받음
+	r := shared.Acquire(t)
+	assert.Equal(t, int64(1), r.ID)
+	assert.Equal(t, int64(0), teardowns.Load(), "TestMain이 끝나기 전에는 정리하지 않아야 합니다")
+}
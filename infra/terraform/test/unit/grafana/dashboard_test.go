@@ -0,0 +1,202 @@
+package grafana
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/k8s-ec2-observability/test/helpers/grafana"
+	"github.com/prometheus/prometheus/promql/parser"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	clusterDashboardPath = "../../../../../manifests/grafana/k8s-cluster-dashboard.json"
+	stackValuesPath      = "../../../../../manifests/observability/prometheus-stack-values.yml"
+)
+
+func TestClusterDashboardModel(t *testing.T) {
+	t.Parallel()
+
+	dashboard, err := grafana.LoadDashboard(clusterDashboardPath)
+	require.NoError(t, err)
+
+	assert.Equal(t, "k8s-ec2-observability-step8", dashboard.UID)
+	require.Len(t, dashboard.AllPanels(), 8)
+	for _, panel := range dashboard.AllPanels() {
+		require.NotNil(t, panel.Datasource, panel.Title)
+		assert.Equal(t, "Prometheus", panel.Datasource.Name, panel.Title)
+		assert.NotEmpty(t, panel.Targets, panel.Title)
+	}
+	assert.Len(t, dashboard.Queries(), 9, "네트워크 I/O 패널은 송신/수신 두 쿼리를 가집니다")
+}
+
+func TestClusterDashboardValidate(t *testing.T) {
+	t.Parallel()
+
+	dashboard, err := grafana.LoadDashboard(clusterDashboardPath)
+	require.NoError(t, err)
+	sources, err := grafana.MetricSourcesFromValues(stackValuesPath)
+	require.NoError(t, err)
+
+	findings := grafana.Validate(dashboard, grafana.DefaultValidateOptions(sources))
+	for _, finding := range findings {
+		t.Log(finding)
+	}
+	assert.Empty(t, grafana.Errors(findings))
+}
+
+func TestMetricSourcesFromValues(t *testing.T) {
+	t.Parallel()
+
+	sources, err := grafana.MetricSourcesFromValues(stackValuesPath)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"node-exporter", "kube-state-metrics", "prometheus", "alertmanager"}, sources.Names())
+
+	source, ok := sources.SourceOf("kube_pod_info")
+	assert.True(t, ok)
+	assert.Equal(t, "kube-state-metrics", source)
+	source, ok = sources.SourceOf("up")
+	assert.True(t, ok)
+	assert.Equal(t, "prometheus", source)
+	_, ok = sources.SourceOf("container_cpu_usage_seconds_total")
+	assert.False(t, ok, "cAdvisor 메트릭은 values에서 활성화한 소스가 아닙니다")
+
+	disabled := filepath.Join(t.TempDir(), "values.yml")
+	require.NoError(t, os.WriteFile(disabled, []byte("nodeExporter:\n  enabled: false\nprometheus:\n  serviceMonitor:\n    selfMonitor: false\n"), 0644))
+	sources, err = grafana.MetricSourcesFromValues(disabled)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"kube-state-metrics", "alertmanager"}, sources.Names())
+}
+
+func TestValidateDetectsProblems(t *testing.T) {
+	t.Parallel()
+
+	dashboard, err := grafana.ParseDashboard([]byte(`{
+  "dashboard": {
+    "uid": "broken",
+    "title": "broken",
+    "templating": {
+      "list": [
+        {"name": "node", "type": "query", "datasource": {"type": "prometheus", "uid": "prometheus"},
+         "query": {"query": "label_values(kube_node_info, node)"}},
+        {"name": "pod", "type": "query", "datasource": "Prometheus", "query": "label_values(container_last_seen, pod)"},
+        {"name": "ds", "type": "datasource", "query": "prometheus"}
+      ]
+    },
+    "panels": [
+      {"id": 1, "title": "cpu", "type": "timeseries", "datasource": "$ds",
+       "targets": [{"refId": "A", "expr": "rate(node_cpu_seconds_total{instance=~\"$node\"}[$__rate_interval])"}]},
+      {"id": 1, "title": "broken", "type": "timeseries", "datasource": "Loki",
+       "targets": [
+         {"refId": "A", "expr": "sum(rate(node_load1[5m])"},
+         {"refId": "A", "expr": ""},
+         {"refId": "B", "expr": "up{namespace=\"$namespace\"}"}
+       ]},
+      {"id": 3, "title": "row", "type": "row", "panels": [
+        {"id": 4, "title": "cadvisor", "type": "stat", "datasource": {"type": "prometheus", "uid": "thanos"},
+         "targets": [{"refId": "A", "expr": "sum by (pod) (rate(container_cpu_usage_seconds_total[5m]))"}]}
+      ]}
+    ]
+  }
+}`))
+	require.NoError(t, err)
+	require.Len(t, dashboard.AllPanels(), 4)
+
+	sources, err := grafana.MetricSourcesFromValues(stackValuesPath)
+	require.NoError(t, err)
+	findings := grafana.Validate(dashboard, grafana.DefaultValidateOptions(sources))
+	for _, finding := range findings {
+		t.Log(finding)
+	}
+
+	expected := []struct {
+		panel string
+		refID string
+		check string
+	}{
+		{"broken", "", grafana.CheckDuplicatePanelID},
+		{"broken", "", grafana.CheckDatasource},
+		{"broken", "A", grafana.CheckPromQLParse},
+		{"broken", "A", grafana.CheckDuplicateRefID},
+		{"broken", "A", grafana.CheckEmptyQuery},
+		{"broken", "B", grafana.CheckUndefinedVariable},
+		{"cadvisor", "", grafana.CheckDatasource},
+		{"cadvisor", "A", grafana.CheckUnknownMetric},
+		{"$pod", "", grafana.CheckUnknownMetric},
+	}
+	for _, want := range expected {
+		assert.True(t, hasFinding(findings, want.panel, want.refID, want.check), "%s/%s %s 검출 필요", want.panel, want.refID, want.check)
+	}
+	for _, finding := range findings {
+		assert.NotEqual(t, "cpu", finding.Panel, "변수와 $__rate_interval을 쓰는 정상 쿼리: %s", finding)
+		assert.NotEqual(t, "$node", finding.Panel, "정상 변수 쿼리: %s", finding)
+	}
+}
+
+func TestHardcodedNodeMatchers(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		expr string
+		want []string
+	}{
+		{`node_load1{instance=~".*ip-10-0-1-34.*"}`, []string{`instance=~".*ip-10-0-1-34.*"`}},
+		{`node_load1{instance="10.0.1.34:9100"}`, []string{`instance="10.0.1.34:9100"`}},
+		{`node_load1{instance=~"10\\.0\\.1\\.34:.*"}`, []string{`instance=~"10\\.0\\.1\\.34:.*"`}},
+		{`kube_node_status_condition{node="ip-10-0-2-15",condition="Ready"}`, []string{`node="ip-10-0-2-15"`}},
+		{`node_load1{instance=~"grafana_variable"}`, nil},
+		{`node_load1 and on(instance) (node_uname_info and on(nodename) label_replace(kube_node_role{role=~"control-plane|master"}, "nodename", "$1", "node", "(.*)"))`, nil},
+		{`rate(prometheus_http_requests_total{handler="/api/v1/query"}[5m])`, nil},
+	}
+	for _, tt := range tests {
+		expr, err := parser.ParseExpr(tt.expr)
+		require.NoError(t, err, tt.expr)
+		assert.Equal(t, tt.want, grafana.HardcodedNodeMatchers(expr), tt.expr)
+	}
+}
+
+func TestValidateDetectsHardcodedNode(t *testing.T) {
+	t.Parallel()
+
+	dashboard, err := grafana.ParseDashboard([]byte(`{
+  "uid": "hardcoded",
+  "title": "hardcoded",
+  "templating": {
+    "list": [
+      {"name": "instance", "type": "query", "datasource": "Prometheus", "query": "label_values(node_uname_info, instance)"}
+    ]
+  },
+  "panels": [
+    {"id": 1, "title": "master cpu", "type": "gauge", "datasource": "Prometheus",
+     "targets": [{"refId": "A", "expr": "node_load1{instance=~\".*ip-10-0-1-34.*\"}"}]},
+    {"id": 2, "title": "selected cpu", "type": "gauge", "datasource": "Prometheus",
+     "targets": [{"refId": "A", "expr": "node_load1{instance=~\"$instance\"}"}]}
+  ]
+}`))
+	require.NoError(t, err)
+	sources, err := grafana.MetricSourcesFromValues(stackValuesPath)
+	require.NoError(t, err)
+
+	findings := grafana.Validate(dashboard, grafana.DefaultValidateOptions(sources))
+	assert.True(t, hasFinding(findings, "master cpu", "A", grafana.CheckHardcodedNode))
+	assert.False(t, hasFinding(findings, "selected cpu", "A", grafana.CheckHardcodedNode), "변수로 선택한 노드는 허용해야 합니다")
+}
+
+func TestMetricNames(t *testing.T) {
+	t.Parallel()
+
+	expr, err := parser.ParseExpr(`count(kube_pod_info) / count({__name__="kube_node_info"}) + on() group_left sum(rate(node_cpu_seconds_total[5m])) + count(kube_pod_info)`)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"kube_node_info", "kube_pod_info", "node_cpu_seconds_total"}, grafana.MetricNames(expr))
+}
+
+func hasFinding(findings []grafana.Finding, panel, refID, check string) bool {
+	for _, finding := range findings {
+		if finding.Panel == panel && finding.RefID == refID && finding.Check == check {
+			return true
+		}
+	}
+	return false
+}
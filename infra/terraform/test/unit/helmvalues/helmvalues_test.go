@@ -0,0 +1,119 @@
+package helmvalues
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/k8s-ec2-observability/test/helpers/allowlist"
+	"github.com/k8s-ec2-observability/test/helpers/helmvalues"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const stackValuesPath = "../../../../../manifests/observability/prometheus-stack-values.yml"
+
+// 현재 values 파일의 알려진 문제 (수정하면 목록에서 제거)
+var knownFindings = map[string]string{
+	"insecure-default grafana.adminPassword":               "데모 환경용 차트 기본 비밀번호",
+	"no-persistence prometheus.prometheusSpec.storageSpec": "EBS CSI 드라이버 도입 전까지 emptyDir 사용",
+	"no-persistence alertmanager.alertmanagerSpec.storage": "EBS CSI 드라이버 도입 전까지 emptyDir 사용",
+	"no-persistence grafana.persistence.enabled":           "대시보드는 ConfigMap으로 프로비저닝",
+}
+
+func TestStackValuesSchema(t *testing.T) {
+	t.Parallel()
+
+	findings, err := helmvalues.ValidateFile(stackValuesPath, helmvalues.KubePrometheusStackSchema())
+	require.NoError(t, err)
+
+	found := make(map[string]string)
+	for _, finding := range findings {
+		t.Log(finding)
+		found[fmt.Sprintf("%s %s", finding.Check, finding.Key)] = finding.String()
+	}
+	allowlist.Check(t, "knownFindings", knownFindings, found)
+}
+
+func TestValidateReportsLines(t *testing.T) {
+	t.Parallel()
+
+	findings := helmvalues.Validate("values.yml", []byte(`prometheus:
+  prometheusSpec:
+    serviceMonitorSelectorNilUsesHelmValue: false
+    retention: 15days
+    replicas: "2"
+    storageSpec:
+      volumeClaimTemplate: {}
+grafana:
+  adminPassword: s3cret
+  persistence:
+    enabled: true
+  service:
+    type: NodePrt
+    nodePort: 8080
+alertmanager:
+  alertmanagerSpec:
+    storage:
+      volumeClaimTemplate: {}
+promethues: {}
+`), helmvalues.KubePrometheusStackSchema())
+	for _, finding := range findings {
+		t.Log(finding)
+	}
+
+	type result struct {
+		line  int
+		check string
+	}
+	byKey := make(map[string]result)
+	for _, finding := range findings {
+		byKey[finding.Key] = result{finding.Line, finding.Check}
+	}
+
+	assert.Equal(t, result{3, helmvalues.CheckUnknownKey}, byKey["prometheus.prometheusSpec.serviceMonitorSelectorNilUsesHelmValue"])
+	assert.Equal(t, result{4, helmvalues.CheckPattern}, byKey["prometheus.prometheusSpec.retention"])
+	assert.Equal(t, result{5, helmvalues.CheckType}, byKey["prometheus.prometheusSpec.replicas"])
+	assert.Equal(t, result{9, helmvalues.CheckInsecureDefault}, byKey["grafana.adminPassword"])
+	assert.Equal(t, result{13, helmvalues.CheckEnum}, byKey["grafana.service.type"])
+	assert.Equal(t, result{14, helmvalues.CheckNodePortRange}, byKey["grafana.service.nodePort"])
+	assert.Equal(t, result{19, helmvalues.CheckUnknownKey}, byKey["promethues"])
+	assert.Len(t, findings, 7, "스토리지와 영속성을 설정하면 no-persistence 없음")
+
+	for _, finding := range findings {
+		if finding.Key == "promethues" {
+			assert.Contains(t, finding.Message, "prometheus의 오타")
+		}
+		if finding.Key == "prometheus.prometheusSpec.serviceMonitorSelectorNilUsesHelmValue" {
+			assert.Contains(t, finding.Message, "serviceMonitorSelectorNilUsesHelmValues의 오타")
+		}
+	}
+}
+
+func TestExistingSecretSuppressesPasswordFinding(t *testing.T) {
+	t.Parallel()
+
+	findings := helmvalues.Validate("values.yml", []byte(`grafana:
+  adminPassword: ""
+  admin:
+    existingSecret: grafana-admin
+`), helmvalues.KubePrometheusStackSchema())
+	for _, finding := range findings {
+		assert.NotEqual(t, helmvalues.CheckInsecureDefault, finding.Check, finding.String())
+	}
+}
+
+func TestValidateParseError(t *testing.T) {
+	t.Parallel()
+
+	findings := helmvalues.Validate("values.yml", []byte("grafana:\n  service: [\n"), helmvalues.KubePrometheusStackSchema())
+	require.Len(t, findings, 1)
+	assert.Equal(t, helmvalues.CheckParse, findings[0].Check)
+	assert.NotZero(t, findings[0].Line)
+}
+
+func TestParseSchemaRejectsUnknownRef(t *testing.T) {
+	t.Parallel()
+
+	_, err := helmvalues.ParseSchema([]byte(`{"type": "object", "properties": {"a": {"$ref": "#/definitions/missing"}}}`))
+	assert.Error(t, err)
+}
@@ -0,0 +1,206 @@
+package iam
+
+import (
+	"testing"
+
+	"github.com/k8s-ec2-observability/test/helpers/iampolicy"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func parse(t *testing.T, name, document string) *iampolicy.Document {
+	t.Helper()
+	parsed, err := iampolicy.Parse(name, []byte(document))
+	require.NoError(t, err)
+	return parsed
+}
+
+func TestEvaluateDecisions(t *testing.T) {
+	t.Parallel()
+
+	identity := parse(t, "identity", `{
+  "Version": "2012-10-17",
+  "Statement": [
+    {"Sid": "Logs", "Effect": "Allow", "Action": "logs:*", "Resource": "arn:aws:logs:ap-northeast-2:123456789012:log-group:/k8s/*"},
+    {"Sid": "DescribeEC2", "Effect": "Allow", "Action": ["ec2:Describe*"], "Resource": "*"},
+    {"Sid": "KMSRead", "Effect": "Allow", "Action": "kms:?escribeKey", "Resource": "*"},
+    {"Sid": "AllButIAM", "Effect": "Allow", "NotAction": ["iam:*", "organizations:*"], "Resource": "arn:aws:s3:::k8s-*"},
+    {"Sid": "NoProdDelete", "Effect": "Deny", "Action": "logs:DeleteLogGroup", "Resource": "*", "Condition": {"StringEquals": {"aws:ResourceTag/Environment": "prod"}}},
+    {"Sid": "NotOthers", "Effect": "Deny", "Action": "s3:*", "NotResource": ["arn:aws:s3:::k8s-*", "arn:aws:s3:::k8s-*/*"]}
+  ]
+}`)
+
+	tests := []struct {
+		name     string
+		request  iampolicy.Request
+		decision iampolicy.Decision
+		sid      string
+	}{
+		{"와일드카드 리소스", iampolicy.Request{Action: "logs:CreateLogStream", Resource: "arn:aws:logs:ap-northeast-2:123456789012:log-group:/k8s/api"}, iampolicy.DecisionAllow, "Logs"},
+		{"리소스 불일치", iampolicy.Request{Action: "logs:CreateLogStream", Resource: "arn:aws:logs:ap-northeast-2:123456789012:log-group:/other"}, iampolicy.DecisionImplicitDeny, ""},
+		{"작업 이름은 대소문자 무시", iampolicy.Request{Action: "EC2:describeinstances", Resource: "*"}, iampolicy.DecisionAllow, "DescribeEC2"},
+		{"물음표 와일드카드", iampolicy.Request{Action: "kms:DescribeKey", Resource: "arn:aws:kms:ap-northeast-2:123456789012:key/1"}, iampolicy.DecisionAllow, "KMSRead"},
+		{"NotAction 허용", iampolicy.Request{Action: "s3:PutObject", Resource: "arn:aws:s3:::k8s-scripts"}, iampolicy.DecisionAllow, "AllButIAM"},
+		{"NotAction 제외", iampolicy.Request{Action: "iam:CreateUser", Resource: "arn:aws:s3:::k8s-scripts"}, iampolicy.DecisionImplicitDeny, ""},
+		{"NotResource 명시적 거부", iampolicy.Request{Action: "s3:GetObject", Resource: "arn:aws:s3:::other-bucket/key"}, iampolicy.DecisionExplicitDeny, "NotOthers"},
+		{
+			"조건이 맞으면 Deny 우선",
+			iampolicy.Request{Action: "logs:DeleteLogGroup", Resource: "arn:aws:logs:ap-northeast-2:123456789012:log-group:/k8s/api", Context: map[string][]string{"aws:resourcetag/environment": {"prod"}}},
+			iampolicy.DecisionExplicitDeny, "NoProdDelete",
+		},
+		{
+			"조건이 안 맞으면 Allow",
+			iampolicy.Request{Action: "logs:DeleteLogGroup", Resource: "arn:aws:logs:ap-northeast-2:123456789012:log-group:/k8s/api", Context: map[string][]string{"aws:ResourceTag/Environment": {"dev"}}},
+			iampolicy.DecisionAllow, "Logs",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			result, err := iampolicy.Evaluate(tt.request, identity)
+			require.NoError(t, err)
+			assert.Equal(t, tt.decision, result.Decision)
+			if tt.sid != "" {
+				require.NotEmpty(t, result.Matches)
+				assert.Equal(t, tt.sid, result.Matches[0].Statement.Sid)
+			}
+		})
+	}
+}
+
+func TestEvaluateConditions(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		condition string
+		context   map[string][]string
+		allowed   bool
+	}{
+		{"StringLike", `{"StringLike": {"aws:PrincipalArn": "arn:aws:iam::*:role/ci-*"}}`, map[string][]string{"aws:PrincipalArn": {"arn:aws:iam::123456789012:role/ci-runner"}}, true},
+		{"값 목록은 OR", `{"StringEquals": {"aws:RequestedRegion": ["us-east-1", "ap-northeast-2"]}}`, map[string][]string{"aws:RequestedRegion": {"ap-northeast-2"}}, true},
+		{"키 없으면 긍정 연산자는 거짓", `{"StringEquals": {"sts:ExternalId": "k8s"}}`, nil, false},
+		{"키 없으면 부정 연산자는 참", `{"StringNotEquals": {"aws:RequestedRegion": "us-east-1"}}`, nil, true},
+		{"IfExists는 키가 없으면 참", `{"StringEqualsIfExists": {"aws:RequestedRegion": "ap-northeast-2"}}`, nil, true},
+		{"IfExists도 키가 있으면 비교", `{"StringEqualsIfExists": {"aws:RequestedRegion": "ap-northeast-2"}}`, map[string][]string{"aws:RequestedRegion": {"us-east-1"}}, false},
+		{"Bool", `{"Bool": {"aws:SecureTransport": true}}`, map[string][]string{"aws:SecureTransport": {"true"}}, true},
+		{"IpAddress", `{"IpAddress": {"aws:SourceIp": ["10.0.0.0/16"]}}`, map[string][]string{"aws:SourceIp": {"10.0.1.34"}}, true},
+		{"NotIpAddress", `{"NotIpAddress": {"aws:SourceIp": "10.0.0.0/16"}}`, map[string][]string{"aws:SourceIp": {"10.0.1.34"}}, false},
+		{"NumericLessThanEquals", `{"NumericLessThanEquals": {"aws:MultiFactorAuthAge": 3600}}`, map[string][]string{"aws:MultiFactorAuthAge": {"120"}}, true},
+		{"DateLessThan", `{"DateLessThan": {"aws:CurrentTime": "2030-01-01T00:00:00Z"}}`, map[string][]string{"aws:CurrentTime": {"2026-10-19T00:00:00Z"}}, true},
+		{"Null true는 키가 없어야", `{"Null": {"aws:RequestTag/Project": "true"}}`, nil, true},
+		{"Null false는 키가 있어야", `{"Null": {"aws:RequestTag/Project": "false"}}`, nil, false},
+		{"ForAllValues는 모든 값이 포함", `{"ForAllValues:StringEquals": {"aws:TagKeys": ["Project", "Environment"]}}`, map[string][]string{"aws:TagKeys": {"Project", "Owner"}}, false},
+		{"ForAllValues는 키가 없으면 참", `{"ForAllValues:StringEquals": {"aws:TagKeys": ["Project"]}}`, nil, true},
+		{"ForAnyValue는 하나만 포함", `{"ForAnyValue:StringEquals": {"aws:TagKeys": ["Project"]}}`, map[string][]string{"aws:TagKeys": {"Project", "Owner"}}, true},
+		{"연산자끼리는 AND", `{"StringEquals": {"aws:RequestedRegion": "ap-northeast-2"}, "Bool": {"aws:SecureTransport": "true"}}`, map[string][]string{"aws:RequestedRegion": {"ap-northeast-2"}, "aws:SecureTransport": {"false"}}, false},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			document := parse(t, tt.name, `{"Version": "2012-10-17", "Statement": {"Effect": "Allow", "Action": "ec2:RunInstances", "Resource": "*", "Condition": `+tt.condition+`}}`)
+			result, err := iampolicy.Evaluate(iampolicy.Request{Action: "ec2:RunInstances", Resource: "*", Context: tt.context}, document)
+			require.NoError(t, err)
+			assert.Equal(t, tt.allowed, result.Allowed())
+		})
+	}
+}
+
+func TestEvaluateUnsupportedCondition(t *testing.T) {
+	t.Parallel()
+
+	document := parse(t, "unsupported", `{"Statement": {"Effect": "Allow", "Action": "*", "Resource": "*", "Condition": {"StringMatches": {"aws:UserAgent": "x"}}}}`)
+	_, err := iampolicy.Evaluate(iampolicy.Request{Action: "s3:GetObject", Resource: "*"}, document)
+	assert.EqualError(t, err, "지원하지 않는 조건 연산자: StringMatches")
+}
+
+func TestEvaluateTrustPrincipals(t *testing.T) {
+	t.Parallel()
+
+	trust := parse(t, "trust", `{
+  "Version": "2012-10-17",
+  "Statement": [
+    {"Effect": "Allow", "Action": "sts:AssumeRole", "Principal": {"AWS": ["arn:aws:iam::123456789012:user/ci", "210987654321"]}, "Condition": {"StringEquals": {"sts:ExternalId": "k8s-ec2"}}},
+    {"Effect": "Allow", "Action": "sts:AssumeRole", "Principal": {"Service": "ec2.amazonaws.com"}}
+  ]
+}`)
+	externalID := map[string][]string{"sts:ExternalId": {"k8s-ec2"}}
+
+	tests := []struct {
+		name      string
+		principal iampolicy.Principal
+		context   map[string][]string
+		allowed   bool
+	}{
+		{"신뢰하는 사용자", iampolicy.Principal{Type: "AWS", ID: "arn:aws:iam::123456789012:user/ci"}, externalID, true},
+		{"외부 ID 없음", iampolicy.Principal{Type: "AWS", ID: "arn:aws:iam::123456789012:user/ci"}, nil, false},
+		{"같은 계정의 다른 사용자", iampolicy.Principal{Type: "AWS", ID: "arn:aws:iam::123456789012:user/dev"}, externalID, false},
+		{"계정 ID로 위임한 계정의 역할", iampolicy.Principal{Type: "AWS", ID: "arn:aws:iam::210987654321:role/deployer"}, externalID, true},
+		{"서비스 주체", iampolicy.Principal{Type: "Service", ID: "ec2.amazonaws.com"}, nil, true},
+		{"다른 서비스 주체", iampolicy.Principal{Type: "Service", ID: "lambda.amazonaws.com"}, nil, false},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			principal := tt.principal
+			result, err := iampolicy.Evaluate(iampolicy.Request{Principal: &principal, Action: "sts:AssumeRole", Context: tt.context}, trust)
+			require.NoError(t, err)
+			assert.Equal(t, tt.allowed, result.Allowed())
+		})
+	}
+}
+
+func TestParseRejectsInvalidDocuments(t *testing.T) {
+	t.Parallel()
+
+	_, err := iampolicy.Parse("bad-effect", []byte(`{"Statement": [{"Effect": "Permit", "Action": "*"}]}`))
+	assert.ErrorContains(t, err, "Effect가 Allow/Deny가 아닙니다")
+
+	_, err = iampolicy.Parse("both", []byte(`{"Statement": [{"Effect": "Allow", "Action": "*", "NotAction": "iam:*"}]}`))
+	assert.ErrorContains(t, err, "Action과 NotAction 중 하나만")
+
+	document, err := iampolicy.ParseURLEncoded("encoded", "%7B%22Statement%22%3A%7B%22Effect%22%3A%22Allow%22%2C%22Action%22%3A%22s3%3AGetObject%22%2C%22Resource%22%3A%22%2A%22%7D%7D")
+	require.NoError(t, err)
+	assert.Equal(t, iampolicy.Strings{"s3:GetObject"}, document.Statement[0].Action)
+}
+
+func TestAudit(t *testing.T) {
+	t.Parallel()
+
+	document := parse(t, "broad", `{
+  "Statement": [
+    {"Sid": "Admin", "Effect": "Allow", "Action": "*", "Resource": "*"},
+    {"Sid": "AllS3", "Effect": "Allow", "Action": ["s3:*", "ec2:DescribeInstances"], "Resource": "*"},
+    {"Sid": "ScopedKMS", "Effect": "Allow", "Action": "kms:*", "Resource": "arn:aws:kms:ap-northeast-2:123456789012:key/1"},
+    {"Sid": "Conditional", "Effect": "Allow", "Action": "ec2:*", "Resource": "*", "Condition": {"StringEquals": {"aws:RequestedRegion": "ap-northeast-2"}}},
+    {"Sid": "Everything", "Effect": "Allow", "NotAction": "iam:*", "Resource": "*"},
+    {"Sid": "Guard", "Effect": "Deny", "Action": "*", "Resource": "*"}
+  ]
+}`)
+
+	var keys []string
+	for _, finding := range iampolicy.Audit(document) {
+		keys = append(keys, finding.Sid+" "+finding.Check)
+	}
+	assert.Equal(t, []string{"Admin admin-access", "AllS3 service-wildcard", "Everything not-action-allow"}, keys)
+	assert.Len(t, iampolicy.Errors(iampolicy.Audit(document)), 1)
+
+	trust := parse(t, "trust", `{"Statement": [
+    {"Sid": "Anyone", "Effect": "Allow", "Action": "sts:AssumeRole", "Principal": "*"},
+    {"Sid": "Nobody", "Effect": "Allow", "Action": "sts:AssumeRole", "Principal": {"AWS": []}},
+    {"Sid": "OrgOnly", "Effect": "Allow", "Action": "sts:AssumeRole", "Principal": {"AWS": "*"}, "Condition": {"StringEquals": {"aws:PrincipalOrgID": "o-123"}}}
+  ]}`)
+	keys = nil
+	for _, finding := range iampolicy.AuditTrust(trust) {
+		keys = append(keys, finding.Sid+" "+finding.Check)
+	}
+	assert.Equal(t, []string{"Anyone wildcard-principal", "Nobody empty-principal"}, keys)
+}
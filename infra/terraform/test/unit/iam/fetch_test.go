@@ -0,0 +1,98 @@
+package iam
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/iam"
+	"github.com/aws/aws-sdk-go/service/iam/iamiface"
+	"github.com/k8s-ec2-observability/test/helpers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeIAM 역할 하나의 정책을 URL 인코딩해서 돌려주는 IAM 대역
+type fakeIAM struct {
+	iamiface.IAMAPI
+
+	trust    string
+	managed  map[string]string
+	inline   map[string]string
+	versions []string
+}
+
+func (f *fakeIAM) GetRole(input *iam.GetRoleInput) (*iam.GetRoleOutput, error) {
+	return &iam.GetRoleOutput{Role: &iam.Role{
+		RoleName:                 input.RoleName,
+		Arn:                      aws.String("arn:aws:iam::123456789012:role/" + aws.StringValue(input.RoleName)),
+		AssumeRolePolicyDocument: aws.String(url.QueryEscape(f.trust)),
+	}}, nil
+}
+
+func (f *fakeIAM) ListAttachedRolePoliciesPages(_ *iam.ListAttachedRolePoliciesInput, fn func(*iam.ListAttachedRolePoliciesOutput, bool) bool) error {
+	page := &iam.ListAttachedRolePoliciesOutput{}
+	for arn := range f.managed {
+		page.AttachedPolicies = append(page.AttachedPolicies, &iam.AttachedPolicy{PolicyArn: aws.String(arn)})
+	}
+	fn(page, true)
+	return nil
+}
+
+func (f *fakeIAM) GetPolicy(input *iam.GetPolicyInput) (*iam.GetPolicyOutput, error) {
+	return &iam.GetPolicyOutput{Policy: &iam.Policy{Arn: input.PolicyArn, DefaultVersionId: aws.String("v3")}}, nil
+}
+
+func (f *fakeIAM) GetPolicyVersion(input *iam.GetPolicyVersionInput) (*iam.GetPolicyVersionOutput, error) {
+	f.versions = append(f.versions, aws.StringValue(input.VersionId))
+	return &iam.GetPolicyVersionOutput{PolicyVersion: &iam.PolicyVersion{
+		Document: aws.String(url.QueryEscape(f.managed[aws.StringValue(input.PolicyArn)])),
+	}}, nil
+}
+
+func (f *fakeIAM) ListRolePoliciesPages(_ *iam.ListRolePoliciesInput, fn func(*iam.ListRolePoliciesOutput, bool) bool) error {
+	page := &iam.ListRolePoliciesOutput{}
+	for name := range f.inline {
+		page.PolicyNames = append(page.PolicyNames, aws.String(name))
+	}
+	fn(page, true)
+	return nil
+}
+
+func (f *fakeIAM) GetRolePolicy(input *iam.GetRolePolicyInput) (*iam.GetRolePolicyOutput, error) {
+	return &iam.GetRolePolicyOutput{PolicyDocument: aws.String(url.QueryEscape(f.inline[aws.StringValue(input.PolicyName)]))}, nil
+}
+
+func TestFetchRolePolicies(t *testing.T) {
+	t.Parallel()
+
+	fake := &fakeIAM{
+		trust: `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Action":"sts:AssumeRole","Principal":{"AWS":"arn:aws:iam::123456789012:user/ci"}}]}`,
+		managed: map[string]string{
+			"arn:aws:iam::123456789012:policy/kms": `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Action":["kms:CreateKey","kms:DescribeKey"],"Resource":"*"}]}`,
+		},
+		inline: map[string]string{
+			"deny-iam": `{"Version":"2012-10-17","Statement":[{"Effect":"Deny","Action":"iam:*","Resource":"*"}]}`,
+		},
+	}
+
+	policies, err := helpers.FetchRolePolicies(fake, "Terraform-Execution-Role")
+	require.NoError(t, err)
+	assert.Equal(t, "arn:aws:iam::123456789012:role/Terraform-Execution-Role", policies.RoleARN)
+	assert.Equal(t, []string{"v3"}, fake.versions, "관리형 정책은 기본 버전을 조회")
+	require.Len(t, policies.Attached, 1)
+	require.Len(t, policies.Inline, 1)
+	assert.Equal(t, "deny-iam", policies.Inline[0].Name)
+
+	result, err := policies.Evaluate("kms:CreateKey", "*", nil)
+	require.NoError(t, err)
+	assert.True(t, result.Allowed())
+
+	result, err = policies.Evaluate("iam:CreateUser", "*", nil)
+	require.NoError(t, err)
+	assert.Equal(t, "ExplicitDeny", string(result.Decision))
+	assert.Equal(t, "deny-iam", result.Matches[0].Policy)
+
+	require.Len(t, policies.Trust.Statement, 1)
+	assert.Equal(t, []string{"arn:aws:iam::123456789012:user/ci"}, []string(policies.Trust.Statement[0].Principal["AWS"]))
+}
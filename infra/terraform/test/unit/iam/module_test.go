@@ -0,0 +1,147 @@
+package iam
+
+import (
+	"testing"
+
+	"github.com/k8s-ec2-observability/test/helpers/allowlist"
+	"github.com/k8s-ec2-observability/test/helpers/iampolicy"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	iamModule       = "../../../modules/iam"
+	executionRole   = "aws_iam_role.terraform_execution_role"
+	kmsPolicy       = "aws_iam_policy.kms_management_policy"
+	ciUserARN       = "arn:aws:iam::123456789012:user/ci"
+	kmsKeyARN       = "arn:aws:kms:ap-northeast-2:123456789012:key/1234abcd-12ab-34cd-56ef-1234567890ab"
+	iamUserResource = "arn:aws:iam::123456789012:user/intruder"
+	projectName     = "k8s-ec2-observability"
+	awsAccountID    = "123456789012"
+	environment     = "test"
+)
+
+// 알려진 감사 항목 → 유지 사유 (해결되면 테스트가 알려줌)
+var knownFindings = map[string]string{
+	"arn:aws:iam::aws:policy/AdministratorAccess admin-access": "부트스트랩 단계에서 전체 스택 배포 권한이 필요해 임시로 연결",
+}
+
+func loadExecutionRole(t *testing.T, trusted []string) *iampolicy.Role {
+	t.Helper()
+	return loadExecutionRoleWithVars(t, map[string]interface{}{
+		"trusted_role_arns": trusted,
+	})
+}
+
+func loadExecutionRoleWithVars(t *testing.T, extra map[string]interface{}) *iampolicy.Role {
+	t.Helper()
+	vars := map[string]interface{}{
+		"project_name":   projectName,
+		"environment":    environment,
+		"aws_account_id": awsAccountID,
+	}
+	for key, value := range extra {
+		vars[key] = value
+	}
+	roles, err := iampolicy.LoadModuleRoles(iamModule, vars)
+	require.NoError(t, err)
+	require.Contains(t, roles, executionRole)
+	return roles[executionRole]
+}
+
+func TestExecutionRoleTrustPolicy(t *testing.T) {
+	t.Parallel()
+
+	role := loadExecutionRole(t, []string{ciUserARN})
+	assert.Equal(t, "Terraform-Execution-Role", role.Name)
+
+	result, err := role.CanAssume(iampolicy.Principal{Type: "AWS", ID: ciUserARN}, nil)
+	require.NoError(t, err)
+	assert.True(t, result.Allowed(), "trusted_role_arns의 주체는 역할을 위임받을 수 있어야 합니다")
+
+	result, err = role.CanAssume(iampolicy.Principal{Type: "AWS", ID: "arn:aws:iam::123456789012:user/other"}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, iampolicy.DecisionImplicitDeny, result.Decision)
+
+	result, err = role.CanAssume(iampolicy.Principal{Type: "Service", ID: "ec2.amazonaws.com"}, nil)
+	require.NoError(t, err)
+	assert.False(t, result.Allowed())
+}
+
+func TestExecutionRoleDefaultTrustIsEmpty(t *testing.T) {
+	t.Parallel()
+
+	// trusted_role_arns 기본값([])이면 아무도 역할을 쓸 수 없음
+	role := loadExecutionRole(t, nil)
+	var checks []string
+	for _, finding := range iampolicy.AuditTrust(role.Trust) {
+		checks = append(checks, finding.Check)
+	}
+	assert.Equal(t, []string{iampolicy.CheckEmptyPrincipal}, checks)
+}
+
+func TestExecutionRoleExternalID(t *testing.T) {
+	t.Parallel()
+
+	ci := iampolicy.Principal{Type: "AWS", ID: ciUserARN}
+	role := loadExecutionRoleWithVars(t, map[string]interface{}{
+		"trusted_role_arns": []string{ciUserARN},
+		"external_id":       "k8s-obs-test",
+	})
+
+	result, err := role.CanAssume(ci, map[string][]string{"sts:ExternalId": {"k8s-obs-test"}})
+	require.NoError(t, err)
+	assert.True(t, result.Allowed(), "external_id가 일치하면 위임받을 수 있어야 합니다")
+
+	result, err = role.CanAssume(ci, map[string][]string{"sts:ExternalId": {"other"}})
+	require.NoError(t, err)
+	assert.False(t, result.Allowed(), "external_id가 다르면 거부되어야 합니다")
+
+	result, err = role.CanAssume(ci, nil)
+	require.NoError(t, err)
+	assert.False(t, result.Allowed(), "external_id 없이 위임받을 수 없어야 합니다")
+
+	// 기본값("")이면 조건 없이 위임
+	result, err = loadExecutionRole(t, []string{ciUserARN}).CanAssume(ci, nil)
+	require.NoError(t, err)
+	assert.True(t, result.Allowed())
+}
+
+func TestExecutionRolePermissions(t *testing.T) {
+	t.Parallel()
+
+	role := loadExecutionRole(t, []string{ciUserARN})
+	assert.Empty(t, role.Unresolved)
+	require.NotNil(t, role.Policy(kmsPolicy))
+	require.NotNil(t, role.Policy(iampolicy.AdministratorAccessARN))
+
+	// KMS 관리 정책만으로 KMS 모듈을 배포할 수 있어야 함
+	scoped := role.Without(iampolicy.AdministratorAccessARN)
+	for _, action := range []string{"kms:CreateKey", "kms:EnableKeyRotation", "kms:CreateAlias", "kms:ScheduleKeyDeletion", "kms:TagResource"} {
+		result, err := scoped.Evaluate(action, kmsKeyARN, nil)
+		require.NoError(t, err)
+		assert.True(t, result.Allowed(), "%s 허용 필요", action)
+	}
+	for _, action := range []string{"iam:CreateUser", "kms:Decrypt", "kms:PutKeyPolicy", "ec2:RunInstances"} {
+		result, err := scoped.Evaluate(action, iamUserResource, nil)
+		require.NoError(t, err)
+		assert.False(t, result.Allowed(), "KMS 관리 정책은 %s를 허용하지 않아야 합니다", action)
+	}
+
+	// AdministratorAccess 연결 때문에 실제로는 최소 권한이 아님
+	result, err := role.Evaluate("iam:CreateUser", iamUserResource, nil)
+	require.NoError(t, err)
+	assert.True(t, result.Allowed())
+	assert.Equal(t, iampolicy.AdministratorAccessARN, result.Matches[0].Policy)
+}
+
+func TestExecutionRoleAudit(t *testing.T) {
+	t.Parallel()
+
+	role := loadExecutionRole(t, []string{ciUserARN})
+	found := make(map[string]string)
+	for _, finding := range role.Audit() {
+		found[finding.Policy+" "+finding.Check] = finding.String()
+	}
+	allowlist.Check(t, "knownFindings", knownFindings, found)
+}
@@ -0,0 +1,59 @@
+package k8s
+
+import (
+	"testing"
+
+	k8shelpers "github.com/k8s-ec2-observability/test/helpers/k8s"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/client-go/kubernetes"
+)
+
+const testKubeconfig = `apiVersion: v1
+kind: Config
+clusters:
+- cluster:
+    server: https://10.0.1.10:6443
+    insecure-skip-tls-verify: true
+  name: kubernetes
+contexts:
+- context:
+    cluster: kubernetes
+    user: kubernetes-admin
+  name: kubernetes-admin@kubernetes
+current-context: kubernetes-admin@kubernetes
+users:
+- name: kubernetes-admin
+  user:
+    token: test-token
+`
+
+func apiServerHost(t *testing.T, client kubernetes.Interface) string {
+	clientset, ok := client.(*kubernetes.Clientset)
+	require.True(t, ok)
+	return clientset.CoreV1().RESTClient().Get().URL().Host
+}
+
+func TestNewClientFromKubeconfig(t *testing.T) {
+	t.Parallel()
+
+	client, err := k8shelpers.NewClientFromKubeconfig([]byte(testKubeconfig), "")
+	require.NoError(t, err)
+	assert.Equal(t, "10.0.1.10:6443", apiServerHost(t, client))
+}
+
+func TestNewClientFromKubeconfigRewritesHost(t *testing.T) {
+	t.Parallel()
+
+	// 퍼블릭 IP로 접속하더라도 포트는 kubeconfig 값을 유지해야 합니다
+	client, err := k8shelpers.NewClientFromKubeconfig([]byte(testKubeconfig), "3.35.10.20")
+	require.NoError(t, err)
+	assert.Equal(t, "3.35.10.20:6443", apiServerHost(t, client))
+}
+
+func TestNewClientFromKubeconfigInvalid(t *testing.T) {
+	t.Parallel()
+
+	_, err := k8shelpers.NewClientFromKubeconfig([]byte("not: [valid"), "")
+	assert.Error(t, err)
+}
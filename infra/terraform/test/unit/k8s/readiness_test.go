@@ -0,0 +1,134 @@
+package k8s
+
+import (
+	"context"
+	"testing"
+
+	k8shelpers "github.com/k8s-ec2-observability/test/helpers/k8s"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func newNode(name, role string, ready corev1.ConditionStatus) *corev1.Node {
+	return &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   name,
+			Labels: map[string]string{"node-role.kubernetes.io/" + role: ""},
+		},
+		Status: corev1.NodeStatus{
+			NodeInfo: corev1.NodeSystemInfo{KubeletVersion: "v1.31.0"},
+			Conditions: []corev1.NodeCondition{
+				{Type: corev1.NodeReady, Status: ready},
+				{Type: corev1.NodeMemoryPressure, Status: corev1.ConditionFalse},
+			},
+		},
+	}
+}
+
+func newPod(namespace, name, node string, labels map[string]string, ready bool) *corev1.Pod {
+	readyStatus := corev1.ConditionFalse
+	phase := corev1.PodPending
+	if ready {
+		readyStatus = corev1.ConditionTrue
+		phase = corev1.PodRunning
+	}
+
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name, Labels: labels},
+		Spec:       corev1.PodSpec{NodeName: node},
+		Status: corev1.PodStatus{
+			Phase:             phase,
+			Conditions:        []corev1.PodCondition{{Type: corev1.PodReady, Status: readyStatus}},
+			ContainerStatuses: []corev1.ContainerStatus{{Name: "main", RestartCount: 1}},
+		},
+	}
+}
+
+// 마스터 1대 + 워커 2대가 모두 준비된 클러스터
+func healthyCluster() []runtime.Object {
+	dnsLabels := map[string]string{"k8s-app": "kube-dns"}
+	return []runtime.Object{
+		newNode("ip-10-0-1-10", "control-plane", corev1.ConditionTrue),
+		newNode("ip-10-0-2-11", "worker", corev1.ConditionTrue),
+		newNode("ip-10-0-2-12", "worker", corev1.ConditionTrue),
+		newPod("calico-system", "calico-node-a", "ip-10-0-1-10", nil, true),
+		newPod("calico-system", "calico-kube-controllers-0", "ip-10-0-1-10", nil, true),
+		newPod("kube-system", "coredns-1", "ip-10-0-1-10", dnsLabels, true),
+		newPod("kube-system", "coredns-2", "ip-10-0-1-10", dnsLabels, true),
+		newPod("kube-system", "kube-proxy-a", "ip-10-0-1-10", map[string]string{"k8s-app": "kube-proxy"}, false),
+	}
+}
+
+func TestClusterReadinessHealthy(t *testing.T) {
+	t.Parallel()
+
+	client := fake.NewSimpleClientset(healthyCluster()...)
+
+	readiness, err := k8shelpers.CheckClusterReadiness(context.Background(), client, k8shelpers.ExpectedNodeCount(2))
+	require.NoError(t, err)
+
+	assert.True(t, readiness.IsReady(), "문제 목록: %v", readiness.Problems())
+	assert.Equal(t, 3, readiness.ReadyNodeCount())
+	assert.Len(t, readiness.CNIPods, 2)
+	assert.Len(t, readiness.CoreDNSPods, 2, "CoreDNS 셀렉터는 kube-proxy 파드를 포함하지 않아야 합니다")
+
+	master := readiness.Nodes[0]
+	assert.Equal(t, "ip-10-0-1-10", master.Name)
+	assert.Equal(t, []string{"control-plane"}, master.Roles)
+	assert.Equal(t, "v1.31.0", master.KubeletVersion)
+	assert.Equal(t, corev1.ConditionFalse, master.Conditions[corev1.NodeMemoryPressure])
+	assert.Equal(t, int32(1), readiness.CoreDNSPods[0].Restarts)
+}
+
+func TestClusterReadinessMissingWorker(t *testing.T) {
+	t.Parallel()
+
+	objects := healthyCluster()
+	objects[2] = newNode("ip-10-0-2-12", "worker", corev1.ConditionFalse)
+	client := fake.NewSimpleClientset(objects...)
+
+	readiness, err := k8shelpers.CheckClusterReadiness(context.Background(), client, k8shelpers.ExpectedNodeCount(3))
+	require.NoError(t, err)
+
+	assert.False(t, readiness.IsReady())
+	assert.Equal(t, 2, readiness.ReadyNodeCount())
+
+	problems := readiness.Problems()
+	assert.Contains(t, problems, "Ready 노드 수 부족: 2/4")
+	assert.Contains(t, problems, "노드 ip-10-0-2-12 NotReady (MemoryPressure=False, Ready=False)")
+}
+
+func TestClusterReadinessWithoutCNI(t *testing.T) {
+	t.Parallel()
+
+	client := fake.NewSimpleClientset(
+		newNode("ip-10-0-1-10", "control-plane", corev1.ConditionFalse),
+		newPod("kube-system", "coredns-1", "", map[string]string{"k8s-app": "kube-dns"}, false),
+	)
+
+	readiness, err := k8shelpers.CheckClusterReadiness(context.Background(), client, 1)
+	require.NoError(t, err)
+
+	problems := readiness.Problems()
+	assert.Contains(t, problems, "CNI 파드가 없습니다")
+	assert.Contains(t, problems, "CoreDNS 파드 kube-system/coredns-1 미준비 (phase=Pending, node=)")
+}
+
+func TestWaitForClusterReady(t *testing.T) {
+	t.Parallel()
+
+	client := fake.NewSimpleClientset(healthyCluster()...)
+	readiness, err := k8shelpers.WaitForClusterReady(t, client, 3, 2, 0)
+	require.NoError(t, err)
+	assert.True(t, readiness.IsReady())
+
+	notReady := fake.NewSimpleClientset(newNode("ip-10-0-1-10", "control-plane", corev1.ConditionFalse))
+	readiness, err = k8shelpers.WaitForClusterReady(t, notReady, 3, 2, 0)
+	assert.Error(t, err)
+	require.NotNil(t, readiness, "실패 시에도 마지막 보고서를 반환해야 합니다")
+	assert.Equal(t, 0, readiness.ReadyNodeCount())
+}
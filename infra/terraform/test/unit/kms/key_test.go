@@ -5,37 +5,25 @@ import (
 	"time"
 
 	"github.com/aws/aws-sdk-go/service/kms"
-	"github.com/gruntwork-io/terratest/modules/terraform"
 	"github.com/k8s-ec2-observability/test/helpers"
 	"github.com/stretchr/testify/assert"
 )
 
 // KMS 키 생성 테스트 (GitHub Actions 최적화)
 func TestKMSKeyCreation(t *testing.T) {
-	// t.Parallel() 제거 - AWS API 제한 방지를 위해 순차 실행
+	// 키는 공유 픽스처가 만들고 AWS 호출은 DefaultRateGovernor가 조절
+	t.Parallel()
 
 	t.Logf("🔐 KMS 키 생성 테스트 시작...")
 
-	// 테스트 설정 초기화
-	config := helpers.NewKMSTestConfig()
-	awsClient := helpers.NewAWSTestClient(t, config.Region)
-	terraformOptions := helpers.SetupKMSTest(t, config)
-
-	// 테스트 완료 후 리소스 정리
-	defer func() {
-		t.Logf("🧹 KMS 리소스 정리 중...")
-		terraform.Destroy(t, terraformOptions)
-		t.Logf("✅ KMS 리소스 정리 완료")
-	}()
-
-	// KMS 키 생성
-	t.Logf("🚀 KMS 키 생성 중... (단순화된 설정)")
-	terraform.InitAndApply(t, terraformOptions)
+	// 패키지가 공유하는 KMS 키 사용 (TestMain이 한 번 만들고 마지막에 정리)
+	shared := kmsKey.Acquire(t)
+	awsClient := helpers.NewAWSTestClient(t, shared.Config.Region)
 
 	// KMS 키 기본 검증
 	t.Logf("🔍 KMS 키 기본 검증 중...")
-	keyID := terraform.Output(t, terraformOptions, "key_id")
-	keyArn := terraform.Output(t, terraformOptions, "key_arn")
+	keyID := shared.KeyID
+	keyArn := shared.KeyARN
 
 	// 기본 출력값 검증
 	assert.NotEmpty(t, keyID, "KMS 키 ID가 비어있지 않아야 합니다")
@@ -0,0 +1,15 @@
+package kms
+
+import (
+	"os"
+	"testing"
+
+	"github.com/k8s-ec2-observability/test/helpers"
+)
+
+// kmsKey 키 생성, 로테이션, 태그 테스트가 함께 쓰는 KMS 키 (패키지 실행마다 하나)
+var kmsKey = helpers.NewKMSKeyFixture()
+
+func TestMain(m *testing.M) {
+	os.Exit(kmsKey.RunTests(m))
+}
@@ -6,28 +6,33 @@ import (
 	"github.com/gruntwork-io/terratest/modules/terraform"
 	"github.com/k8s-ec2-observability/test/helpers"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
-// CloudWatch 설정 테스트 (GitHub Actions에서 권한 문제로 비활성화)
+// CloudWatch 설정 테스트 (로그 그룹은 CloudTrail 기능이 만들므로 CloudTrail 권한도 필요)
 func TestCloudWatchConfiguration(t *testing.T) {
-	t.Skip("GitHub Actions에서 CloudWatch 권한 문제로 비활성화됨")
-
-	// 병렬 실행 비활성화로 리소스 충돌 방지
-	// t.Parallel()
+	// AWS 호출은 DefaultRateGovernor, apply/destroy는 DefaultApplyLimiter가 조절
+	t.Parallel()
 
 	t.Logf("📊 CloudWatch 설정 테스트 시작...")
 
 	// 테스트 설정 초기화
 	config := helpers.NewKMSTestConfig()
 	awsClient := helpers.NewAWSTestClient(t, config.Region)
+
+	// 현재 자격 증명으로 만들 수 없으면 부족한 권한을 사유로 건너뜀
+	capabilities, err := awsClient.ProbeCapabilities()
+	require.NoError(t, err)
+	capabilities.Require(t, helpers.CapabilityCloudWatch, helpers.CapabilityCloudTrail, helpers.CapabilityLogs)
+	config.EnableAllowedFeatures(t, capabilities)
 	terraformOptions := helpers.SetupKMSTest(t, config)
 
 	// 테스트 완료 후 리소스 정리
-	defer terraform.Destroy(t, terraformOptions)
+	defer helpers.DestroyTerraform(t, terraformOptions)
 
 	// KMS 키 생성
 	t.Logf("🔐 KMS 키 및 CloudWatch 리소스 생성 중...")
-	terraform.InitAndApply(t, terraformOptions)
+	helpers.ApplyTerraform(t, terraformOptions)
 
 	// CloudWatch 로그 그룹 검증
 	t.Logf("📋 CloudWatch 로그 그룹 검증 중...")
@@ -46,26 +51,30 @@ func TestCloudWatchConfiguration(t *testing.T) {
 	t.Logf("✅ CloudWatch 설정 테스트 완료: 로그 그룹 및 경보 활성화됨")
 }
 
-// CloudTrail 설정 테스트 (GitHub Actions에서 권한 문제로 비활성화)
+// CloudTrail 설정 테스트
 func TestCloudTrailConfiguration(t *testing.T) {
-	t.Skip("GitHub Actions에서 CloudTrail/S3 권한 문제로 비활성화됨")
-
-	// 병렬 실행 비활성화로 리소스 충돌 방지
-	// t.Parallel()
+	// AWS 호출은 DefaultRateGovernor, apply/destroy는 DefaultApplyLimiter가 조절
+	t.Parallel()
 
 	t.Logf("🛤️ CloudTrail 설정 테스트 시작...")
 
 	// 테스트 설정 초기화
 	config := helpers.NewKMSTestConfig()
 	awsClient := helpers.NewAWSTestClient(t, config.Region)
+
+	// 현재 자격 증명으로 만들 수 없으면 부족한 권한을 사유로 건너뜀
+	capabilities, err := awsClient.ProbeCapabilities()
+	require.NoError(t, err)
+	capabilities.Require(t, helpers.CapabilityCloudTrail, helpers.CapabilityLogs)
+	config.EnableAllowedFeatures(t, capabilities)
 	terraformOptions := helpers.SetupKMSTest(t, config)
 
 	// 테스트 완료 후 리소스 정리
-	defer terraform.Destroy(t, terraformOptions)
+	defer helpers.DestroyTerraform(t, terraformOptions)
 
 	// KMS 키 생성
 	t.Logf("🔐 KMS 키 및 CloudTrail 리소스 생성 중...")
-	terraform.InitAndApply(t, terraformOptions)
+	helpers.ApplyTerraform(t, terraformOptions)
 
 	// CloudTrail 검증
 	t.Logf("🔍 CloudTrail 설정 검증 중...")
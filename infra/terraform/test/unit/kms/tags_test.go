@@ -4,32 +4,20 @@ import (
 	"testing"
 	"time"
 
-	"github.com/gruntwork-io/terratest/modules/terraform"
 	"github.com/k8s-ec2-observability/test/helpers"
 	"github.com/stretchr/testify/assert"
 )
 
 // TestKMSKeyTags KMS 키 태그 테스트 (단순화)
 func TestKMSKeyTags(t *testing.T) {
-	// t.Parallel() 제거 - AWS API 제한 방지를 위해 순차 실행
+	// 키는 공유 픽스처가 만들고 AWS 호출은 DefaultRateGovernor가 조절
+	t.Parallel()
 
 	t.Logf("🏷️ KMS 키 태그 테스트 시작...")
 
-	// 테스트 설정 초기화
-	config := helpers.NewKMSTestConfig()
-	awsClient := helpers.NewAWSTestClient(t, config.Region)
-	terraformOptions := helpers.SetupKMSTest(t, config)
-
-	// 테스트 완료 후 리소스 정리
-	defer func() {
-		t.Logf("🧹 KMS 리소스 정리 중...")
-		terraform.Destroy(t, terraformOptions)
-		t.Logf("✅ KMS 리소스 정리 완료")
-	}()
-
-	// KMS 키 생성
-	t.Logf("🔐 KMS 키 생성 중...")
-	terraform.InitAndApply(t, terraformOptions)
+	// 패키지가 공유하는 KMS 키 사용 (TestMain이 한 번 만들고 마지막에 정리)
+	shared := kmsKey.Acquire(t)
+	awsClient := helpers.NewAWSTestClient(t, shared.Config.Region)
 
 	// 기본 태그 검증을 위한 예상 태그 정의 (단순화)
 	expectedTags := map[string]string{
@@ -42,7 +30,7 @@ func TestKMSKeyTags(t *testing.T) {
 
 	// KMS 키 태그 검증 (재시도 로직 포함)
 	t.Logf("🔍 KMS 키 태그 검증 중...")
-	keyID := terraform.Output(t, terraformOptions, "key_id")
+	keyID := shared.KeyID
 	assert.NotEmpty(t, keyID, "KMS 키 ID가 비어있지 않아야 합니다")
 
 	var actualTags map[string]string
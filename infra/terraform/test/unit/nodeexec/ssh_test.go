@@ -0,0 +1,246 @@
+package nodeexec
+
+import (
+	"bytes"
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/k8s-ec2-observability/test/helpers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newSSHNodeExec(t *testing.T, server *testSSHServer, privateKey []byte, bastion *helpers.SSHEndpoint) *helpers.SSHNodeExec {
+	node := helpers.NewSSHNodeExec(helpers.SSHNodeExecConfig{
+		Target: helpers.SSHEndpoint{
+			Host:       server.host(),
+			Port:       server.port(),
+			User:       "ubuntu",
+			PrivateKey: privateKey,
+		},
+		Bastion:     bastion,
+		DialTimeout: 5 * time.Second,
+	})
+	t.Cleanup(func() { node.Close() })
+	return node
+}
+
+func TestSSHNodeExecOutputAndExitCode(t *testing.T) {
+	t.Parallel()
+
+	privateKey, publicKey := newClientKey(t)
+	server := newTestSSHServer(t, publicKey)
+	node := newSSHNodeExec(t, server, privateKey, nil)
+
+	result, err := node.Exec(context.Background(), helpers.NodeCommand{Command: "echo kubeadm; echo warn >&2"})
+	require.NoError(t, err)
+	assert.Equal(t, "kubeadm\n", result.Stdout)
+	assert.Equal(t, "warn\n", result.Stderr)
+	assert.Equal(t, 0, result.ExitCode)
+	assert.NoError(t, result.Err())
+
+	// 0이 아닌 종료 코드는 연결 에러가 아니라 결과로 보고되어야 합니다
+	result, err = node.Exec(context.Background(), helpers.NodeCommand{Command: "echo failed >&2; exit 3"})
+	require.NoError(t, err)
+	assert.Equal(t, 3, result.ExitCode)
+
+	var commandErr *helpers.NodeCommandError
+	require.ErrorAs(t, result.Err(), &commandErr)
+	assert.Equal(t, 3, commandErr.ExitCode)
+	assert.Contains(t, commandErr.Error(), "failed")
+
+	// 같은 연결을 재사용하여 여러 명령 실행
+	assert.Len(t, server.executed(), 2)
+}
+
+func TestSSHNodeExecStreamsOutput(t *testing.T) {
+	t.Parallel()
+
+	privateKey, publicKey := newClientKey(t)
+	server := newTestSSHServer(t, publicKey)
+	node := newSSHNodeExec(t, server, privateKey, nil)
+
+	var stdout, stderr bytes.Buffer
+	result, err := node.Exec(context.Background(), helpers.NodeCommand{
+		Command: "for i in 1 2 3; do echo line$i; done; echo done >&2",
+		Stdout:  &stdout,
+		Stderr:  &stderr,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "line1\nline2\nline3\n", stdout.String())
+	assert.Equal(t, "done\n", stderr.String())
+	assert.Equal(t, stdout.String(), result.Stdout, "스트리밍과 캡처 결과가 같아야 합니다")
+}
+
+func TestSSHNodeExecTimeout(t *testing.T) {
+	t.Parallel()
+
+	privateKey, publicKey := newClientKey(t)
+	server := newTestSSHServer(t, publicKey)
+	node := newSSHNodeExec(t, server, privateKey, nil)
+
+	start := time.Now()
+	result, err := node.Exec(context.Background(), helpers.NodeCommand{
+		Command: "echo started; sleep 30",
+		Timeout: 500 * time.Millisecond,
+	})
+	require.Error(t, err)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Contains(t, err.Error(), "타임아웃")
+	assert.Less(t, time.Since(start), 10*time.Second)
+	assert.Equal(t, -1, result.ExitCode)
+}
+
+func TestSSHNodeExecHandshakeTimeout(t *testing.T) {
+	t.Parallel()
+
+	// 연결은 받지만 SSH 버전 문자열을 보내지 않는 서버
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { listener.Close() })
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			t.Cleanup(func() { conn.Close() })
+		}
+	}()
+
+	privateKey, _ := newClientKey(t)
+	node := helpers.NewSSHNodeExec(helpers.SSHNodeExecConfig{
+		Target: helpers.SSHEndpoint{
+			Host:       "127.0.0.1",
+			Port:       listener.Addr().(*net.TCPAddr).Port,
+			User:       "ubuntu",
+			PrivateKey: privateKey,
+		},
+		DialTimeout: 30 * time.Second,
+	})
+	t.Cleanup(func() { node.Close() })
+
+	start := time.Now()
+	_, err = node.Exec(context.Background(), helpers.NodeCommand{Command: "true", Timeout: 500 * time.Millisecond})
+	require.Error(t, err)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Less(t, time.Since(start), 10*time.Second, "핸드셰이크가 명령 타임아웃에 묶여야 합니다")
+}
+
+func TestSSHNodeExecReconnectsAfterDroppedConnection(t *testing.T) {
+	t.Parallel()
+
+	privateKey, publicKey := newClientKey(t)
+	server := newTestSSHServer(t, publicKey)
+	node := newSSHNodeExec(t, server, privateKey, nil)
+
+	_, err := node.Exec(context.Background(), helpers.NodeCommand{Command: "echo first"})
+	require.NoError(t, err)
+
+	server.dropConnections()
+	// 끊어진 연결로 인한 실패는 한 번으로 끝나고 다음 호출은 재연결해야 합니다
+	_, err = node.Exec(context.Background(), helpers.NodeCommand{Command: "echo dropped"})
+	require.Error(t, err)
+
+	result, err := node.Exec(context.Background(), helpers.NodeCommand{Command: "echo again"})
+	require.NoError(t, err)
+	assert.Equal(t, "again\n", result.Stdout)
+}
+
+func TestSSHNodeExecViaBastion(t *testing.T) {
+	t.Parallel()
+
+	privateKey, publicKey := newClientKey(t)
+	bastionServer := newTestSSHServer(t, publicKey)
+	targetServer := newTestSSHServer(t, publicKey)
+
+	// Bastion 키를 비워 두면 대상 노드 키를 그대로 사용
+	node := newSSHNodeExec(t, targetServer, privateKey, &helpers.SSHEndpoint{
+		Host: bastionServer.host(),
+		Port: bastionServer.port(),
+		User: "ubuntu",
+	})
+
+	output, err := helpers.RunNodeCommand(context.Background(), node, "echo worker")
+	require.NoError(t, err)
+	assert.Equal(t, "worker\n", output)
+
+	assert.Equal(t, []string{targetServer.address()}, bastionServer.forwarded())
+	assert.Empty(t, bastionServer.executed(), "Bastion에서는 명령이 실행되지 않아야 합니다")
+	assert.Equal(t, []string{"echo worker"}, targetServer.executed())
+}
+
+func TestSSHNodeExecDiscardsConnectionAfterStartFailure(t *testing.T) {
+	t.Parallel()
+
+	privateKey, publicKey := newClientKey(t)
+	server := newTestSSHServer(t, publicKey)
+	node := newSSHNodeExec(t, server, privateKey, nil)
+
+	server.rejectNextExecs(1)
+	_, err := node.Exec(context.Background(), helpers.NodeCommand{Command: "echo rejected"})
+	require.Error(t, err)
+
+	// 명령 시작에 실패한 연결은 버리고 다음 호출에서 새로 연결해야 합니다
+	result, err := node.Exec(context.Background(), helpers.NodeCommand{Command: "echo again"})
+	require.NoError(t, err)
+	assert.Equal(t, "again\n", result.Stdout)
+	assert.Equal(t, 2, server.acceptedConnections())
+}
+
+func TestSSHNodeExecBastionDialHonorsContext(t *testing.T) {
+	t.Parallel()
+
+	privateKey, publicKey := newClientKey(t)
+	bastionServer := newTestSSHServer(t, publicKey)
+	targetServer := newTestSSHServer(t, publicKey)
+	bastionServer.stallForwards(t)
+
+	node := newSSHNodeExec(t, targetServer, privateKey, &helpers.SSHEndpoint{
+		Host: bastionServer.host(),
+		Port: bastionServer.port(),
+		User: "ubuntu",
+	})
+
+	start := time.Now()
+	_, err := node.Exec(context.Background(), helpers.NodeCommand{Command: "true", Timeout: 500 * time.Millisecond})
+	require.Error(t, err)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Less(t, time.Since(start), 4*time.Second, "Bastion 경유 연결이 명령 타임아웃에 묶여야 합니다")
+	assert.Empty(t, targetServer.executed())
+}
+
+func TestSSHNodeExecRejectsUnknownKey(t *testing.T) {
+	t.Parallel()
+
+	_, publicKey := newClientKey(t)
+	otherKey, _ := newClientKey(t)
+	server := newTestSSHServer(t, publicKey)
+	node := newSSHNodeExec(t, server, otherKey, nil)
+
+	_, err := node.Exec(context.Background(), helpers.NodeCommand{Command: "true"})
+	assert.Error(t, err)
+	assert.Empty(t, server.executed())
+}
+
+func TestNodeLogHelpers(t *testing.T) {
+	t.Parallel()
+
+	privateKey, publicKey := newClientKey(t)
+	server := newTestSSHServer(t, publicKey)
+	node := newSSHNodeExec(t, server, privateKey, nil)
+	ctx := context.Background()
+
+	// 테스트 서버에는 sudo가 없을 수 있으므로 실행된 명령 문자열만 검증
+	_, _ = helpers.GetKubeadmInitLog(ctx, node)
+	_, _ = helpers.GetJournalLogs(ctx, node, "kubelet", 50)
+	_, _ = helpers.GetCombinedSettingsLog(ctx, node)
+
+	assert.Equal(t, []string{
+		`sudo -n bash -c 'cat '"'"'/var/log/kubeadm_init.log'"'"''`,
+		`sudo -n bash -c 'journalctl -u '"'"'kubelet'"'"' -n 50 --no-pager'`,
+		`sudo -n bash -c 'cat '"'"'/home/ubuntu/combined_settings.log'"'"''`,
+	}, server.executed())
+}
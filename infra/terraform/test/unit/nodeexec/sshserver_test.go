@@ -0,0 +1,267 @@
+package nodeexec
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/pem"
+	"io"
+	"net"
+	"os/exec"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ssh"
+)
+
+// testSSHServer 로컬 셸로 exec 요청을 처리하는 테스트용 SSH 서버
+type testSSHServer struct {
+	listener net.Listener
+	config   *ssh.ServerConfig
+
+	mu          sync.Mutex
+	commands    []string
+	forwards    []string
+	conns       []net.Conn
+	accepted    int
+	rejectExecs int           // 거절할 exec 요청 수
+	stall       chan struct{} // nil이 아니면 닫힐 때까지 포워딩 요청에 응답하지 않음
+}
+
+// 클라이언트용 PEM 개인키와 해당 공개키를 허용하는 서버 생성
+func newTestSSHServer(t *testing.T, authorizedKey ssh.PublicKey) *testSSHServer {
+	_, hostPrivateKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	hostSigner, err := ssh.NewSignerFromKey(hostPrivateKey)
+	require.NoError(t, err)
+
+	server := &testSSHServer{}
+	server.config = &ssh.ServerConfig{
+		PublicKeyCallback: func(conn ssh.ConnMetadata, key ssh.PublicKey) (*ssh.Permissions, error) {
+			if conn.User() == "ubuntu" && string(key.Marshal()) == string(authorizedKey.Marshal()) {
+				return nil, nil
+			}
+			return nil, io.EOF
+		},
+	}
+	server.config.AddHostKey(hostSigner)
+
+	server.listener, err = net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { server.listener.Close() })
+
+	go server.serve()
+	return server
+}
+
+func (s *testSSHServer) host() string {
+	return s.listener.Addr().(*net.TCPAddr).IP.String()
+}
+
+func (s *testSSHServer) port() int {
+	return s.listener.Addr().(*net.TCPAddr).Port
+}
+
+func (s *testSSHServer) address() string {
+	return net.JoinHostPort(s.host(), strconv.Itoa(s.port()))
+}
+
+func (s *testSSHServer) executed() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.commands...)
+}
+
+func (s *testSSHServer) forwarded() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.forwards...)
+}
+
+func (s *testSSHServer) acceptedConnections() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.accepted
+}
+
+// 다음 n개의 exec 요청을 거절
+func (s *testSSHServer) rejectNextExecs(n int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.rejectExecs = n
+}
+
+// 테스트가 끝날 때까지 포워딩 요청에 응답하지 않음 (대상 노드로 가는 경로가 막힌 Bastion 재현)
+func (s *testSSHServer) stallForwards(t *testing.T) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.stall = make(chan struct{})
+	t.Cleanup(func() { close(s.stall) })
+}
+
+// 수립된 연결을 모두 끊음 (인스턴스 재부팅 등으로 연결이 끊긴 상황 재현)
+func (s *testSSHServer) dropConnections() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, conn := range s.conns {
+		conn.Close()
+	}
+	s.conns = nil
+}
+
+func (s *testSSHServer) serve() {
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+		go s.handleConn(conn)
+	}
+}
+
+func (s *testSSHServer) handleConn(conn net.Conn) {
+	s.mu.Lock()
+	s.conns = append(s.conns, conn)
+	s.accepted++
+	s.mu.Unlock()
+
+	serverConn, channels, requests, err := ssh.NewServerConn(conn, s.config)
+	if err != nil {
+		conn.Close()
+		return
+	}
+	defer serverConn.Close()
+	go ssh.DiscardRequests(requests)
+
+	for newChannel := range channels {
+		switch newChannel.ChannelType() {
+		case "session":
+			go s.handleSession(newChannel)
+		case "direct-tcpip":
+			go s.handleDirectTCPIP(newChannel)
+		default:
+			newChannel.Reject(ssh.UnknownChannelType, "지원하지 않는 채널")
+		}
+	}
+}
+
+func (s *testSSHServer) handleSession(newChannel ssh.NewChannel) {
+	channel, requests, err := newChannel.Accept()
+	if err != nil {
+		return
+	}
+	defer channel.Close()
+
+	for request := range requests {
+		if request.Type != "exec" {
+			request.Reply(false, nil)
+			continue
+		}
+
+		var payload struct{ Command string }
+		if err := ssh.Unmarshal(request.Payload, &payload); err != nil {
+			request.Reply(false, nil)
+			return
+		}
+
+		s.mu.Lock()
+		reject := s.rejectExecs > 0
+		if reject {
+			s.rejectExecs--
+		} else {
+			s.commands = append(s.commands, payload.Command)
+		}
+		s.mu.Unlock()
+		if reject {
+			request.Reply(false, nil)
+			return
+		}
+		request.Reply(true, nil)
+
+		cmd := exec.Command("sh", "-c", payload.Command)
+		cmd.Stdout = channel
+		cmd.Stderr = channel.Stderr()
+		if err := cmd.Start(); err != nil {
+			return
+		}
+
+		// 클라이언트가 세션을 닫거나 신호를 보내면 프로세스 종료
+		go func() {
+			for range requests {
+			}
+			cmd.Process.Kill()
+		}()
+
+		exitCode := 0
+		if err := cmd.Wait(); err != nil {
+			if exitErr, ok := err.(*exec.ExitError); ok {
+				exitCode = exitErr.ExitCode()
+			}
+		}
+		status := make([]byte, 4)
+		binary.BigEndian.PutUint32(status, uint32(exitCode))
+		channel.SendRequest("exit-status", false, status)
+		return
+	}
+}
+
+func (s *testSSHServer) handleDirectTCPIP(newChannel ssh.NewChannel) {
+	s.mu.Lock()
+	stall := s.stall
+	s.mu.Unlock()
+	if stall != nil {
+		<-stall
+	}
+
+	var payload struct {
+		Host       string
+		Port       uint32
+		OriginHost string
+		OriginPort uint32
+	}
+	if err := ssh.Unmarshal(newChannel.ExtraData(), &payload); err != nil {
+		newChannel.Reject(ssh.ConnectionFailed, err.Error())
+		return
+	}
+
+	target := net.JoinHostPort(payload.Host, strconv.Itoa(int(payload.Port)))
+	conn, err := net.Dial("tcp", target)
+	if err != nil {
+		newChannel.Reject(ssh.ConnectionFailed, err.Error())
+		return
+	}
+
+	channel, requests, err := newChannel.Accept()
+	if err != nil {
+		conn.Close()
+		return
+	}
+	go ssh.DiscardRequests(requests)
+
+	s.mu.Lock()
+	s.forwards = append(s.forwards, target)
+	s.mu.Unlock()
+
+	go func() {
+		io.Copy(channel, conn)
+		channel.CloseWrite()
+	}()
+	io.Copy(conn, channel)
+	conn.Close()
+	channel.Close()
+}
+
+// 테스트용 ed25519 클라이언트 키 생성 (PEM, 공개키)
+func newClientKey(t *testing.T) ([]byte, ssh.PublicKey) {
+	_, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	block, err := ssh.MarshalPrivateKey(privateKey, "")
+	require.NoError(t, err)
+	signer, err := ssh.NewSignerFromKey(privateKey)
+	require.NoError(t, err)
+
+	return pem.EncodeToMemory(block), signer.PublicKey()
+}
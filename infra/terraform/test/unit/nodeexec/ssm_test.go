@@ -0,0 +1,129 @@
+package nodeexec
+
+import (
+	"bytes"
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/ssm"
+	"github.com/aws/aws-sdk-go/service/ssm/ssmiface"
+	"github.com/k8s-ec2-observability/test/helpers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeSSM 정해진 상태 순서대로 응답하는 SSM 대역
+type fakeSSM struct {
+	ssmiface.SSMAPI
+
+	mu        sync.Mutex
+	statuses  []string
+	polls     int
+	sent      *ssm.SendCommandInput
+	cancelled bool
+	output    string
+	exitCode  int64
+}
+
+func (f *fakeSSM) SendCommandWithContext(_ aws.Context, input *ssm.SendCommandInput, _ ...request.Option) (*ssm.SendCommandOutput, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.sent = input
+	return &ssm.SendCommandOutput{Command: &ssm.Command{CommandId: aws.String("cmd-1")}}, nil
+}
+
+func (f *fakeSSM) GetCommandInvocationWithContext(_ aws.Context, input *ssm.GetCommandInvocationInput, _ ...request.Option) (*ssm.GetCommandInvocationOutput, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	// 첫 조회는 호출 정보가 아직 없다고 응답
+	f.polls++
+	if f.polls == 1 {
+		return nil, awserr.New(ssm.ErrCodeInvocationDoesNotExist, "not yet", nil)
+	}
+
+	status := f.statuses[len(f.statuses)-1]
+	if f.polls-2 < len(f.statuses) {
+		status = f.statuses[f.polls-2]
+	}
+	return &ssm.GetCommandInvocationOutput{
+		CommandId:             input.CommandId,
+		Status:                aws.String(status),
+		StandardOutputContent: aws.String(f.output),
+		StandardErrorContent:  aws.String(""),
+		ResponseCode:          aws.Int64(f.exitCode),
+	}, nil
+}
+
+func (f *fakeSSM) CancelCommand(*ssm.CancelCommandInput) (*ssm.CancelCommandOutput, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.cancelled = true
+	return &ssm.CancelCommandOutput{}, nil
+}
+
+func TestSSMNodeExec(t *testing.T) {
+	t.Parallel()
+
+	fake := &fakeSSM{
+		statuses: []string{ssm.CommandInvocationStatusInProgress, ssm.CommandInvocationStatusFailed},
+		output:   "kubelet inactive\n",
+		exitCode: 3,
+	}
+	node := helpers.NewSSMNodeExec(fake, "i-0123456789")
+	node.PollInterval = time.Millisecond
+
+	var stdout bytes.Buffer
+	result, err := node.Exec(context.Background(), helpers.NodeCommand{
+		Command: "systemctl is-active kubelet",
+		Sudo:    true,
+		Timeout: time.Minute,
+		Stdout:  &stdout,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 3, result.ExitCode)
+	assert.Equal(t, "kubelet inactive\n", result.Stdout)
+	assert.Equal(t, result.Stdout, stdout.String())
+
+	assert.Equal(t, "AWS-RunShellScript", aws.StringValue(fake.sent.DocumentName))
+	assert.Equal(t, []string{"i-0123456789"}, aws.StringValueSlice(fake.sent.InstanceIds))
+	assert.Equal(t, `sudo -n bash -c 'systemctl is-active kubelet'`, aws.StringValue(fake.sent.Parameters["commands"][0]))
+	assert.NotEmpty(t, fake.sent.Parameters["executionTimeout"])
+}
+
+func TestSSMNodeExecTimeout(t *testing.T) {
+	t.Parallel()
+
+	fake := &fakeSSM{statuses: []string{ssm.CommandInvocationStatusInProgress}}
+	node := helpers.NewSSMNodeExec(fake, "i-0123456789")
+	node.PollInterval = 10 * time.Millisecond
+
+	_, err := node.Exec(context.Background(), helpers.NodeCommand{Command: "sleep 600", Timeout: 100 * time.Millisecond})
+	require.Error(t, err)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Contains(t, err.Error(), "타임아웃")
+	assert.True(t, fake.cancelled, "타임아웃 시 SSM 명령을 취소해야 합니다")
+}
+
+func TestSSMNodeExecCancelled(t *testing.T) {
+	t.Parallel()
+
+	fake := &fakeSSM{statuses: []string{ssm.CommandInvocationStatusInProgress}}
+	node := helpers.NewSSMNodeExec(fake, "i-0123456789")
+	node.PollInterval = 10 * time.Millisecond
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(100*time.Millisecond, cancel)
+
+	_, err := node.Exec(ctx, helpers.NodeCommand{Command: "sleep 600", Timeout: time.Minute})
+	require.Error(t, err)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Contains(t, err.Error(), "취소", "취소는 타임아웃과 구분해 보고해야 합니다")
+	assert.NotContains(t, err.Error(), "타임아웃")
+	assert.True(t, fake.cancelled, "취소 시 SSM 명령도 취소해야 합니다")
+}
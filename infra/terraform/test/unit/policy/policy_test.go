@@ -0,0 +1,189 @@
+package policy
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/k8s-ec2-observability/test/helpers/allowlist"
+	"github.com/k8s-ec2-observability/test/helpers/policy"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	applicationsDir = "../../../../../manifests/applications"
+	linkerdDir      = "../../../../../manifests/linkerd"
+	stackValuesPath = "../../../../../manifests/observability/prometheus-stack-values.yml"
+)
+
+// 현재 매니페스트의 알려진 위반 (수정하면 목록에서 제거)
+var knownViolations = map[string]string{
+	"image-tag bookinfo/traffic-generator (traffic-generator.yml)":             "curl 이미지 버전 미고정",
+	"linkerd-inject bookinfo (bookinfo-nodeport.yml)":                          "Linkerd 안정화 전까지 주입 비활성화",
+	"nodeport-collision bookinfo/productpage-nodeport (bookinfo-nodeport.yml)": "bookinfo-with-linkerd.yml과 택일 배포",
+	"nodeport-collision bookinfo/productpage (bookinfo-with-linkerd.yml)":      "bookinfo-nodeport.yml과 택일 배포",
+}
+
+func violationKey(finding policy.Finding) string {
+	return fmt.Sprintf("%s %s (%s)", finding.Rule, finding.Name, filepath.Base(finding.Path))
+}
+
+func repositoryOptions(t *testing.T) policy.Options {
+	options := policy.DefaultOptions()
+	reserved, err := policy.ReservedNodePortsFromValues(stackValuesPath)
+	require.NoError(t, err)
+	options.ReservedNodePorts = reserved
+	return options
+}
+
+func TestRepositoryManifestsPolicy(t *testing.T) {
+	t.Parallel()
+
+	documents, err := policy.LoadManifests(applicationsDir, linkerdDir)
+	require.NoError(t, err)
+	require.NotEmpty(t, documents)
+
+	findings := policy.Evaluate(documents, repositoryOptions(t))
+	violations := make(map[string]string)
+	for _, finding := range findings {
+		t.Log(finding)
+		if finding.Severity == policy.SeverityError {
+			violations[violationKey(finding)] = finding.String()
+		}
+	}
+	allowlist.Check(t, "knownViolations", knownViolations, violations)
+}
+
+func TestDecodeManifest(t *testing.T) {
+	t.Parallel()
+
+	documents := policy.DecodeManifest("test.yml", []byte(`# 주석만 있는 문서
+---
+# Service
+apiVersion: v1
+kind: Service
+metadata:
+  name: web
+  namespace: demo
+spec:
+  ports:
+  - port: 80
+--- # 구분자 뒤 주석
+apiVersion: linkerd.io/v1alpha2
+kind: ServiceProfile
+metadata:
+  name: web
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: broken
+spec:
+  replicas: "many"
+`))
+	require.Len(t, documents, 3)
+
+	assert.Equal(t, "Service", documents[0].GVK.Kind)
+	assert.Equal(t, "demo/web", documents[0].Name())
+	assert.Equal(t, 4, documents[0].Line)
+	assert.NoError(t, documents[0].Err)
+
+	assert.Equal(t, "ServiceProfile", documents[1].GVK.Kind)
+	assert.Nil(t, documents[1].Object, "스킴에 없는 종류는 객체 없이 기록")
+	assert.NoError(t, documents[1].Err)
+
+	assert.Error(t, documents[2].Err)
+	findings := policy.Evaluate(documents, policy.DefaultOptions())
+	require.Len(t, findings, 1)
+	assert.Equal(t, policy.RuleDecode, findings[0].Rule)
+	assert.Equal(t, 18, findings[0].Line)
+}
+
+func TestEvaluateRules(t *testing.T) {
+	t.Parallel()
+
+	documents := policy.DecodeManifest("apps.yml", []byte(`apiVersion: v1
+kind: Namespace
+metadata:
+  name: bookinfo
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+  namespace: bookinfo
+spec:
+  selector:
+    matchLabels: {app: web}
+  template:
+    metadata:
+      labels: {app: web}
+      annotations:
+        linkerd.io/inject: disabled
+    spec:
+      initContainers:
+      - name: init
+        image: busybox
+        resources:
+          requests: {cpu: 10m, memory: 16Mi}
+          limits: {cpu: 10m, memory: 16Mi}
+      containers:
+      - name: web
+        image: registry.local:5000/web@sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef
+        resources:
+          requests: {cpu: 10m}
+        readinessProbe:
+          tcpSocket: {port: 80}
+---
+apiVersion: v1
+kind: Service
+metadata:
+  name: web
+  namespace: bookinfo
+spec:
+  type: NodePort
+  ports:
+  - port: 80
+    nodePort: 30300
+  - port: 81
+    nodePort: 8080
+`))
+
+	options := policy.DefaultOptions()
+	options.ReservedNodePorts = map[int32]string{30300: "grafana.service.nodePort"}
+	findings := policy.Evaluate(documents, options)
+	for _, finding := range findings {
+		t.Log(finding)
+	}
+
+	byRule := make(map[string][]policy.Finding)
+	for _, finding := range findings {
+		byRule[finding.Rule] = append(byRule[finding.Rule], finding)
+	}
+
+	require.Len(t, byRule[policy.RuleImageTag], 1, "digest로 고정한 이미지는 허용")
+	assert.Contains(t, byRule[policy.RuleImageTag][0].Message, "busybox")
+
+	require.Len(t, byRule[policy.RuleResources], 1)
+	assert.Contains(t, byRule[policy.RuleResources][0].Message, "requests.memory, limits.cpu, limits.memory")
+
+	require.Len(t, byRule[policy.RuleProbes], 1, "init 컨테이너는 프로브 검사 제외")
+	assert.Equal(t, policy.SeverityWarning, byRule[policy.RuleProbes][0].Severity)
+	assert.Contains(t, byRule[policy.RuleProbes][0].Message, "livenessProbe")
+	assert.NotContains(t, byRule[policy.RuleProbes][0].Message, "readinessProbe")
+
+	assert.Len(t, byRule[policy.RuleLinkerdInject], 2, "네임스페이스 어노테이션 누락 + Pod 템플릿 disabled")
+	assert.Len(t, byRule[policy.RuleNodePortRange], 1)
+	require.Len(t, byRule[policy.RuleNodePortCollision], 1)
+	assert.Contains(t, byRule[policy.RuleNodePortCollision][0].Message, "grafana.service.nodePort")
+}
+
+func TestReservedNodePortsFromValues(t *testing.T) {
+	t.Parallel()
+
+	reserved, err := policy.ReservedNodePortsFromValues(stackValuesPath)
+	require.NoError(t, err)
+	require.Contains(t, reserved, int32(30300))
+	assert.Contains(t, reserved[30300], "grafana.service.nodePort")
+}
@@ -0,0 +1,179 @@
+package prom
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/k8s-ec2-observability/test/helpers/alerts"
+	"github.com/k8s-ec2-observability/test/helpers/prom"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const customAlertRulesPath = "../../../../../manifests/observability/custom-alert-rules.yaml"
+
+const targetsResponse = `{"status":"success","data":{"activeTargets":[
+  {"labels":{"job":"node-exporter","instance":"10.0.1.10:9100"},"scrapePool":"serviceMonitor/monitoring/prometheus-prometheus-node-exporter/0","scrapeUrl":"http://10.0.1.10:9100/metrics","lastError":"","lastScrape":"2024-01-01T00:00:00Z","lastScrapeDuration":0.01,"health":"up"},
+  {"labels":{"job":"node-exporter","instance":"10.0.1.20:9100"},"scrapeUrl":"http://10.0.1.20:9100/metrics","lastError":"connection refused","health":"down"},
+  {"labels":{"job":"kube-state-metrics","instance":"192.168.1.5:8080"},"health":"up"}
+],"droppedTargets":[{"discoveredLabels":{"__address__":"10.0.1.10:10249"}}]}}`
+
+// 경로별 응답을 돌려주는 Prometheus 대역 서버
+func newFakePrometheus(t *testing.T, responses map[string]string, requests chan<- *http.Request) *prom.Client {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if requests != nil {
+			requests <- r
+		}
+		body, ok := responses[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			body = `{"status":"error","errorType":"not_found","error":"unknown path"}`
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(body))
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := prom.NewClient(server.URL+"/", nil)
+	require.NoError(t, err)
+	return client
+}
+
+func TestNewClientRequiresAddress(t *testing.T) {
+	t.Parallel()
+
+	_, err := prom.NewClient("localhost:9090", nil)
+	assert.Error(t, err, "scheme 없는 주소는 거부")
+}
+
+func TestTargets(t *testing.T) {
+	t.Parallel()
+
+	requests := make(chan *http.Request, 1)
+	client := newFakePrometheus(t, map[string]string{"/api/v1/targets": targetsResponse}, requests)
+
+	targets, err := client.Targets(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "any", (<-requests).URL.Query().Get("state"))
+
+	require.Len(t, targets.Active, 3)
+	assert.Len(t, targets.Dropped, 1)
+	assert.Len(t, targets.ByJob(prom.NodeExporterJob), 2)
+	assert.Equal(t, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), targets.Active[0].LastScrape)
+
+	problems := prom.CheckNodeExporterTargets(targets, []string{"10.0.1.10", "10.0.1.20", "10.0.1.30"})
+	require.Len(t, problems, 2)
+	assert.Contains(t, problems[0], "10.0.1.20")
+	assert.Contains(t, problems[0], "connection refused")
+	assert.Contains(t, problems[1], "10.0.1.30")
+
+	assert.Empty(t, prom.CheckJobUp(targets, prom.KubeStateMetricsJob))
+	assert.NotEmpty(t, prom.CheckJobUp(targets, "prometheus-operator"))
+}
+
+func TestQuery(t *testing.T) {
+	t.Parallel()
+
+	requests := make(chan *http.Request, 2)
+	client := newFakePrometheus(t, map[string]string{
+		"/api/v1/query": `{"status":"success","data":{"resultType":"vector","result":[
+		  {"metric":{"__name__":"up","job":"kube-state-metrics"},"value":[1704067200.5,"1"]}
+		]}}`,
+		"/api/v1/query_range": `{"status":"success","data":{"resultType":"matrix","result":[
+		  {"metric":{"job":"node-exporter"},"values":[[1704067200,"0.5"],[1704067260,"NaN"]]}
+		]}}`,
+	}, requests)
+
+	at := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	result, err := client.Query(context.Background(), `up{job="kube-state-metrics"}`, at)
+	require.NoError(t, err)
+	request := <-requests
+	assert.Equal(t, `up{job="kube-state-metrics"}`, request.URL.Query().Get("query"))
+	assert.Equal(t, "1704067200", request.URL.Query().Get("time"))
+
+	assert.Equal(t, prom.ResultVector, result.ResultType)
+	require.Len(t, result.Samples, 1)
+	assert.Equal(t, 1.0, result.Samples[0].Value)
+	assert.Equal(t, at.Add(500*time.Millisecond), result.Samples[0].Timestamp)
+
+	result, err = client.QueryRange(context.Background(), "rate(node_cpu_seconds_total[5m])", prom.Range{Start: at, End: at.Add(time.Minute), Step: 30 * time.Second})
+	require.NoError(t, err)
+	request = <-requests
+	assert.Equal(t, "30", request.URL.Query().Get("step"))
+	assert.Equal(t, "1704067260", request.URL.Query().Get("end"))
+
+	assert.Equal(t, prom.ResultMatrix, result.ResultType)
+	require.Len(t, result.Series, 1)
+	require.Len(t, result.Series[0].Points, 2)
+	assert.Equal(t, 0.5, result.Series[0].Points[0].Value)
+	assert.True(t, result.Series[0].Points[1].Value != result.Series[0].Points[1].Value, "NaN 값 파싱")
+}
+
+func TestQueryError(t *testing.T) {
+	t.Parallel()
+
+	client := newFakePrometheus(t, map[string]string{
+		"/api/v1/query": `{"status":"error","errorType":"bad_data","error":"parse error at char 3"}`,
+	}, nil)
+
+	_, err := client.Query(context.Background(), "up{", time.Time{})
+	var apiErr *prom.APIError
+	require.True(t, errors.As(err, &apiErr))
+	assert.Equal(t, "bad_data", apiErr.ErrorType)
+	assert.Contains(t, apiErr.Message, "parse error")
+
+	_, err = client.Rules(context.Background())
+	require.True(t, errors.As(err, &apiErr))
+	assert.Equal(t, http.StatusNotFound, apiErr.StatusCode)
+}
+
+func TestRulesLoaded(t *testing.T) {
+	t.Parallel()
+
+	rule, err := alerts.LoadPrometheusRule(customAlertRulesPath)
+	require.NoError(t, err)
+
+	client := newFakePrometheus(t, map[string]string{
+		"/api/v1/rules": `{"status":"success","data":{"groups":[
+		  {"name":"critical-system-alerts","file":"/etc/prometheus/rules/prometheus-kube-prometheus-prometheus-rulefiles-0/monitoring-k8s-ec2-observability-alerts-1.yaml",
+		   "rules":[{"name":"MasterNodeHighCPU","type":"alerting","health":"err","lastError":"many-to-many matching not allowed"}]},
+		  {"name":"warning-system-alerts","file":"/etc/prometheus/rules/other.yaml","rules":[]}
+		]}}`,
+	}, nil)
+
+	groups, err := client.Rules(context.Background())
+	require.NoError(t, err)
+	require.Len(t, groups, 2)
+
+	problems := prom.CheckRulesLoaded(groups, rule)
+	for _, problem := range problems {
+		t.Log(problem)
+	}
+	assert.Contains(t, problems, "critical-system-alerts/MasterNodeHighCPU 규칙 평가 오류: many-to-many matching not allowed")
+	assert.Contains(t, problems, "critical-system-alerts/MasterNodeHighMemory 규칙이 로드되지 않았습니다")
+	assert.Contains(t, problems, "규칙 그룹 bookinfo-application-alerts가 로드되지 않았습니다")
+	assert.Contains(t, problems, "warning-system-alerts/HighCPUUsage 규칙이 로드되지 않았습니다", "그룹이 하나뿐이면 파일 이름과 무관하게 사용")
+}
+
+func TestAlerts(t *testing.T) {
+	t.Parallel()
+
+	client := newFakePrometheus(t, map[string]string{
+		"/api/v1/alerts": `{"status":"success","data":{"alerts":[
+		  {"labels":{"alertname":"PodRestartLoop","severity":"warning"},"state":"firing","activeAt":"2024-01-01T00:00:00Z","value":"3e+00"},
+		  {"labels":{"alertname":"LowDiskSpace"},"state":"pending"},
+		  {"labels":{"alertname":"PodRestartLoop","pod":"b"},"state":"firing"}
+		]}}`,
+	}, nil)
+
+	alertList, err := client.Alerts(context.Background())
+	require.NoError(t, err)
+	require.Len(t, alertList, 3)
+	require.NotNil(t, alertList[0].ActiveAt)
+	assert.Nil(t, alertList[1].ActiveAt)
+	assert.Equal(t, []string{"PodRestartLoop"}, prom.FiringAlerts(alertList))
+}
@@ -0,0 +1,65 @@
+package ratelimit
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/k8s-ec2-observability/test/helpers"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApplyLimiterBoundsConcurrency(t *testing.T) {
+	t.Parallel()
+
+	limiter := helpers.NewApplyLimiter(2)
+	assert.Equal(t, 2, limiter.Limit())
+
+	var running, peak int32
+	var wg sync.WaitGroup
+	for i := 0; i < 6; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			release := limiter.Acquire(t)
+			defer release()
+			now := atomic.AddInt32(&running, 1)
+			for {
+				old := atomic.LoadInt32(&peak)
+				if now <= old || atomic.CompareAndSwapInt32(&peak, old, now) {
+					break
+				}
+			}
+			time.Sleep(20 * time.Millisecond)
+			atomic.AddInt32(&running, -1)
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, int32(2), atomic.LoadInt32(&peak), "동시에 두 개까지만 실행해야 합니다")
+}
+
+func TestApplyLimiterReleaseIsIdempotent(t *testing.T) {
+	t.Parallel()
+
+	limiter := helpers.NewApplyLimiter(1)
+	release := limiter.Acquire(t)
+	release()
+	release() // 두 번 반납해도 다른 슬롯을 빼앗지 않음
+
+	other := limiter.Acquire(t)
+	acquired := make(chan struct{})
+	go func() {
+		defer close(acquired)
+		limiter.Acquire(t)()
+	}()
+
+	select {
+	case <-acquired:
+		t.Fatal("슬롯이 하나뿐이므로 반납 전에는 얻을 수 없어야 합니다")
+	case <-time.After(50 * time.Millisecond):
+	}
+	other()
+	<-acquired
+}
@@ -0,0 +1,129 @@
+package ratelimit
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/client"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/kms"
+	"github.com/k8s-ec2-observability/test/helpers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGovernorBurstThenRate(t *testing.T) {
+	t.Parallel()
+
+	governor := helpers.NewRateGovernor(map[string]helpers.RateLimit{
+		"kms": {RequestsPerSecond: 2, Burst: 3},
+	}, helpers.RateLimit{RequestsPerSecond: 1, Burst: 1})
+
+	for i := 0; i < 3; i++ {
+		assert.Zero(t, governor.Reserve("kms"), "버스트 안의 요청 %d은 기다리지 않아야 합니다", i)
+	}
+	// 버스트를 다 쓰면 초당 2개 속도로 예약 (0.5초, 1초 ...)
+	assert.InDelta(t, 500*time.Millisecond, governor.Reserve("kms"), float64(50*time.Millisecond))
+	assert.InDelta(t, time.Second, governor.Reserve("kms"), float64(50*time.Millisecond))
+
+	// 서비스마다 버킷이 따로 있고, 한도가 없는 서비스는 기본값 사용
+	assert.Zero(t, governor.Reserve("ec2"))
+	assert.InDelta(t, time.Second, governor.Reserve("ec2"), float64(50*time.Millisecond))
+}
+
+func TestGovernorAdaptsToThrottling(t *testing.T) {
+	t.Parallel()
+
+	governor := helpers.NewRateGovernor(map[string]helpers.RateLimit{
+		"kms": {RequestsPerSecond: 16, Burst: 16},
+	}, helpers.RateLimit{RequestsPerSecond: 5, Burst: 5})
+
+	governor.Throttled("kms")
+	assert.Equal(t, 8.0, governor.Rate("kms"), "스로틀링이면 속도를 절반으로 줄여야 합니다")
+	assert.Equal(t, 5.0, governor.Rate("ec2"), "다른 서비스 속도는 그대로여야 합니다")
+
+	// 스로틀링 직후에는 남은 버스트 없이 줄어든 속도로 대기
+	assert.InDelta(t, time.Second/8, governor.Reserve("kms"), float64(20*time.Millisecond))
+
+	for i := 0; i < 10; i++ {
+		governor.Throttled("kms")
+	}
+	assert.Equal(t, 1.0, governor.Rate("kms"), "설정 속도의 1/16 밑으로는 줄이지 않아야 합니다")
+
+	for i := 0; i < 100; i++ {
+		governor.Succeeded("kms")
+	}
+	assert.Equal(t, 16.0, governor.Rate("kms"), "성공이 이어지면 설정 속도까지만 회복해야 합니다")
+}
+
+func TestGovernorConcurrentReservations(t *testing.T) {
+	t.Parallel()
+
+	governor := helpers.NewRateGovernor(nil, helpers.RateLimit{RequestsPerSecond: 100, Burst: 10})
+
+	var wg sync.WaitGroup
+	delays := make([]time.Duration, 50)
+	for i := range delays {
+		i := i
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			delays[i] = governor.Reserve("sts")
+		}()
+	}
+	wg.Wait()
+
+	// 예약은 겹치지 않으므로 가장 늦은 요청은 (50-10)/100초 뒤
+	var longest time.Duration
+	for _, delay := range delays {
+		if delay > longest {
+			longest = delay
+		}
+	}
+	assert.InDelta(t, 400*time.Millisecond, longest, float64(50*time.Millisecond))
+}
+
+// SDK 클라이언트에 연결하면 스로틀링 응답을 재시도하고 버킷 속도에 반영
+func TestGovernorAttachedToClient(t *testing.T) {
+	t.Parallel()
+
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/x-amz-json-1.1")
+		if atomic.AddInt32(&calls, 1) <= 2 {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"__type":"ThrottlingException","message":"Rate exceeded"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"KeyMetadata":{"KeyId":"1234abcd"}}`))
+	}))
+	t.Cleanup(server.Close)
+
+	config := request.WithRetryer(aws.NewConfig().
+		WithRegion("ap-northeast-2").
+		WithEndpoint(server.URL).
+		WithCredentials(credentials.NewStaticCredentials("AKID", "SECRET", "")),
+		client.DefaultRetryer{NumMaxRetries: 3, MinThrottleDelay: time.Millisecond, MaxThrottleDelay: 5 * time.Millisecond})
+	sess, err := session.NewSession(config)
+	require.NoError(t, err)
+
+	governor := helpers.NewRateGovernor(map[string]helpers.RateLimit{
+		"kms": {RequestsPerSecond: 1000, Burst: 10},
+	}, helpers.RateLimit{RequestsPerSecond: 1000, Burst: 10})
+	governor.Attach(&sess.Handlers)
+
+	out, err := kms.New(sess).DescribeKey(&kms.DescribeKeyInput{KeyId: aws.String("1234abcd")})
+	require.NoError(t, err, "스로틀링은 재시도로 복구되어야 합니다")
+	assert.Equal(t, "1234abcd", aws.StringValue(out.KeyMetadata.KeyId))
+	assert.Equal(t, int32(3), atomic.LoadInt32(&calls))
+
+	// 스로틀링 두 번(1000 → 250), 성공 한 번(+50)
+	assert.Equal(t, 300.0, governor.Rate("kms"))
+}
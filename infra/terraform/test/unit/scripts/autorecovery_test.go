@@ -0,0 +1,313 @@
+package scripts
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/k8s-ec2-observability/test/helpers/scripttest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const autoRecoveryScript = "../../../../../scripts/automation/auto-recovery-system-fixed.sh"
+
+const testMasterNode = "ip-10-0-1-10"
+
+// 가짜 kubectl/linkerd가 돌려줄 클러스터 상태
+type clusterState struct {
+	Pods           []pod
+	MasterCPU      int
+	LinkerdChecks  int    // linkerd check --proxy의 √ 개수
+	PrometheusPod  string // 파드 phase (빈 값이면 파드 없음)
+	GrafanaPod     string
+	LinkerdPodList []pod
+}
+
+type pod struct {
+	Namespace string
+	Name      string
+	Phase     string
+	OOMKilled bool
+	Status    string // kubectl get pods 표의 STATUS 열 (빈 값이면 Phase)
+}
+
+func healthyCluster() clusterState {
+	return clusterState{
+		Pods: []pod{
+			{Namespace: "bookinfo", Name: "productpage-v1-6b746f74dc-abcde", Phase: "Running"},
+			{Namespace: "bookinfo", Name: "ratings-v1-b6994bb9-fghij", Phase: "Running"},
+			{Namespace: "monitoring", Name: "prometheus-prometheus-node-exporter-xyz12", Phase: "Running"},
+		},
+		MasterCPU:     35,
+		LinkerdChecks: 12,
+		PrometheusPod: "Running",
+		GrafanaPod:    "Running",
+		LinkerdPodList: []pod{
+			{Namespace: "linkerd", Name: "linkerd-destination-7d5b8c9f4-abcde", Phase: "Running"},
+		},
+	}
+}
+
+func podListJSON(pods []pod) string {
+	items := make([]map[string]interface{}, 0, len(pods))
+	for _, p := range pods {
+		status := map[string]interface{}{"phase": p.Phase}
+		if p.OOMKilled {
+			status["containerStatuses"] = []interface{}{map[string]interface{}{
+				"name":      "app",
+				"lastState": map[string]interface{}{"terminated": map[string]interface{}{"reason": "OOMKilled", "exitCode": 137}},
+			}}
+		}
+		items = append(items, map[string]interface{}{
+			"metadata": map[string]interface{}{"namespace": p.Namespace, "name": p.Name},
+			"status":   status,
+		})
+	}
+	data, _ := json.Marshal(map[string]interface{}{"kind": "List", "items": items})
+	return string(data)
+}
+
+func podTable(pods []pod, header bool) string {
+	var b strings.Builder
+	if header {
+		b.WriteString("NAMESPACE   NAME   READY   STATUS   RESTARTS   AGE\n")
+	}
+	for _, p := range pods {
+		status := p.Status
+		if status == "" {
+			status = p.Phase
+		}
+		fmt.Fprintf(&b, "%s   %s   1/1   %s   0   1h\n", p.Namespace, p.Name, status)
+	}
+	return b.String()
+}
+
+func filterPods(pods []pod, phase string) []pod {
+	var filtered []pod
+	for _, p := range pods {
+		if p.Phase == phase {
+			filtered = append(filtered, p)
+		}
+	}
+	return filtered
+}
+
+// 상태에 맞는 가짜 kubectl/linkerd/helm 등록
+func (c clusterState) install(h *scripttest.Harness) {
+	h.Fake("kubectl",
+		scripttest.Response{Args: "get pods -A -o json", Stdout: podListJSON(c.Pods)},
+		scripttest.Response{Args: "get pods -A --field-selector=status.phase=Pending -o json", Stdout: podListJSON(filterPods(c.Pods, "Pending"))},
+		scripttest.Response{Args: "get pods -A --field-selector=status.phase=Pending --no-headers", Stdout: podTable(filterPods(c.Pods, "Pending"), false)},
+		scripttest.Response{Args: "get pods -A --field-selector=status.phase=Running --no-headers", Stdout: podTable(filterPods(c.Pods, "Running"), false)},
+		scripttest.Response{Args: "get pods -A --no-headers", Stdout: podTable(c.Pods, false)},
+		scripttest.Response{Args: "get pods -A", Stdout: podTable(c.Pods, true)},
+		scripttest.Response{Args: "top node *", Stdout: fmt.Sprintf("%s   1890m   %d%%   2900Mi   75%%\n", testMasterNode, c.MasterCPU)},
+		scripttest.Response{Args: "get pods -n linkerd -o json", Stdout: podListJSON(c.LinkerdPodList)},
+		scripttest.Response{Args: "get pods -n monitoring -l app.kubernetes.io/name=prometheus -o jsonpath=*", Stdout: c.PrometheusPod},
+		scripttest.Response{Args: "get pods -n monitoring -l app.kubernetes.io/name=grafana -o jsonpath=*", Stdout: c.GrafanaPod},
+	)
+	h.Fake("linkerd", scripttest.Response{Args: "check --proxy", Stdout: strings.Repeat("√ check passed\n", c.LinkerdChecks)})
+	h.Fake("helm")
+}
+
+func runAutoRecovery(t *testing.T, state clusterState, args ...string) *scripttest.Result {
+	h := scripttest.New(t)
+	h.Setenv("MASTER_NODE", testMasterNode)
+	h.Setenv("LOG_FILE", filepath.Join(h.Dir, "auto-recovery.log"))
+	state.install(h)
+
+	result := h.Run(autoRecoveryScript, args...)
+	t.Log(result.Stdout)
+	require.Equal(t, 0, result.ExitCode, "stderr: %s", result.Stderr)
+	return result
+}
+
+// 클러스터 상태를 바꾸는 kubectl 호출 (delete, patch, scale)
+func mutations(result *scripttest.Result) []string {
+	var lines []string
+	for _, call := range result.CallsTo("kubectl") {
+		if len(call.Args) > 0 && (call.Args[0] == "delete" || call.Args[0] == "patch" || call.Args[0] == "scale") {
+			lines = append(lines, call.Line())
+		}
+	}
+	return lines
+}
+
+func TestAutoRecoveryHealthyCluster(t *testing.T) {
+	t.Parallel()
+
+	result := runAutoRecovery(t, healthyCluster())
+
+	assert.Empty(t, mutations(result))
+	assert.Empty(t, result.CallsTo("helm"))
+	assert.Contains(t, result.Stdout, "✅ OOMKilled Pod 없음")
+	assert.Contains(t, result.Stdout, "✅ Pending Pod 없음")
+	assert.Contains(t, result.Stdout, "✅ Linkerd 상태 정상")
+	assert.Contains(t, result.Stdout, "✅ 모니터링 스택 정상")
+	assert.Contains(t, result.Stdout, "전체 Pod: 3개")
+	assert.Contains(t, result.Stdout, "실행 중: 3개")
+	assert.Contains(t, result.Stdout, "오류: 0개")
+	assert.Contains(t, result.Stdout, "성공률: 100%")
+}
+
+func TestAutoRecoveryDeletesOOMKilledPods(t *testing.T) {
+	t.Parallel()
+
+	state := healthyCluster()
+	state.Pods[1].OOMKilled = true
+	state.Pods[2].OOMKilled = true // exporter는 제외 대상
+
+	result := runAutoRecovery(t, state)
+
+	assert.Equal(t, []string{"kubectl delete pod ratings-v1-b6994bb9-fghij -n bookinfo"}, mutations(result))
+	assert.Contains(t, result.Stdout, "🚨 OOMKilled Pod 발견")
+}
+
+func TestAutoRecoveryScalesDownOnPendingWithHighCPU(t *testing.T) {
+	t.Parallel()
+
+	state := healthyCluster()
+	state.Pods = append(state.Pods, pod{Namespace: "bookinfo", Name: "reviews-v3-5c8d8b6f7-qwert", Phase: "Pending"})
+	state.MasterCPU = 95
+
+	result := runAutoRecovery(t, state)
+
+	assert.Equal(t, []string{
+		"kubectl scale deployment reviews-v2 -n bookinfo --replicas=0",
+		"kubectl scale deployment reviews-v3 -n bookinfo --replicas=0",
+	}, mutations(result))
+	assert.Contains(t, result.Stdout, "Pending Pod 1개 발견")
+	assert.Contains(t, result.Stdout, "마스터노드 CPU 사용률: 95%")
+	assert.Contains(t, result.Stdout, "대기 중: 1개")
+	assert.Contains(t, result.Stdout, "성공률: 75%")
+
+	var top []string
+	for _, call := range result.CallsTo("kubectl") {
+		if call.Args[0] == "top" {
+			top = append(top, call.Line())
+		}
+	}
+	assert.Equal(t, []string{"kubectl top node " + testMasterNode + " --no-headers"}, top)
+}
+
+func TestAutoRecoveryKeepsPendingPodsWithLowCPU(t *testing.T) {
+	t.Parallel()
+
+	state := healthyCluster()
+	state.Pods = append(state.Pods, pod{Namespace: "bookinfo", Name: "reviews-v3-5c8d8b6f7-qwert", Phase: "Pending"})
+	state.MasterCPU = 60
+
+	result := runAutoRecovery(t, state)
+
+	assert.Empty(t, mutations(result))
+	assert.NotContains(t, result.Stdout, "리소스 최적화 실행")
+}
+
+func TestAutoRecoveryRestartsFailedLinkerdPods(t *testing.T) {
+	t.Parallel()
+
+	state := healthyCluster()
+	state.LinkerdChecks = 4
+	state.LinkerdPodList = []pod{
+		{Namespace: "linkerd", Name: "linkerd-destination-7d5b8c9f4-abcde", Phase: "Running"},
+		{Namespace: "linkerd", Name: "linkerd-identity-6f9b7c8d5-fghij", Phase: "Pending"},
+		{Namespace: "linkerd", Name: "linkerd-heartbeat-28391230-klmno", Phase: "Failed"},
+	}
+
+	result := runAutoRecovery(t, state)
+
+	assert.Equal(t, []string{"kubectl delete pod linkerd-identity-6f9b7c8d5-fghij -n linkerd"}, mutations(result))
+	assert.Contains(t, result.Stdout, "Linkerd 정상 체크: 4개")
+	assert.Contains(t, result.Stdout, "🚨 Linkerd 상태 이상 감지")
+}
+
+func TestAutoRecoveryRestartsMonitoringStack(t *testing.T) {
+	t.Parallel()
+
+	state := healthyCluster()
+	state.PrometheusPod = "Pending"
+
+	result := runAutoRecovery(t, state)
+
+	assert.Equal(t, []string{"kubectl delete pods -n monitoring -l app.kubernetes.io/name=prometheus"}, mutations(result))
+	assert.Contains(t, result.Stdout, "Prometheus: Pending, Grafana: Running")
+}
+
+func TestAutoRecoveryReportCountsErrorPods(t *testing.T) {
+	t.Parallel()
+
+	state := healthyCluster()
+	state.Pods = append(state.Pods, pod{Namespace: "bookinfo", Name: "details-v1-79f774bdb9-zxcvb", Phase: "Running", Status: "CrashLoopBackOff"})
+
+	result := runAutoRecovery(t, state)
+	assert.Contains(t, result.Stdout, "오류: 1개")
+}
+
+func TestAutoRecoveryEmptyCluster(t *testing.T) {
+	t.Parallel()
+
+	state := healthyCluster()
+	state.Pods = nil
+
+	result := runAutoRecovery(t, state)
+	assert.Contains(t, result.Stdout, "전체 Pod: 0개")
+	assert.Contains(t, result.Stdout, "성공률: N/A")
+}
+
+func TestAutoRecoveryDryRun(t *testing.T) {
+	t.Parallel()
+
+	state := healthyCluster()
+	state.Pods[1].OOMKilled = true
+	state.PrometheusPod = "Failed"
+	state.GrafanaPod = ""
+
+	result := runAutoRecovery(t, state, "--dry-run")
+
+	assert.Empty(t, mutations(result), "DRY RUN은 kubectl 변경 명령을 실행하지 않음")
+	assert.Contains(t, result.Stdout, "🔍 [DRY RUN] OOMKilled Pod 재시작: kubectl delete pod ratings-v1-b6994bb9-fghij -n bookinfo")
+	assert.Contains(t, result.Stdout, "🔍 [DRY RUN] Prometheus 재시작")
+	assert.Contains(t, result.Stdout, "🔍 [DRY RUN] Grafana 재시작")
+	assert.Contains(t, result.Stdout, "DRY RUN 모드 완료")
+}
+
+func TestForceToMaster(t *testing.T) {
+	t.Parallel()
+
+	h := scripttest.New(t)
+	h.Setenv("MASTER_NODE", testMasterNode)
+	h.Setenv("LOG_FILE", filepath.Join(h.Dir, "auto-recovery.log"))
+	healthyCluster().install(h)
+
+	result := h.RunFunction(autoRecoveryScript, "force_to_master", "bookinfo", "reviews-v3")
+	require.Equal(t, 0, result.ExitCode, "stderr: %s", result.Stderr)
+	assert.NotContains(t, result.Stdout, "Auto Recovery System 시작", "source 시 main은 실행하지 않음")
+
+	calls := result.CallsTo("kubectl")
+	require.Len(t, calls, 1)
+	require.Equal(t, []string{"patch", "deployment", "reviews-v3", "-n", "bookinfo", "-p"}, calls[0].Args[:6])
+
+	var patch struct {
+		Spec struct {
+			Template struct {
+				Spec struct {
+					NodeSelector map[string]string `json:"nodeSelector"`
+					Tolerations  []struct {
+						Key      string `json:"key"`
+						Operator string `json:"operator"`
+						Effect   string `json:"effect"`
+					} `json:"tolerations"`
+				} `json:"spec"`
+			} `json:"template"`
+		} `json:"spec"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(calls[0].Args[6]), &patch), "패치 본문은 유효한 JSON")
+	podSpec := patch.Spec.Template.Spec
+	assert.Equal(t, map[string]string{"kubernetes.io/hostname": testMasterNode}, podSpec.NodeSelector)
+	require.Len(t, podSpec.Tolerations, 1)
+	assert.Equal(t, "node-role.kubernetes.io/control-plane", podSpec.Tolerations[0].Key)
+	assert.Equal(t, "NoSchedule", podSpec.Tolerations[0].Effect)
+}
@@ -0,0 +1,449 @@
+package scripts
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/k8s-ec2-observability/test/helpers"
+	"github.com/k8s-ec2-observability/test/helpers/scripttest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	combinedSettingsScript = "../../../../../scripts/combined_settings.sh"
+	workerSetupScript      = "../../../../../scripts/worker_setup.sh"
+)
+
+const (
+	testNodeIP      = "10.0.1.50"
+	testMasterIP    = "10.0.1.34"
+	testJoinCommand = "kubeadm join 10.0.1.34:6443 --token abcdef.0123456789abcdef --discovery-token-ca-cert-hash sha256:1234"
+)
+
+// 업스트림 custom-resources.yaml의 기본 IP 풀 (install_cni가 POD_CIDR로 치환)
+const calicoCustomResources = `apiVersion: operator.tigera.io/v1
+kind: Installation
+metadata:
+  name: default
+spec:
+  calicoNetwork:
+    ipPools:
+    - blockSize: 26
+      cidr: 192.168.0.0/16
+`
+
+// simulatedHost 격리된 루트 디렉터리와 가짜 시스템 명령으로 구성한 EC2 호스트
+type simulatedHost struct {
+	h    *scripttest.Harness
+	root string // K8S_SETUP_ROOT (스크립트의 /etc, /home/ubuntu 등이 이 아래에 생성)
+}
+
+func newSimulatedHost(t *testing.T) *simulatedHost {
+	h := scripttest.New(t)
+	host := &simulatedHost{h: h, root: filepath.Join(h.Dir, "root")}
+	for _, dir := range []string{
+		"home/ubuntu/.ssh", "etc/modules-load.d", "etc/sysctl.d", "etc/kubernetes", "tmp", "var/log",
+	} {
+		require.NoError(t, os.MkdirAll(filepath.Join(host.root, dir), 0o755))
+	}
+	h.Setenv("K8S_SETUP_ROOT", host.root)
+
+	// EC2 메타데이터와 Calico 매니페스트 다운로드
+	h.Script("curl", fmt.Sprintf(`case "$*" in
+  *local-ipv4) echo %s ;;
+  *public-ipv4) echo 203.0.113.20 ;;
+  "-LO "*/tigera-operator.yaml) echo "kind: Namespace" > tigera-operator.yaml ;;
+  "-LO "*/custom-resources.yaml) cat > custom-resources.yaml <<'YAML'
+%sYAML
+  ;;
+esac
+`, testNodeIP, calicoCustomResources))
+	h.Script("sudo", "[[ \"${1:-}\" == -E ]] && shift\nexec \"$@\"\n")
+	for _, name := range []string{"modprobe", "sysctl", "apt-get", "apt-mark", "systemctl", "containerd", "gpg", "chown", "sleep"} {
+		h.Fake(name)
+	}
+	host.resources(2, 2)
+	host.cluster(true)
+	h.Fake("kubeadm", scripttest.Response{Args: "token create --print-join-command", Stdout: testJoinCommand + "\n"})
+	return host
+}
+
+// resources nproc/free/netstat가 보고할 CPU, 메모리(GB), 사용 중인 포트
+func (s *simulatedHost) resources(cpuCores, memoryGB int, listening ...int) {
+	var netstat strings.Builder
+	netstat.WriteString("Active Internet connections (only servers)\nProto Recv-Q Send-Q Local Address           Foreign Address         State\n")
+	netstat.WriteString("tcp        0      0 0.0.0.0:22              0.0.0.0:*               LISTEN\n")
+	for _, port := range listening {
+		fmt.Fprintf(&netstat, "tcp6       0      0 :::%d                 :::*                    LISTEN\n", port)
+	}
+
+	s.h.Fake("nproc", scripttest.Response{Args: "*", Stdout: fmt.Sprintf("%d\n", cpuCores)})
+	s.h.Fake("free", scripttest.Response{Args: "*", Stdout: fmt.Sprintf(
+		"               total        used        free      shared  buff/cache   available\nMem:               %d           0           %d           0           0           %d\nSwap:              0           0           0\n",
+		memoryGB, memoryGB, memoryGB)})
+	s.h.Fake("netstat", scripttest.Response{Args: "*", Stdout: netstat.String()})
+}
+
+// cluster Calico 설치 상태를 파일로 추적하는 가짜 kubectl
+//
+// create -f로 calico-system 네임스페이스가 생기고 delete -f로 사라지며,
+// podsReady가 false면 kubectl wait가 항상 시간 초과로 실패합니다.
+func (s *simulatedHost) cluster(podsReady bool) {
+	waitExit := 0
+	if !podsReady {
+		waitExit = 1
+	}
+	state := filepath.Join(s.h.Dir, "calico-installed")
+	s.h.Script("kubectl", fmt.Sprintf(`state=%q
+case "$*" in
+  "create -f "*) touch "$state" ;;
+  "delete -f "*) rm -f "$state" ;;
+  "get ns calico-system") [[ -f "$state" ]] || { echo 'Error from server (NotFound): namespaces "calico-system" not found' >&2; exit 1; } ;;
+  "wait "*)
+    if [[ %d -ne 0 ]]; then
+      echo 'error: timed out waiting for the condition on pods/calico-node-abcde' >&2
+      exit %d
+    fi ;;
+  "get nodes -o wide") echo "ip-10-0-1-50   Ready    control-plane   5m   v1.31.0   %s" ;;
+esac
+`, state, waitExit, waitExit, testNodeIP))
+}
+
+func (s *simulatedHost) path(rel string) string {
+	return filepath.Join(s.root, rel)
+}
+
+func (s *simulatedHost) read(t *testing.T, rel string) string {
+	data, err := os.ReadFile(s.path(rel))
+	require.NoError(t, err)
+	return string(data)
+}
+
+// runFunction combined_settings.sh를 source한 뒤 함수 하나만 실행
+func (s *simulatedHost) runFunction(t *testing.T, function string) *scripttest.Result {
+	result := s.h.RunFunction(combinedSettingsScript, function)
+	t.Logf("%s:\n%s%s", function, result.Stdout, result.Stderr)
+	return result
+}
+
+// timeline 스크립트 로그를 부트스트랩 진행 파서로 해석 (로그 문구와 파서 마커의 계약 확인)
+func (s *simulatedHost) timeline(t *testing.T) *helpers.BootstrapTimeline {
+	return helpers.ParseBootstrapLog(s.read(t, "home/ubuntu/combined_settings.log"))
+}
+
+// 설치 상태를 바꾸는 kubectl 호출
+func kubectlMutations(result *scripttest.Result) []string {
+	var lines []string
+	for _, call := range result.CallsTo("kubectl") {
+		if call.Args[0] == "create" || call.Args[0] == "delete" || call.Args[0] == "wait" {
+			lines = append(lines, call.Line())
+		}
+	}
+	return lines
+}
+
+func TestVerifySystemRequirements(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		cpu       int
+		memory    int
+		listening []int
+		wantExit  int
+		wantLog   string
+	}{
+		{name: "충족", cpu: 2, memory: 2, wantLog: "시스템 요구사항 검증 완료"},
+		{name: "CPU 부족", cpu: 1, memory: 4, wantExit: 1, wantLog: "CPU 코어 수 부족: 1 (필요: 2)"},
+		{name: "메모리 부족", cpu: 4, memory: 1, wantExit: 1, wantLog: "메모리 부족: 1GB (필요: 2GB)"},
+		{name: "kubelet 포트 사용 중", cpu: 2, memory: 2, listening: []int{10250}, wantExit: 1, wantLog: "포트 10250가 이미 사용 중"},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			host := newSimulatedHost(t)
+			host.resources(tt.cpu, tt.memory, tt.listening...)
+
+			result := host.runFunction(t, "verify_system_requirements")
+			assert.Equal(t, tt.wantExit, result.ExitCode)
+			assert.Contains(t, result.Stdout, tt.wantLog)
+
+			wantStatus := helpers.StepCompleted
+			if tt.wantExit != 0 {
+				wantStatus = helpers.StepRunning
+			}
+			assert.Equal(t, wantStatus, host.timeline(t).StepStatus(helpers.StepVerifyRequirements))
+		})
+	}
+}
+
+func TestSetupNetwork(t *testing.T) {
+	t.Parallel()
+
+	host := newSimulatedHost(t)
+
+	result := host.runFunction(t, "setup_network")
+	require.Equal(t, 0, result.ExitCode)
+
+	var calls []string
+	for _, call := range result.Calls {
+		// source 시점의 EC2 메타데이터 조회는 제외
+		if call.Name != "curl" {
+			calls = append(calls, call.Line())
+		}
+	}
+	assert.Equal(t, []string{"modprobe overlay", "modprobe br_netfilter", "sysctl --system"}, calls)
+	assert.Equal(t, "overlay\nbr_netfilter\n", host.read(t, "etc/modules-load.d/k8s.conf"))
+	assert.Equal(t, "net.bridge.bridge-nf-call-iptables=1\nnet.bridge.bridge-nf-call-ip6tables=1\nnet.ipv4.ip_forward=1\n",
+		host.read(t, "etc/sysctl.d/k8s.conf"))
+	assert.Equal(t, helpers.StepCompleted, host.timeline(t).StepStatus(helpers.StepSetupNetwork))
+}
+
+// remote-exec로 실행되는 스크립트의 진행 상황은 /dev/console을 거쳐야 EC2 콘솔 출력에 나타남
+func TestLogMirrorsToConsole(t *testing.T) {
+	t.Parallel()
+
+	host := newSimulatedHost(t)
+	require.NoError(t, os.MkdirAll(host.path("dev"), 0o755))
+	require.NoError(t, os.WriteFile(host.path("dev/console"), nil, 0o644))
+
+	result := host.runFunction(t, "setup_network")
+	require.Equal(t, 0, result.ExitCode)
+
+	console := host.read(t, "dev/console")
+	assert.Equal(t, host.read(t, "home/ubuntu/combined_settings.log"), console)
+	assert.Equal(t, helpers.StepCompleted, helpers.ParseBootstrapLog(console).StepStatus(helpers.StepSetupNetwork),
+		"WaitForInstanceBootstrap이 콘솔 출력에서 단계 마커를 찾을 수 있어야 합니다")
+}
+
+func TestSetupNetworkExitsWhenSysctlFails(t *testing.T) {
+	t.Parallel()
+
+	host := newSimulatedHost(t)
+	host.h.Fake("sysctl", scripttest.Response{Args: "--system", Stderr: "sysctl: permission denied\n", ExitCode: 255})
+
+	result := host.runFunction(t, "setup_network")
+	assert.Equal(t, 1, result.ExitCode, "check_error는 함수 반환이 아니라 스크립트를 종료")
+	assert.Contains(t, result.Stdout, "오류: 네트워크 설정 실패")
+	assert.NotContains(t, result.Stdout, "네트워크 설정 완료")
+
+	timeline := host.timeline(t)
+	assert.Equal(t, helpers.StepFailed, timeline.StepStatus(helpers.StepSetupNetwork))
+	require.NotNil(t, timeline.Failure)
+	assert.Equal(t, helpers.StepSetupNetwork, timeline.Failure.Step)
+	assert.Equal(t, "네트워크 설정 실패", timeline.Failure.Message)
+}
+
+func TestInstallDockerStopsOnPackageFailure(t *testing.T) {
+	t.Parallel()
+
+	host := newSimulatedHost(t)
+	host.h.Fake("apt-get", scripttest.Response{Args: "install -y docker.io", Stderr: "E: Unable to locate package docker.io\n", ExitCode: 100})
+
+	result := host.runFunction(t, "install_docker")
+	assert.Equal(t, 1, result.ExitCode)
+	assert.Contains(t, result.Stdout, "오류: Docker 설치 실패")
+	assert.Empty(t, result.CallsTo("systemctl"), "설치 실패 후 서비스 활성화로 진행하지 않음")
+	assert.Empty(t, result.CallsTo("containerd"))
+	assert.Equal(t, helpers.StepFailed, host.timeline(t).StepStatus(helpers.StepInstallDocker))
+}
+
+func TestInstallDockerConfiguresSystemdCgroup(t *testing.T) {
+	t.Parallel()
+
+	host := newSimulatedHost(t)
+	host.h.Fake("containerd", scripttest.Response{Args: "config default", Stdout: "[plugins]\n  SystemdCgroup = false\n"})
+
+	result := host.runFunction(t, "install_docker")
+	require.Equal(t, 0, result.ExitCode)
+
+	var systemctl []string
+	for _, call := range result.CallsTo("systemctl") {
+		systemctl = append(systemctl, call.Line())
+	}
+	assert.Equal(t, []string{"systemctl enable --now docker", "systemctl restart containerd"}, systemctl)
+	assert.Equal(t, "[plugins]\n  SystemdCgroup = true\n", host.read(t, "etc/containerd/config.toml"))
+	assert.Equal(t, helpers.StepCompleted, host.timeline(t).StepStatus(helpers.StepInstallDocker))
+}
+
+func TestInstallCNI(t *testing.T) {
+	t.Parallel()
+
+	host := newSimulatedHost(t)
+
+	result := host.runFunction(t, "install_cni")
+	require.Equal(t, 0, result.ExitCode)
+	assert.Contains(t, result.Stdout, "Calico CNI 설치 및 검증 완료")
+
+	assert.Equal(t, []string{
+		// 기존 설치 정리
+		"kubectl delete -f custom-resources.yaml",
+		"kubectl delete -f tigera-operator.yaml",
+		"kubectl create -f tigera-operator.yaml",
+		"kubectl create -f custom-resources.yaml",
+		"kubectl wait --for=condition=Ready pods --all -n calico-system --timeout=300s",
+	}, kubectlMutations(result))
+
+	manifest, err := os.ReadFile(filepath.Join(host.h.Dir, "calico", "custom-resources.yaml"))
+	require.NoError(t, err)
+	assert.Contains(t, string(manifest), "cidr: 10.244.0.0/16", "IP 풀을 POD_CIDR로 치환")
+	assert.NotContains(t, string(manifest), "192.168.0.0/16")
+
+	assert.Equal(t, helpers.StepCompleted, host.timeline(t).StepStatus(helpers.StepInstallCNI))
+}
+
+func TestInstallCNIRollsBackWhenVerificationFails(t *testing.T) {
+	t.Parallel()
+
+	host := newSimulatedHost(t)
+	host.cluster(false)
+
+	result := host.runFunction(t, "install_cni")
+	assert.Equal(t, 1, result.ExitCode)
+	assert.Equal(t, 3, strings.Count(result.Stdout, "CNI POD가 정상적으로 실행되지 않았습니다"), "MAX_RETRIES만큼 검증")
+
+	wait := "kubectl wait --for=condition=Ready pods --all -n calico-system --timeout=300s"
+	assert.Equal(t, []string{
+		"kubectl delete -f custom-resources.yaml",
+		"kubectl delete -f tigera-operator.yaml",
+		"kubectl create -f tigera-operator.yaml",
+		"kubectl create -f custom-resources.yaml",
+		wait, wait, wait,
+		// 마지막 검증 실패 후 롤백
+		"kubectl delete -f custom-resources.yaml",
+		"kubectl delete -f tigera-operator.yaml",
+	}, kubectlMutations(result))
+
+	var debugLogs int
+	for _, call := range result.CallsTo("kubectl") {
+		if call.Line() == "kubectl logs -n tigera-operator -l k8s-app=tigera-operator" {
+			debugLogs++
+		}
+	}
+	assert.Equal(t, 1, debugLogs, "디버깅 정보는 마지막 재시도에서 한 번만 수집")
+
+	assert.NoFileExists(t, filepath.Join(host.h.Dir, "calico-installed"), "롤백 후 calico-system 네임스페이스 제거")
+	assert.Contains(t, result.Stdout, "CNI 설치 롤백 완료")
+
+	timeline := host.timeline(t)
+	assert.Equal(t, helpers.StepFailed, timeline.StepStatus(helpers.StepInstallCNI))
+	require.NotNil(t, timeline.Failure)
+	assert.Equal(t, "Calico CNI 설치 실패", timeline.Failure.Message)
+}
+
+func TestGenerateJoinCommand(t *testing.T) {
+	t.Parallel()
+
+	host := newSimulatedHost(t)
+
+	result := host.runFunction(t, "generate_join_command")
+	require.Equal(t, 0, result.ExitCode)
+
+	joinFile := host.path("home/ubuntu/join_command")
+	assert.Equal(t, testJoinCommand+"\n", host.read(t, "home/ubuntu/join_command"))
+	info, err := os.Stat(joinFile)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o644), info.Mode().Perm(), "워커가 ssh로 읽을 수 있어야 함")
+
+	chown := result.CallsTo("chown")
+	require.Len(t, chown, 1)
+	assert.Equal(t, []string{"ubuntu:ubuntu", joinFile}, chown[0].Args)
+	assert.Equal(t, helpers.StepCompleted, host.timeline(t).StepStatus(helpers.StepGenerateJoin))
+}
+
+func TestGenerateJoinCommandFailsWithoutToken(t *testing.T) {
+	t.Parallel()
+
+	host := newSimulatedHost(t)
+	host.h.Fake("kubeadm", scripttest.Response{Args: "token create *", Stderr: "failed to load admin kubeconfig\n", ExitCode: 1})
+
+	result := host.runFunction(t, "generate_join_command")
+	assert.Equal(t, 1, result.ExitCode)
+	assert.NoFileExists(t, host.path("home/ubuntu/join_command"), "워커가 빈 join 명령을 실행하지 않도록 파일을 만들지 않음")
+
+	timeline := host.timeline(t)
+	assert.Equal(t, helpers.StepFailed, timeline.StepStatus(helpers.StepGenerateJoin))
+	require.NotNil(t, timeline.Failure)
+	assert.Equal(t, "Join 명령어 파일 생성 실패", timeline.Failure.Message)
+}
+
+func TestGenerateJoinCommandFailsOnEmptyOutput(t *testing.T) {
+	t.Parallel()
+
+	host := newSimulatedHost(t)
+	// kubeadm이 성공 코드로 끝나도 join 명령을 출력하지 않으면 실패로 처리
+	host.h.Fake("kubeadm", scripttest.Response{Args: "token create *"})
+
+	result := host.runFunction(t, "generate_join_command")
+	assert.Equal(t, 1, result.ExitCode)
+	assert.NoFileExists(t, host.path("home/ubuntu/join_command"), "워커가 빈 join 명령을 실행하지 않도록 파일을 만들지 않음")
+	assert.Empty(t, result.CallsTo("chown"))
+
+	timeline := host.timeline(t)
+	assert.Equal(t, helpers.StepFailed, timeline.StepStatus(helpers.StepGenerateJoin))
+	require.NotNil(t, timeline.Failure)
+	assert.Equal(t, "Join 명령어 파일 생성 실패", timeline.Failure.Message)
+}
+
+func TestWorkerSetupJoinsCluster(t *testing.T) {
+	t.Parallel()
+
+	host := newSimulatedHost(t)
+	require.NoError(t, os.WriteFile(host.path("home/ubuntu/.ssh/k8s-key.pem"), []byte("key"), 0o600))
+	// 설치 스크립트는 전달받은 환경만 기록
+	require.NoError(t, os.WriteFile(host.path("home/ubuntu/combined_settings.sh"),
+		[]byte("#!/bin/bash\necho \"${NODE_ROLE} ${MASTER_PRIVATE_IP}\" > \"${K8S_SETUP_ROOT}/combined.env\"\n"), 0o644))
+
+	host.h.Fake("ssh",
+		// 마스터가 아직 join 명령을 만들지 않은 상태를 두 번 재현
+		scripttest.Response{Args: "* test -f /home/ubuntu/join_command", ExitCode: 1, Times: 2},
+		scripttest.Response{Args: "* test -f /home/ubuntu/join_command"},
+		scripttest.Response{Args: "* cat /home/ubuntu/join_command", Stdout: testJoinCommand + "\n"},
+		scripttest.Response{Args: "* sudo cat /etc/kubernetes/admin.conf", Stdout: "apiVersion: v1\nkind: Config\n"},
+	)
+	host.h.Fake("ssh-keyscan", scripttest.Response{Args: "*", Stdout: testMasterIP + " ssh-ed25519 AAAAC3Nza\n"})
+	host.h.Fake("hostname", scripttest.Response{Args: "-I", Stdout: testNodeIP + " 172.17.0.1 \n"})
+
+	result := host.h.Run(workerSetupScript, testMasterIP, "1")
+	t.Logf("worker_setup.sh:\n%s%s", result.Stdout, result.Stderr)
+	require.Equal(t, 0, result.ExitCode)
+	assert.Contains(t, result.Stdout, "Worker node 1 setup completed successfully")
+
+	env, err := os.ReadFile(filepath.Join(host.root, "combined.env"))
+	require.NoError(t, err)
+	assert.Equal(t, "worker-node1 "+testMasterIP+"\n", string(env), "sudo -E로 노드 역할과 마스터 IP 전달")
+
+	keyPath := host.path("home/ubuntu/.ssh/k8s-key.pem")
+	ssh := func(command string) string {
+		return fmt.Sprintf("ssh -i %s -o StrictHostKeyChecking=no ubuntu@%s %s", keyPath, testMasterIP, command)
+	}
+	var calls []string
+	for _, call := range result.Calls {
+		if call.Name == "ssh" || call.Name == "ssh-keyscan" || call.Name == "kubeadm" {
+			calls = append(calls, call.Line())
+		}
+	}
+	assert.Equal(t, []string{
+		"ssh-keyscan -p 22 " + testMasterIP,
+		ssh("test -f /home/ubuntu/join_command"),
+		ssh("test -f /home/ubuntu/join_command"),
+		ssh("test -f /home/ubuntu/join_command"),
+		ssh("cat /home/ubuntu/join_command"),
+		testJoinCommand,
+		ssh("sudo cat /etc/kubernetes/admin.conf"),
+		ssh("kubectl label node ip-10-0-1-50 node-role.kubernetes.io/worker-node1=''"),
+	}, calls)
+
+	assert.Equal(t, "apiVersion: v1\nkind: Config\n", host.read(t, "home/ubuntu/.kube/config"))
+	assert.Contains(t, host.read(t, "home/ubuntu/.ssh/known_hosts"), testMasterIP+" ssh-ed25519")
+	info, err := os.Stat(keyPath)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o400), info.Mode().Perm())
+}
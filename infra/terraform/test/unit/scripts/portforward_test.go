@@ -0,0 +1,324 @@
+package scripts
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/k8s-ec2-observability/test/helpers/scripttest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const portForwardScript = "../../../../../scripts/manage-port-forward.sh"
+
+// fakeKubectlWaitExitEnv 가짜 kubectl wait의 종료 코드 (Pod 미준비 재현)
+const fakeKubectlWaitExitEnv = "FAKE_KUBECTL_WAIT_EXIT"
+
+func TestMain(m *testing.M) {
+	scripttest.RunFakeIfRequested(map[string]scripttest.FakeFunc{"kubectl": fakeKubectl})
+	os.Exit(m.Run())
+}
+
+// fakeKubectl port-forward는 실제 로컬 리스너를 열어 HTTP 200을 응답하고 종료 신호까지 대기
+func fakeKubectl(args []string) int {
+	if len(args) == 0 {
+		return 0
+	}
+	switch args[0] {
+	case "wait":
+		if code, err := strconv.Atoi(os.Getenv(fakeKubectlWaitExitEnv)); err == nil && code != 0 {
+			fmt.Fprintln(os.Stderr, "error: timed out waiting for the condition on pods/productpage-v1")
+			return code
+		}
+		fmt.Println("pod/productpage-v1 condition met")
+		return 0
+	case "port-forward":
+		return fakePortForward(args[1:])
+	}
+	return 0
+}
+
+// kubectl port-forward [-n ns] [--address addr] svc/name LOCAL:REMOTE
+func fakePortForward(args []string) int {
+	address := "127.0.0.1"
+	var target, ports string
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "-n", "--namespace":
+			i++
+		case "--address":
+			i++
+			address = args[i]
+		default:
+			if target == "" {
+				target = args[i]
+			} else {
+				ports = args[i]
+			}
+		}
+	}
+	local, remote, _ := strings.Cut(ports, ":")
+
+	listener, err := net.Listen("tcp", net.JoinHostPort(address, local))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Unable to listen on port %s: %v\n", local, err)
+		return 1
+	}
+	fmt.Printf("Forwarding from %s -> %s\n", net.JoinHostPort(address, local), remote)
+
+	err = http.Serve(listener, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Printf("Handling connection for %s\n", local)
+		fmt.Fprintf(w, "fake port-forward %s%s", target, r.URL.Path)
+	}))
+	fmt.Fprintln(os.Stderr, err)
+	return 1
+}
+
+type portForwardEnv struct {
+	h            *scripttest.Harness
+	bookinfoPort int
+	grafanaPort  int
+}
+
+func freePort(t *testing.T) int {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer listener.Close()
+	return listener.Addr().(*net.TCPAddr).Port
+}
+
+func newPortForwardEnv(t *testing.T) *portForwardEnv {
+	h := scripttest.New(t)
+	h.GoFake("kubectl")
+	env := &portForwardEnv{h: h, bookinfoPort: freePort(t), grafanaPort: freePort(t)}
+
+	h.Setenv("BOOKINFO_PORT", strconv.Itoa(env.bookinfoPort))
+	h.Setenv("GRAFANA_PORT", strconv.Itoa(env.grafanaPort))
+	h.Setenv("PORT_FORWARD_STATE_DIR", h.Dir)
+	h.Setenv("PORT_FORWARD_STARTUP_WAIT", "1")
+	h.Setenv("PUBLIC_IP", "203.0.113.10")
+	return env
+}
+
+func (e *portForwardEnv) run(t *testing.T, command string) *scripttest.Result {
+	result := e.h.Run(portForwardScript, command)
+	t.Logf("%s:\n%s%s", command, result.Stdout, result.Stderr)
+	return result
+}
+
+func (e *portForwardEnv) stateFile(name string) string {
+	return filepath.Join(e.h.Dir, name)
+}
+
+func (e *portForwardEnv) pid(t *testing.T, name string) int {
+	data, err := os.ReadFile(e.stateFile(name))
+	require.NoError(t, err)
+	pid, err := strconv.Atoi(strings.TrimSpace(string(data)))
+	require.NoError(t, err)
+	return pid
+}
+
+// 포워딩된 포트로 HTTP 요청 (리스너가 뜰 때까지 잠시 재시도)
+func httpGet(port int, path string) (string, error) {
+	var lastErr error
+	for i := 0; i < 20; i++ {
+		resp, err := http.Get(fmt.Sprintf("http://127.0.0.1:%d%s", port, path))
+		if err == nil {
+			defer resp.Body.Close()
+			body, err := io.ReadAll(resp.Body)
+			return string(body), err
+		}
+		lastErr = err
+		time.Sleep(50 * time.Millisecond)
+	}
+	return "", lastErr
+}
+
+func portOpen(port int) bool {
+	conn, err := net.DialTimeout("tcp", fmt.Sprintf("127.0.0.1:%d", port), time.Second)
+	if err != nil {
+		return false
+	}
+	conn.Close()
+	return true
+}
+
+func (e *portForwardEnv) assertStopped(t *testing.T) {
+	assert.Eventually(t, func() bool { return len(e.h.FakeProcesses()) == 0 }, 5*time.Second, 50*time.Millisecond,
+		"남은 port-forward 프로세스: %v", e.h.FakeProcesses())
+	assert.NoFileExists(t, e.stateFile("bookinfo-portforward.pid"))
+	assert.NoFileExists(t, e.stateFile("grafana-portforward.pid"))
+	assert.Eventually(t, func() bool { return !portOpen(e.bookinfoPort) && !portOpen(e.grafanaPort) }, 5*time.Second, 50*time.Millisecond)
+}
+
+func TestPortForwardLifecycle(t *testing.T) {
+	t.Parallel()
+
+	env := newPortForwardEnv(t)
+
+	start := env.run(t, "start")
+	require.Equal(t, 0, start.ExitCode)
+	assert.Contains(t, start.Stdout, "✅ port-forward 서비스가 성공적으로 시작되었습니다")
+	assert.Contains(t, start.Stdout, fmt.Sprintf("http://203.0.113.10:%d/productpage", env.bookinfoPort))
+
+	var forwards []string
+	for _, call := range start.CallsTo("kubectl") {
+		if call.Args[0] == "port-forward" {
+			forwards = append(forwards, call.Line())
+		}
+	}
+	assert.Equal(t, []string{
+		fmt.Sprintf("kubectl port-forward -n bookinfo --address 0.0.0.0 svc/productpage %d:9080", env.bookinfoPort),
+		fmt.Sprintf("kubectl port-forward -n monitoring --address 0.0.0.0 svc/prometheus-grafana %d:80", env.grafanaPort),
+	}, forwards)
+
+	bookinfoPID := env.pid(t, "bookinfo-portforward.pid")
+	grafanaPID := env.pid(t, "grafana-portforward.pid")
+	assert.ElementsMatch(t, []int{bookinfoPID, grafanaPID}, env.h.FakeProcesses(), "PID 파일이 실제 포워딩 프로세스를 가리킴")
+
+	body, err := httpGet(env.bookinfoPort, "/productpage")
+	require.NoError(t, err)
+	assert.Equal(t, "fake port-forward svc/productpage/productpage", body)
+	body, err = httpGet(env.grafanaPort, "/login")
+	require.NoError(t, err)
+	assert.Equal(t, "fake port-forward svc/prometheus-grafana/login", body)
+
+	logs, err := os.ReadFile(env.stateFile("bookinfo-portforward.log"))
+	require.NoError(t, err)
+	assert.Contains(t, string(logs), "Forwarding from 0.0.0.0:"+strconv.Itoa(env.bookinfoPort)+" -> 9080")
+
+	status := env.run(t, "status")
+	assert.Contains(t, status.Stdout, fmt.Sprintf("Bookinfo (%d): \x1b[0;32m✅ 접속 가능", env.bookinfoPort))
+	assert.Contains(t, status.Stdout, fmt.Sprintf("Grafana (%d):  \x1b[0;32m✅ 접속 가능", env.grafanaPort))
+	assert.Contains(t, status.Stdout, "kubectl port-forward -n bookinfo")
+
+	logsOutput := env.run(t, "logs")
+	assert.Contains(t, logsOutput.Stdout, "Forwarding from 0.0.0.0:"+strconv.Itoa(env.grafanaPort)+" -> 80")
+
+	stop := env.run(t, "stop")
+	require.Equal(t, 0, stop.ExitCode)
+	assert.Contains(t, stop.Stdout, "Bookinfo port-forward를 중지했습니다")
+	assert.Contains(t, stop.Stdout, "Grafana port-forward를 중지했습니다")
+	env.assertStopped(t)
+
+	status = env.run(t, "status")
+	assert.Contains(t, status.Stdout, fmt.Sprintf("Bookinfo (%d): \x1b[0;31m❌ 접속 불가", env.bookinfoPort))
+	assert.Contains(t, status.Stdout, fmt.Sprintf("Grafana (%d):  \x1b[0;31m❌ 접속 불가", env.grafanaPort))
+}
+
+func TestPortForwardSkipsReachableGrafana(t *testing.T) {
+	t.Parallel()
+
+	env := newPortForwardEnv(t)
+
+	// NodePort로 이미 열려 있는 Grafana
+	listener, err := net.Listen("tcp", fmt.Sprintf("127.0.0.1:%d", env.grafanaPort))
+	require.NoError(t, err)
+	server := &http.Server{Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})}
+	go server.Serve(listener)
+	t.Cleanup(func() { server.Close() })
+
+	start := env.run(t, "start")
+	require.Equal(t, 0, start.ExitCode)
+	assert.Contains(t, start.Stdout, "Grafana는 이미 NodePort를 통해 접속 가능합니다")
+	assert.NoFileExists(t, env.stateFile("grafana-portforward.pid"))
+	assert.Len(t, env.h.FakeProcesses(), 1)
+
+	stop := env.run(t, "stop")
+	require.Equal(t, 0, stop.ExitCode)
+	assert.NotContains(t, stop.Stdout, "Grafana port-forward를 중지했습니다")
+	assert.Empty(t, env.h.FakeProcesses())
+	assert.True(t, portOpen(env.grafanaPort), "스크립트가 띄우지 않은 Grafana는 그대로 유지")
+}
+
+func TestPortForwardStopWithStalePID(t *testing.T) {
+	t.Parallel()
+
+	env := newPortForwardEnv(t)
+	require.Equal(t, 0, env.run(t, "start").ExitCode)
+	require.Len(t, env.h.FakeProcesses(), 2)
+
+	// 이미 종료된 프로세스의 PID로 덮어써 PID 파일이 실제 프로세스를 잃은 상황 재현
+	exited := exec.Command("true")
+	require.NoError(t, exited.Run())
+	stalePID := strconv.Itoa(exited.Process.Pid)
+	require.NoError(t, os.WriteFile(env.stateFile("bookinfo-portforward.pid"), []byte(stalePID+"\n"), 0o644))
+	require.NoError(t, os.WriteFile(env.stateFile("grafana-portforward.pid"), []byte(stalePID+"\n"), 0o644))
+
+	stop := env.run(t, "stop")
+	require.Equal(t, 0, stop.ExitCode)
+	assert.NotContains(t, stop.Stdout, "Bookinfo port-forward를 중지했습니다", "죽은 PID는 kill하지 않음")
+	assert.Contains(t, stop.Stdout, "✅ port-forward 서비스가 중지되었습니다")
+	env.assertStopped(t)
+}
+
+func TestPortForwardStopKeepsUnrelatedForwards(t *testing.T) {
+	t.Parallel()
+
+	env := newPortForwardEnv(t)
+	require.Equal(t, 0, env.run(t, "start").ExitCode)
+
+	// 원격 포트가 Bookinfo 로컬 포트와 같은, 스크립트와 무관한 port-forward
+	otherPort := freePort(t)
+	launcher := filepath.Join(env.h.Dir, "other-port-forward.sh")
+	require.NoError(t, os.WriteFile(launcher, []byte(fmt.Sprintf(
+		"#!/bin/bash\nnohup kubectl port-forward -n other svc/other %d:%d > /dev/null 2>&1 &\necho $!\n",
+		otherPort, env.bookinfoPort)), 0o755))
+	launched := env.h.Run(launcher)
+	require.Equal(t, 0, launched.ExitCode)
+	otherPID, err := strconv.Atoi(strings.TrimSpace(launched.Stdout))
+	require.NoError(t, err)
+	_, err = httpGet(otherPort, "/")
+	require.NoError(t, err)
+
+	// PID 파일을 잃어 패턴으로 정리하는 경로를 타도록 함
+	require.NoError(t, os.Remove(env.stateFile("bookinfo-portforward.pid")))
+	require.NoError(t, os.Remove(env.stateFile("grafana-portforward.pid")))
+
+	require.Equal(t, 0, env.run(t, "stop").ExitCode)
+	assert.Eventually(t, func() bool { return !portOpen(env.bookinfoPort) && !portOpen(env.grafanaPort) }, 5*time.Second, 50*time.Millisecond)
+	assert.True(t, scripttest.ProcessAlive(otherPID), "포트 번호가 부분 일치하는 다른 port-forward는 종료하지 않음")
+	assert.Equal(t, []int{otherPID}, env.h.FakeProcesses())
+}
+
+func TestPortForwardRestart(t *testing.T) {
+	t.Parallel()
+
+	env := newPortForwardEnv(t)
+	require.Equal(t, 0, env.run(t, "start").ExitCode)
+	before := env.pid(t, "bookinfo-portforward.pid")
+
+	restart := env.run(t, "restart")
+	require.Equal(t, 0, restart.ExitCode)
+	assert.Contains(t, restart.Stdout, "✅ port-forward 서비스가 성공적으로 시작되었습니다")
+
+	after := env.pid(t, "bookinfo-portforward.pid")
+	assert.NotEqual(t, before, after)
+	assert.False(t, scripttest.ProcessAlive(before), "이전 포워딩 프로세스는 종료")
+	assert.Len(t, env.h.FakeProcesses(), 2, "포트마다 프로세스 하나만 유지")
+
+	require.Equal(t, 0, env.run(t, "stop").ExitCode)
+	env.assertStopped(t)
+}
+
+func TestPortForwardStartFailsWhenProductpageNotReady(t *testing.T) {
+	t.Parallel()
+
+	env := newPortForwardEnv(t)
+	env.h.Setenv(fakeKubectlWaitExitEnv, "1")
+
+	start := env.run(t, "start")
+	assert.NotEqual(t, 0, start.ExitCode)
+	assert.Contains(t, start.Stdout, "Bookinfo productpage pod가 준비되지 않았습니다")
+	assert.NoFileExists(t, env.stateFile("bookinfo-portforward.pid"))
+	assert.Empty(t, env.h.FakeProcesses())
+}
@@ -0,0 +1,83 @@
+package teardown
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/k8s-ec2-observability/test/helpers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTeardownAllRunsInReverseOrder(t *testing.T) {
+	t.Parallel()
+
+	registry := helpers.NewTeardownRegistry(filepath.Join(t.TempDir(), "journal.json"))
+	var order []string
+	for _, name := range []string{"kms", "master", "worker", "removed"} {
+		name := name
+		unregister := registry.OnInterrupt(name, func() { order = append(order, name) })
+		if name == "removed" {
+			unregister()
+		}
+	}
+
+	require.NoError(t, registry.TeardownAll("SIGINT"))
+	assert.Equal(t, []string{"worker", "master", "kms"}, order, "나중에 만든 리소스부터 정리해야 합니다")
+	assert.True(t, registry.Interrupted())
+
+	err := registry.Register(t, newOptions(t.TempDir()))
+	assert.Error(t, err, "중단 정리가 시작된 뒤에는 새 apply를 기록하지 않아야 합니다")
+}
+
+func TestTeardownAllWaitsForRunningApply(t *testing.T) {
+	t.Parallel()
+
+	registry := helpers.NewTeardownRegistry(filepath.Join(t.TempDir(), "journal.json"))
+	options := newOptions(filepath.Join(t.TempDir(), "missing"))
+	require.NoError(t, registry.Register(t, options))
+	done, err := registry.Begin(options)
+	require.NoError(t, err)
+
+	finished := make(chan error, 1)
+	go func() { finished <- registry.TeardownAll("SIGINT") }()
+
+	select {
+	case <-finished:
+		t.Fatal("실행 중인 apply가 끝나기 전에 destroy하면 상태 잠금을 얻지 못합니다")
+	case <-time.After(time.Second):
+	}
+	_, err = registry.Begin(options)
+	assert.Error(t, err, "중단 정리 중에는 새 시도를 시작하지 않아야 합니다")
+
+	done()
+	select {
+	case err := <-finished:
+		// 작업 디렉터리가 없으므로 destroy는 실패하고 항목은 저널에 남음
+		assert.Error(t, err)
+	case <-time.After(time.Minute):
+		t.Fatal("apply가 끝난 뒤에도 정리가 끝나지 않았습니다")
+	}
+	assert.True(t, options.Lock, "destroy는 상태 잠금을 기다려야 합니다")
+	assert.NotEmpty(t, options.LockTimeout)
+}
+
+func TestTeardownAllForgetsDestroyedEntries(t *testing.T) {
+	t.Parallel()
+
+	// destroy가 성공하도록 아무것도 하지 않는 terraform
+	binary := filepath.Join(t.TempDir(), "terraform")
+	require.NoError(t, os.WriteFile(binary, []byte("#!/bin/sh\nexit 0\n"), 0o755))
+
+	path := filepath.Join(t.TempDir(), "journal.json")
+	registry := helpers.NewTeardownRegistry(path)
+	options := newOptions(t.TempDir())
+	options.TerraformBinary = binary
+	require.NoError(t, registry.Register(t, options))
+	require.FileExists(t, path)
+
+	require.NoError(t, registry.TeardownAll("SIGINT"))
+	assert.NoFileExists(t, path, "destroy한 항목은 기본 레지스트리가 아니라 이 레지스트리의 저널에서 지워야 합니다")
+}
@@ -0,0 +1,64 @@
+package teardown
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/k8s-ec2-observability/test/helpers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// childEnv 하위 프로세스로 실행 중임을 표시
+const childEnv = "TEARDOWN_CHILD"
+
+// runJournalMode 현재 테스트 바이너리를 --teardown-from-journal로 실행
+func runJournalMode(t *testing.T, journal string) (string, int) {
+	t.Helper()
+	executable, err := os.Executable()
+	require.NoError(t, err)
+	cmd := exec.Command(executable, "--"+helpers.TeardownFromJournalFlag)
+	cmd.Env = append(os.Environ(), childEnv+"=1", helpers.EnvTeardownJournal+"="+journal)
+	output, err := cmd.CombinedOutput()
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		return string(output), exitErr.ExitCode()
+	}
+	require.NoError(t, err)
+	return string(output), 0
+}
+
+func TestJournalModeWithEmptyJournal(t *testing.T) {
+	t.Parallel()
+
+	output, code := runJournalMode(t, filepath.Join(t.TempDir(), "journal.json"))
+	assert.Equal(t, 0, code, output)
+	assert.Contains(t, output, "정리할 항목이 없습니다")
+	assert.NotContains(t, output, "TestMustNotRunInJournalMode", "저널 모드에서는 테스트를 실행하지 않아야 합니다")
+}
+
+func TestJournalModeReportsFailures(t *testing.T) {
+	t.Parallel()
+
+	journal := filepath.Join(t.TempDir(), "journal.json")
+	writeJournal(t, journal, helpers.TeardownEntry{
+		Name:         "TestKMSKeyCreation",
+		TerraformDir: filepath.Join(t.TempDir(), "gone"),
+		RegisteredAt: time.Now().UTC(),
+	})
+
+	output, code := runJournalMode(t, journal)
+	assert.Equal(t, 1, code, output)
+	assert.Contains(t, output, "TestKMSKeyCreation")
+	assert.FileExists(t, journal)
+}
+
+func TestMustNotRunInJournalMode(t *testing.T) {
+	if os.Getenv(childEnv) != "" {
+		t.Fatal("저널 모드에서 테스트가 실행됨")
+	}
+}
@@ -0,0 +1,140 @@
+package teardown
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/gruntwork-io/terratest/modules/terraform"
+	"github.com/k8s-ec2-observability/test/helpers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newOptions(dir string) *terraform.Options {
+	return &terraform.Options{
+		TerraformDir: dir,
+		Vars: map[string]interface{}{
+			"project_name":            "k8s-ec2-observability",
+			"deletion_window_in_days": 7,
+		},
+		EnvVars: map[string]string{
+			"AWS_DEFAULT_REGION":    "ap-northeast-2",
+			"AWS_ACCESS_KEY_ID":     "ASIAEXAMPLE",
+			"AWS_SECRET_ACCESS_KEY": "secret",
+			"AWS_SESSION_TOKEN":     "token",
+			"AWS_CONFIG_FILE":       "/tmp/terratest-aws/terratest-abc123.config",
+			"AWS_PROFILE":           "terratest-abc123",
+			"TF_PLUGIN_CACHE_DIR":   "/tmp/plugins",
+		},
+	}
+}
+
+func writeJournal(t *testing.T, path string, entries ...helpers.TeardownEntry) {
+	t.Helper()
+	data, err := json.Marshal(entries)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(path, data, 0o600))
+}
+
+func TestJournalRecordsAndForgets(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "journal.json")
+	registry := helpers.NewTeardownRegistry(path)
+	options := newOptions("../../../examples/kms")
+
+	require.NoError(t, registry.Register(t, options))
+	require.NoError(t, registry.Register(t, options), "같은 options는 한 번만 기록해야 합니다")
+
+	entries, err := helpers.ReadTeardownJournal(path)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	entry := entries[0]
+	assert.Equal(t, t.Name(), entry.Name)
+	assert.True(t, filepath.IsAbs(entry.TerraformDir), "다른 디렉터리에서도 정리할 수 있도록 절대 경로로 기록해야 합니다")
+	assert.Equal(t, "ap-northeast-2", entry.EnvVars["AWS_DEFAULT_REGION"])
+	assert.Equal(t, "/tmp/plugins", entry.EnvVars["TF_PLUGIN_CACHE_DIR"])
+	for _, key := range []string{"AWS_ACCESS_KEY_ID", "AWS_SECRET_ACCESS_KEY", "AWS_SESSION_TOKEN", "AWS_CONFIG_FILE", "AWS_PROFILE"} {
+		assert.NotContains(t, entry.EnvVars, key, "자격 증명과 실행별 역할 프로필은 저널에 남기지 않아야 합니다")
+	}
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+
+	require.NoError(t, registry.Forget(options))
+	assert.NoFileExists(t, path, "정리할 항목이 없으면 저널을 지워야 합니다")
+}
+
+func TestStaleEntriesArePreserved(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "journal.json")
+	stale := helpers.TeardownEntry{Name: "TestKilled", TerraformDir: "/tmp/killed", RegisteredAt: time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)}
+	writeJournal(t, path, stale)
+
+	registry := helpers.NewTeardownRegistry(path)
+	require.Len(t, registry.Stale(), 1)
+
+	options := newOptions(t.TempDir())
+	require.NoError(t, registry.Register(t, options))
+	entries, err := helpers.ReadTeardownJournal(path)
+	require.NoError(t, err)
+	require.Len(t, entries, 2, "이전 실행의 항목을 덮어쓰지 않아야 합니다")
+	assert.Equal(t, "TestKilled", entries[0].Name)
+
+	require.NoError(t, registry.Forget(options))
+	entries, err = helpers.ReadTeardownJournal(path)
+	require.NoError(t, err)
+	assert.Equal(t, []helpers.TeardownEntry{stale}, entries)
+}
+
+func TestEntryOptions(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "journal.json")
+	dir := t.TempDir()
+	registry := helpers.NewTeardownRegistry(path)
+	require.NoError(t, registry.Register(t, newOptions(dir)))
+
+	entries, err := helpers.ReadTeardownJournal(path)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+
+	options := entries[0].Options()
+	assert.Equal(t, dir, options.TerraformDir)
+	assert.Equal(t, "k8s-ec2-observability", options.Vars["project_name"])
+	assert.EqualValues(t, 7, options.Vars["deletion_window_in_days"])
+	assert.Equal(t, "ap-northeast-2", options.EnvVars["AWS_DEFAULT_REGION"])
+	assert.True(t, options.NoColor)
+	assert.True(t, options.Lock)
+	assert.NotEmpty(t, options.LockTimeout)
+}
+
+func TestTeardownJournalKeepsFailedEntries(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "journal.json")
+	missing := helpers.TeardownEntry{Name: "TestGone", TerraformDir: filepath.Join(t.TempDir(), "gone"), RegisteredAt: time.Now().UTC()}
+	writeJournal(t, path, missing)
+
+	err := helpers.NewTeardownRegistry(path).TeardownJournal()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "TestGone")
+	assert.Contains(t, err.Error(), "작업 디렉터리를 찾을 수 없어")
+
+	entries, err := helpers.ReadTeardownJournal(path)
+	require.NoError(t, err)
+	assert.Len(t, entries, 1, "정리하지 못한 항목은 저널에 남아야 합니다")
+}
+
+func TestReadMissingJournal(t *testing.T) {
+	t.Parallel()
+
+	entries, err := helpers.ReadTeardownJournal(filepath.Join(t.TempDir(), "none.json"))
+	assert.NoError(t, err)
+	assert.Empty(t, entries)
+}
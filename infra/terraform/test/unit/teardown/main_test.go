@@ -0,0 +1,12 @@
+package teardown
+
+import (
+	"os"
+	"testing"
+
+	"github.com/k8s-ec2-observability/test/helpers"
+)
+
+func TestMain(m *testing.M) {
+	os.Exit(helpers.RunTests(m))
+}
@@ -0,0 +1,417 @@
+package tfcontract
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/k8s-ec2-observability/test/helpers"
+	"github.com/k8s-ec2-observability/test/helpers/tfcontract"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	rootStackDir    = "../../.."
+	ec2MasterModule = "../../../modules/ec2-master"
+	ec2WorkerModule = "../../../modules/ec2-worker"
+	kmsModule       = "../../../modules/kms"
+	kmsExample      = "../../../examples/kms"
+	securityModule  = "../../../modules/security"
+)
+
+// 임시 디렉터리에 모듈 파일 작성 (경로 → 내용)
+func writeModules(t *testing.T, files map[string]string) string {
+	dir := t.TempDir()
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+	}
+	return dir
+}
+
+// "check name" 형태로 요약
+func findingKeys(findings []tfcontract.Finding) []string {
+	keys := make([]string, len(findings))
+	for i, finding := range findings {
+		keys[i] = finding.Check + " " + finding.Name
+	}
+	return keys
+}
+
+func TestRootStackContract(t *testing.T) {
+	t.Parallel()
+
+	tree, err := tfcontract.LoadTree(rootStackDir)
+	require.NoError(t, err)
+	assert.Len(t, tree.Modules, 5, "루트 + vpc, security, ec2-master, ec2-worker")
+
+	findings := tfcontract.Check(tree)
+	for _, finding := range findings {
+		t.Log(finding)
+	}
+	assert.Empty(t, findings, "루트 스택의 module 배선이 피호출 모듈 계약과 맞아야 합니다")
+
+	graph := tfcontract.BuildGraph(tree.Root)
+	order, err := graph.Order()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"vpc", "security", "master", "worker"}, order)
+	assert.Equal(t, []string{"master", "vpc"}, graph.DependenciesOf("worker"))
+
+	edge := graph.Edge("master", "worker")
+	require.NotNil(t, edge)
+	assert.Contains(t, edge.Wires, tfcontract.Wire{Output: "private_ip", Argument: "master_private_ip"})
+	assert.Contains(t, edge.Wires, tfcontract.Wire{Output: "security_group_id", Argument: "master_security_group_id"})
+	assert.Contains(t, edge.Wires, tfcontract.Wire{Output: "", Argument: "master_instance"}, "모듈 전체 전달")
+}
+
+func TestRootStackGraphDOT(t *testing.T) {
+	t.Parallel()
+
+	tree, err := tfcontract.LoadTree(rootStackDir)
+	require.NoError(t, err)
+
+	dot := tfcontract.BuildGraph(tree.Root).DOT()
+	assert.True(t, strings.HasPrefix(dot, "digraph modules {"))
+	assert.Contains(t, dot, `"vpc" -> "security" [label="vpc_id → vpc_id"];`)
+	assert.Contains(t, dot, `"security" -> "master" [label="security_group_id → security_group_id"];`)
+}
+
+func TestCheckReportsBrokenWiring(t *testing.T) {
+	t.Parallel()
+
+	dir := writeModules(t, map[string]string{
+		"main.tf": `
+variable "subnets" {
+  type = list(string)
+}
+
+variable "labels" {
+  type = list(string)
+}
+
+module "net" {
+  source = "./modules/net"
+  cidr   = "10.0.0.0/16"
+}
+
+module "app" {
+  source     = "./modules/app"
+  nmae       = "web"
+  port       = "http"
+  subnet_ids = module.net.subnet_id
+  tags       = var.labels
+  replicas   = null
+}
+
+module "ghost" {
+  source = "./modules/ghost"
+}
+
+output "app_url" {
+  value = module.app.url
+}
+
+output "zone" {
+  value = "${var.zone}-a"
+}
+
+output "db" {
+  value = module.db.endpoint
+}
+`,
+		"modules/net/variables.tf": `
+variable "cidr" {
+  type = string
+}
+`,
+		"modules/net/outputs.tf": `
+output "subnet_ids" {
+  value = ["subnet-a"]
+}
+`,
+		"modules/app/variables.tf": `
+variable "name" {
+  type = string
+}
+
+variable "port" {
+  type    = number
+  default = 80
+}
+
+variable "subnet_ids" {
+  type = list(string)
+}
+
+variable "tags" {
+  type    = map(string)
+  default = {}
+}
+
+variable "replicas" {
+  type = number
+}
+`,
+		"modules/app/outputs.tf": `
+output "endpoint" {
+  value = "http://app"
+}
+`,
+	})
+
+	tree, err := tfcontract.LoadTree(dir)
+	require.NoError(t, err)
+	findings := tfcontract.Check(tree)
+	for _, finding := range findings {
+		t.Log(finding)
+	}
+
+	assert.ElementsMatch(t, []string{
+		"unknown-argument nmae",
+		"type-mismatch port",
+		"type-mismatch tags",
+		"missing-argument replicas",
+		"missing-argument name",
+		"unresolved-source ./modules/ghost",
+		"unknown-output module.net.subnet_id",
+		"unknown-output module.app.url",
+		"unknown-variable var.zone",
+		"unknown-module module.db.endpoint",
+	}, findingKeys(findings))
+	assert.Len(t, tfcontract.Errors(findings), len(findings))
+
+	byKey := make(map[string]tfcontract.Finding)
+	for _, finding := range findings {
+		byKey[finding.Check+" "+finding.Name] = finding
+	}
+	assert.Contains(t, byKey["unknown-argument nmae"].Message, "(name의 오타?)")
+	assert.Contains(t, byKey["unknown-output module.net.subnet_id"].Message, "(subnet_ids의 오타?)")
+	assert.Equal(t, filepath.Join(dir, "main.tf"), byKey["type-mismatch port"].Path)
+	assert.Equal(t, 18, byKey["type-mismatch port"].Line)
+	assert.Equal(t, "app", byKey["type-mismatch port"].Call)
+	assert.Equal(t, 15, byKey["missing-argument name"].Line, "누락은 module 블록 위치로 보고")
+}
+
+func TestCheckTypesPassThroughOutputs(t *testing.T) {
+	t.Parallel()
+
+	dir := writeModules(t, map[string]string{
+		"main.tf": `
+module "config" {
+  source   = "./modules/config"
+  replicas = "3"
+  zones    = ["a", "b"]
+}
+
+module "app" {
+  source   = "./modules/app"
+  replicas = module.config.replicas
+  zones    = module.config.zones
+  name     = module.config.computed
+}
+`,
+		"modules/config/main.tf": `
+variable "replicas" {
+  type = string
+}
+
+variable "zones" {
+  type = list(string)
+}
+
+output "replicas" {
+  value = var.replicas
+}
+
+output "zones" {
+  value = var.zones
+}
+
+output "computed" {
+  value = upper(var.replicas)
+}
+`,
+		"modules/app/main.tf": `
+variable "replicas" {
+  type = number
+}
+
+variable "zones" {
+  type = string
+}
+
+variable "name" {
+  type = bool
+}
+`,
+	})
+
+	tree, err := tfcontract.LoadTree(dir)
+	require.NoError(t, err)
+	findings := tfcontract.Check(tree)
+	for _, finding := range findings {
+		t.Log(finding)
+	}
+
+	// string → number는 값에 따라 성공할 수 있어 경고, list → string은 불가능
+	assert.ElementsMatch(t, []string{"unsafe-conversion replicas", "type-mismatch zones"}, findingKeys(findings))
+	assert.Len(t, tfcontract.Errors(findings), 1)
+}
+
+func TestCheckDetectsCycle(t *testing.T) {
+	t.Parallel()
+
+	dir := writeModules(t, map[string]string{
+		"main.tf": `
+module "a" {
+  source = "./modules/m"
+  input  = module.b.value
+}
+
+module "b" {
+  source     = "./modules/m"
+  input      = "x"
+  depends_on = [module.a]
+}
+`,
+		"modules/m/main.tf": `
+variable "input" {
+  type = string
+}
+
+output "value" {
+  value = var.input
+}
+`,
+	})
+
+	tree, err := tfcontract.LoadTree(dir)
+	require.NoError(t, err)
+	findings := tfcontract.Check(tree)
+	require.Len(t, findings, 1)
+	assert.Equal(t, tfcontract.CheckCycle, findings[0].Check)
+	assert.Contains(t, findings[0].Message, "a, b")
+
+	graph := tfcontract.BuildGraph(tree.Root)
+	assert.Equal(t, []tfcontract.Wire{{Argument: "depends_on"}}, graph.Edge("a", "b").Wires)
+}
+
+func TestLoadModuleParsesOptionalAttributes(t *testing.T) {
+	t.Parallel()
+
+	module, err := tfcontract.LoadModule(securityModule)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"ingress_rules", "project_name", "vpc_id"}, module.RequiredVariables())
+
+	findings := tfcontract.CheckVars(module, map[string]interface{}{
+		"project_name": "k8s-ec2-observability",
+		"vpc_id":       "vpc-0123",
+		"ingress_rules": []map[string]interface{}{
+			{"from_port": 22, "to_port": 22, "protocol": "tcp", "cidr_blocks": []string{"0.0.0.0/0"}},
+			{"from_port": 0, "to_port": 0, "protocol": "-1", "self": true},
+		},
+	}, nil)
+	assert.Empty(t, findings, "optional 속성은 생략 가능")
+
+	findings = tfcontract.CheckVars(module, map[string]interface{}{
+		"project_name":  "k8s-ec2-observability",
+		"vpc_id":        "vpc-0123",
+		"ingress_rules": []map[string]interface{}{{"from_port": 22, "protocol": "tcp"}},
+	}, nil)
+	assert.Equal(t, []string{"type-mismatch ingress_rules"}, findingKeys(findings), "to_port는 필수 속성")
+}
+
+// 통합 테스트가 마스터 출력 값을 손으로 워커 변수에 넘기는 배선
+func TestCheckVarsIntegrationWorkerWiring(t *testing.T) {
+	t.Parallel()
+
+	module, err := tfcontract.LoadModule(ec2WorkerModule)
+	require.NoError(t, err)
+
+	findings := tfcontract.CheckVars(module, map[string]interface{}{
+		"project_name":             "k8s-ec2-observability",
+		"worker_count":             2,
+		"ami_id":                   "ami-08943a151bd468f4e",
+		"instance_type":            "t3.micro",
+		"subnet_id":                "subnet-0da71e4d6ec33bb2f",
+		"vpc_id":                   "vpc-058a9f815567295d2",
+		"master_private_ip":        "10.0.1.34",
+		"master_security_group_id": "sg-0123",
+		"kms_key_id":               "key-0123",
+		"tags":                     map[string]string{"Terraform": "true"},
+	}, map[string]string{"AWS_DEFAULT_REGION": "ap-northeast-2", "TF_VAR_private_key_path": "/tmp/key.pem"})
+
+	assert.Equal(t, []string{
+		"missing-argument master_instance",
+		"missing-argument master_public_ip",
+	}, findingKeys(findings), "TF_VAR_ 환경 변수로 넘긴 private_key_path는 제공된 것으로 간주")
+}
+
+func TestCheckVarsTypesAndTypos(t *testing.T) {
+	t.Parallel()
+
+	module, err := tfcontract.LoadModule(ec2MasterModule)
+	require.NoError(t, err)
+
+	findings := tfcontract.CheckVars(module, map[string]interface{}{
+		"project_name":      "k8s-ec2-observability",
+		"ami_id":            "ami-08943a151bd468f4e",
+		"subnet_id":         "subnet-0da71e4d6ec33bb2f",
+		"vpc_id":            "vpc-058a9f815567295d2",
+		"private_key_path":  "/tmp/key.pem",
+		"security_group_id": "sg-0123",
+		"scripts_bucket":    "dummy-bucket",
+		"root_volume_size":  "thirty",
+		"tags":              []string{"Terraform"},
+		"instance_typ":      "t3.small",
+		"kms_key_id":        nil,
+	}, nil)
+	for _, finding := range findings {
+		t.Log(finding)
+	}
+
+	assert.Equal(t, []string{
+		"unknown-argument instance_typ",
+		"type-mismatch root_volume_size",
+		"type-mismatch tags",
+	}, findingKeys(findings))
+	assert.Contains(t, findings[0].Message, "(instance_type의 오타?)")
+}
+
+func TestDefaultKMSVarsMatchModule(t *testing.T) {
+	t.Parallel()
+
+	module, err := tfcontract.LoadModule(kmsModule)
+	require.NoError(t, err)
+	assert.Empty(t, tfcontract.CheckVars(module, helpers.DefaultKMSVars("k8s-ec2-observability", "test"), nil))
+}
+
+// SetupKMSTest가 넘기는 변수가 examples/kms와 맞는지 (선택 기능을 모두 켠 경우 포함)
+func TestKMSTestConfigMatchesExample(t *testing.T) {
+	t.Parallel()
+
+	module, err := tfcontract.LoadModule(kmsExample)
+	require.NoError(t, err)
+
+	config := helpers.NewKMSTestConfig()
+	assert.Empty(t, tfcontract.CheckVars(module, config.TerraformVars(), nil))
+
+	config.Features = helpers.KMSFeatures{Monitoring: true, CloudTrail: true, Backup: true, AutoRecovery: true}
+	assert.Empty(t, tfcontract.CheckVars(module, config.TerraformVars(), nil))
+}
+
+// 루트 스택 통합 테스트가 넘기는 변수가 루트 variables.tf와 맞는지 apply 없이 확인
+func TestRootStackTestConfigMatchesRoot(t *testing.T) {
+	t.Parallel()
+
+	module, err := tfcontract.LoadModule(rootStackDir)
+	require.NoError(t, err)
+
+	config := helpers.NewRootStackTestConfig()
+	vars := config.TerraformVars("k8s-obs-key", "/tmp/key.pem", "123456789012", "arn:aws:iam::123456789012:user/terraform")
+	assert.Empty(t, tfcontract.CheckVars(module, vars, nil))
+	assert.Contains(t, config.ProjectName, config.UniqueID, "리소스 이름 충돌을 피하려면 프로젝트 이름에 고유 ID가 있어야 합니다")
+}
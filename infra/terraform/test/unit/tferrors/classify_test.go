@@ -0,0 +1,139 @@
+package tferrors
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/k8s-ec2-observability/test/helpers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func readOutput(t *testing.T, name string) string {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join("testdata", name))
+	require.NoError(t, err)
+	return string(data)
+}
+
+func TestClassifyTerraformMessage(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		message string
+		want    helpers.TerraformErrorCategory
+	}{
+		{"api error ThrottlingException: Rate exceeded", helpers.TerraformErrorThrottling},
+		{"RequestLimitExceeded: Request limit exceeded.", helpers.TerraformErrorThrottling},
+		{"TooManyRequestsException: Too many requests", helpers.TerraformErrorThrottling},
+		{"InsufficientInstanceCapacity: We currently do not have sufficient t3.small capacity", helpers.TerraformErrorCapacity},
+		{"VpcLimitExceeded: The maximum number of VPCs has been reached.", helpers.TerraformErrorQuota},
+		{"LimitExceededException: Cannot exceed quota for RolesPerAccount: 1000", helpers.TerraformErrorQuota},
+		{"AccessDeniedException: User is not authorized to perform: kms:CreateKey", helpers.TerraformErrorAuth},
+		{"UnauthorizedOperation: You are not authorized to perform this operation.", helpers.TerraformErrorAuth},
+		{"ExpiredToken: The security token included in the request is expired", helpers.TerraformErrorAuth},
+		{"No valid credential sources found", helpers.TerraformErrorAuth},
+		{"InvalidParameterValueException: The role defined for the function cannot be assumed by Lambda.", helpers.TerraformErrorEventualConsistency},
+		{"InvalidInstanceID.NotFound: The instance ID 'i-0123' does not exist", helpers.TerraformErrorEventualConsistency},
+		{"DependencyViolation: resource sg-0123 has a dependent object", helpers.TerraformErrorEventualConsistency},
+		{"Invalid IAM Instance Profile name", helpers.TerraformErrorEventualConsistency},
+		{"InvalidAMIID.NotFound: The image id '[ami-0123]' does not exist", helpers.TerraformErrorValidation},
+		{"InvalidKeyPair.NotFound: The key pair 'missing' does not exist", helpers.TerraformErrorValidation},
+		{"InvalidSubnetID.NotFound: The subnet ID 'subnet-0123' does not exist", helpers.TerraformErrorValidation},
+		{"InvalidVpcID.NotFound: The vpc ID 'vpc-0123' does not exist", helpers.TerraformErrorValidation},
+		{"InvalidGroup.NotFound: The security group 'sg-0123' does not exist", helpers.TerraformErrorValidation},
+		{"InvalidSecurityGroupID.NotFound: The security group ID 'sg-0123' is not valid", helpers.TerraformErrorValidation},
+		{"Failed to install provider: could not query provider registry for registry.terraform.io/hashicorp/aws", helpers.TerraformErrorNetwork},
+		{"RequestError: send request failed caused by: dial tcp: i/o timeout", helpers.TerraformErrorNetwork},
+		{"Invalid value for variable", helpers.TerraformErrorValidation},
+		{"Value for undeclared variable", helpers.TerraformErrorValidation},
+		{"MalformedPolicyDocument: Policy document should not specify a principal.", helpers.TerraformErrorValidation},
+		{`expected deletion_window_in_days to be in the range (7 - 30), got 3`, helpers.TerraformErrorValidation},
+		{"AlreadyExistsException: An alias with the name already exists", helpers.TerraformErrorUnknown},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.message, func(t *testing.T) {
+			t.Parallel()
+			category := helpers.ClassifyTerraformMessage(tt.message)
+			assert.Equal(t, tt.want, category)
+		})
+	}
+}
+
+func TestCategoryRetryable(t *testing.T) {
+	t.Parallel()
+
+	retryable := map[helpers.TerraformErrorCategory]bool{
+		helpers.TerraformErrorThrottling:          true,
+		helpers.TerraformErrorEventualConsistency: true,
+		helpers.TerraformErrorCapacity:            true,
+		helpers.TerraformErrorNetwork:             true,
+		helpers.TerraformErrorAuth:                false,
+		helpers.TerraformErrorValidation:          false,
+		helpers.TerraformErrorQuota:               false,
+		helpers.TerraformErrorUnknown:             false,
+	}
+	for category, want := range retryable {
+		assert.Equal(t, want, category.Retryable(), "%s", category)
+	}
+}
+
+func TestParseTerraformErrorsMixedApply(t *testing.T) {
+	t.Parallel()
+
+	diagnostics := helpers.ParseTerraformErrors(readOutput(t, "mixed_apply.txt"))
+	require.Len(t, diagnostics, 2)
+
+	assert.Equal(t, helpers.TerraformErrorEventualConsistency, diagnostics[0].Category)
+	assert.Equal(t, "module.kms.aws_lambda_function.auto_recovery[0]", diagnostics[0].Address)
+	assert.Contains(t, diagnostics[0].Summary, "creating Lambda Function")
+
+	assert.Equal(t, helpers.TerraformErrorThrottling, diagnostics[1].Category)
+	assert.Equal(t, "module.kms.aws_cloudtrail.kms_trail[0]", diagnostics[1].Address)
+	assert.Empty(t, diagnostics[1].Detail, "위치와 소스 줄은 상세에 넣지 않아야 합니다")
+}
+
+func TestParseTerraformErrorsNoColor(t *testing.T) {
+	t.Parallel()
+
+	diagnostics := helpers.ParseTerraformErrors(readOutput(t, "nocolor_validation.txt"))
+	require.Len(t, diagnostics, 2)
+
+	assert.Equal(t, helpers.TerraformErrorValidation, diagnostics[0].Category)
+	assert.Empty(t, diagnostics[0].Address, "변수 오류는 리소스 주소가 없습니다")
+	assert.Contains(t, diagnostics[0].Detail, "KMS 키 삭제 대기 기간은 7-30일 사이여야 합니다.")
+	assert.Equal(t, "[validation] (리소스 없음): Invalid value for variable", diagnostics[0].String())
+
+	assert.Equal(t, helpers.TerraformErrorAuth, diagnostics[1].Category)
+	assert.Equal(t, "module.kms.aws_kms_key.k8s_key", diagnostics[1].Address)
+}
+
+func TestParseTerraformErrorsStripsColor(t *testing.T) {
+	t.Parallel()
+
+	diagnostics := helpers.ParseTerraformErrors(readOutput(t, "color_alias_conflict.txt"))
+	require.Len(t, diagnostics, 1)
+	assert.Equal(t, "module.kms.aws_kms_alias.k8s_key_alias", diagnostics[0].Address)
+	assert.NotContains(t, diagnostics[0].Summary, "\x1b[")
+	assert.Contains(t, diagnostics[0].Summary, "AlreadyExistsException")
+	assert.Equal(t, helpers.TerraformErrorUnknown, diagnostics[0].Category)
+}
+
+func TestParseTerraformErrorsIgnoresWarnings(t *testing.T) {
+	t.Parallel()
+
+	output := `
+Warning: Argument is deprecated
+
+  with module.kms.aws_s3_bucket.kms_logs[0],
+  on ../../modules/kms/main.tf line 44:
+
+Use the aws_s3_bucket_versioning resource instead (ThrottlingException는 경고 안에서 무시)
+
+Apply complete! Resources: 3 added, 0 changed, 0 destroyed.
+`
+	assert.Empty(t, helpers.ParseTerraformErrors(output))
+}
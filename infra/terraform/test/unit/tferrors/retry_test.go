@@ -0,0 +1,85 @@
+package tferrors
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/k8s-ec2-observability/test/helpers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var fastPolicy = helpers.TerraformRetryPolicy{MaxRetries: 3, Delay: time.Millisecond, MaxDelay: 5 * time.Millisecond}
+
+// attempts 시도마다 outputs를 차례로 돌려주고 마지막 다음에는 성공
+func attempts(outputs ...string) (func() (string, error), *int) {
+	calls := 0
+	return func() (string, error) {
+		calls++
+		if calls <= len(outputs) {
+			return outputs[calls-1], errors.New("exit status 1")
+		}
+		return "Apply complete!", nil
+	}, &calls
+}
+
+const (
+	throttled = "Error: creating CloudTrail Trail: ThrottlingException: Rate exceeded\n\n  with module.kms.aws_cloudtrail.kms_trail[0],\n"
+	denied    = "Error: creating KMS Key: AccessDeniedException: not authorized to perform: kms:CreateKey\n\n  with module.kms.aws_kms_key.k8s_key,\n"
+)
+
+func TestRetryTransientErrors(t *testing.T) {
+	t.Parallel()
+
+	run, calls := attempts(throttled, throttled)
+	output, err := helpers.RunTerraformWithRetry(t, "apply", fastPolicy, run)
+	require.NoError(t, err)
+	assert.Equal(t, "Apply complete!", output)
+	assert.Equal(t, 3, *calls)
+}
+
+func TestNoRetryForPermanentErrors(t *testing.T) {
+	t.Parallel()
+
+	// 일시적인 오류와 함께 나와도 권한 오류가 있으면 재시도하지 않음
+	run, calls := attempts(throttled + "\n" + denied)
+	_, err := helpers.RunTerraformWithRetry(t, "apply", fastPolicy, run)
+	require.Error(t, err)
+	assert.Equal(t, 1, *calls, "영구적인 오류는 재시도하지 않아야 합니다")
+
+	var terraformErr *helpers.TerraformError
+	require.ErrorAs(t, err, &terraformErr)
+	assert.False(t, terraformErr.Retryable())
+	assert.Equal(t, []helpers.TerraformErrorCategory{helpers.TerraformErrorThrottling, helpers.TerraformErrorAuth}, terraformErr.Categories())
+	assert.Equal(t, "terraform apply 실패 (1회 시도)\n"+
+		"  [throttling] module.kms.aws_cloudtrail.kms_trail[0]: creating CloudTrail Trail: ThrottlingException: Rate exceeded\n"+
+		"  [auth] module.kms.aws_kms_key.k8s_key: creating KMS Key: AccessDeniedException: not authorized to perform: kms:CreateKey",
+		err.Error())
+	assert.EqualError(t, errors.Unwrap(err), "exit status 1")
+}
+
+func TestNoRetryWithoutDiagnostics(t *testing.T) {
+	t.Parallel()
+
+	// 진단이 없으면(바이너리 없음, 비정상 종료) 원인을 모르므로 재시도하지 않음
+	run, calls := attempts("exec: \"terraform\": executable file not found in $PATH")
+	_, err := helpers.RunTerraformWithRetry(t, "init", fastPolicy, run)
+	require.Error(t, err)
+	assert.Equal(t, 1, *calls)
+	assert.Equal(t, "terraform init 실패 (1회 시도): exit status 1", err.Error())
+}
+
+func TestRetryGivesUpAfterMaxRetries(t *testing.T) {
+	t.Parallel()
+
+	run, calls := attempts(throttled, throttled, throttled, throttled, throttled)
+	_, err := helpers.RunTerraformWithRetry(t, "destroy", fastPolicy, run)
+	require.Error(t, err)
+	assert.Equal(t, 4, *calls, "최초 시도 + 재시도 3회")
+
+	var terraformErr *helpers.TerraformError
+	require.ErrorAs(t, err, &terraformErr)
+	assert.Equal(t, 4, terraformErr.Attempts)
+	assert.True(t, terraformErr.Retryable(), "마지막 오류도 일시적인 오류로 남아 있어야 합니다")
+}
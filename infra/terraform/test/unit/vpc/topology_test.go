@@ -0,0 +1,262 @@
+package vpc
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
+	"github.com/k8s-ec2-observability/test/helpers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	vpcID           = "vpc-0123"
+	publicSubnetID  = "subnet-public"
+	privateSubnetID = "subnet-private"
+	igwID           = "igw-0123"
+	natID           = "nat-0123"
+)
+
+// fakeEC2 VPC 하나의 Describe 응답을 돌려주는 EC2 대역
+type fakeEC2 struct {
+	ec2iface.EC2API
+
+	vpcs             []*ec2.Vpc
+	subnets          []*ec2.Subnet
+	internetGateways []*ec2.InternetGateway
+	natGateways      []*ec2.NatGateway
+	routeTables      []*ec2.RouteTable
+}
+
+func (f *fakeEC2) DescribeVpcs(*ec2.DescribeVpcsInput) (*ec2.DescribeVpcsOutput, error) {
+	return &ec2.DescribeVpcsOutput{Vpcs: f.vpcs}, nil
+}
+
+func (f *fakeEC2) DescribeSubnets(*ec2.DescribeSubnetsInput) (*ec2.DescribeSubnetsOutput, error) {
+	return &ec2.DescribeSubnetsOutput{Subnets: f.subnets}, nil
+}
+
+func (f *fakeEC2) DescribeInternetGateways(*ec2.DescribeInternetGatewaysInput) (*ec2.DescribeInternetGatewaysOutput, error) {
+	return &ec2.DescribeInternetGatewaysOutput{InternetGateways: f.internetGateways}, nil
+}
+
+func (f *fakeEC2) DescribeNatGateways(*ec2.DescribeNatGatewaysInput) (*ec2.DescribeNatGatewaysOutput, error) {
+	return &ec2.DescribeNatGatewaysOutput{NatGateways: f.natGateways}, nil
+}
+
+func (f *fakeEC2) DescribeRouteTables(*ec2.DescribeRouteTablesInput) (*ec2.DescribeRouteTablesOutput, error) {
+	return &ec2.DescribeRouteTablesOutput{RouteTables: f.routeTables}, nil
+}
+
+func route(cidr string, configure func(*ec2.Route)) *ec2.Route {
+	r := &ec2.Route{DestinationCidrBlock: aws.String(cidr), State: aws.String(ec2.RouteStateActive)}
+	configure(r)
+	return r
+}
+
+func localRoute() *ec2.Route {
+	return route("10.0.0.0/16", func(r *ec2.Route) { r.GatewayId = aws.String("local") })
+}
+
+func association(subnetID string) *ec2.RouteTableAssociation {
+	return &ec2.RouteTableAssociation{SubnetId: aws.String(subnetID)}
+}
+
+// modules/vpc가 만드는 구성 (enable_nat_gateway에 따라 NAT와 프라이빗 기본 경로 유무가 바뀜)
+func moduleVPC(natEnabled bool) *fakeEC2 {
+	fake := &fakeEC2{
+		vpcs: []*ec2.Vpc{{VpcId: aws.String(vpcID), CidrBlock: aws.String("10.0.0.0/16")}},
+		subnets: []*ec2.Subnet{
+			{SubnetId: aws.String(publicSubnetID), CidrBlock: aws.String("10.0.1.0/24"), AvailabilityZone: aws.String("ap-northeast-2a"), MapPublicIpOnLaunch: aws.Bool(true)},
+			{SubnetId: aws.String(privateSubnetID), CidrBlock: aws.String("10.0.2.0/24"), AvailabilityZone: aws.String("ap-northeast-2a"), MapPublicIpOnLaunch: aws.Bool(false)},
+		},
+		internetGateways: []*ec2.InternetGateway{{
+			InternetGatewayId: aws.String(igwID),
+			Attachments:       []*ec2.InternetGatewayAttachment{{VpcId: aws.String(vpcID), State: aws.String("available")}},
+		}},
+		routeTables: []*ec2.RouteTable{
+			{
+				RouteTableId: aws.String("rtb-main"),
+				Associations: []*ec2.RouteTableAssociation{{Main: aws.Bool(true)}},
+				Routes:       []*ec2.Route{localRoute()},
+			},
+			{
+				RouteTableId: aws.String("rtb-public"),
+				Associations: []*ec2.RouteTableAssociation{association(publicSubnetID)},
+				Routes: []*ec2.Route{localRoute(), route(helpers.DefaultRouteCIDR, func(r *ec2.Route) {
+					r.GatewayId = aws.String(igwID)
+				})},
+			},
+			{
+				RouteTableId: aws.String("rtb-private"),
+				Associations: []*ec2.RouteTableAssociation{association(privateSubnetID)},
+				Routes:       []*ec2.Route{localRoute()},
+			},
+		},
+	}
+	if natEnabled {
+		fake.natGateways = []*ec2.NatGateway{{
+			NatGatewayId: aws.String(natID),
+			SubnetId:     aws.String(publicSubnetID),
+			State:        aws.String(ec2.NatGatewayStateAvailable),
+			NatGatewayAddresses: []*ec2.NatGatewayAddress{{
+				AllocationId: aws.String("eipalloc-0123"),
+				PublicIp:     aws.String("3.34.0.1"),
+			}},
+		}}
+		fake.routeTables[2].Routes = append(fake.routeTables[2].Routes, route(helpers.DefaultRouteCIDR, func(r *ec2.Route) {
+			r.NatGatewayId = aws.String(natID)
+		}))
+	}
+	return fake
+}
+
+func TestDescribeVPCTopology(t *testing.T) {
+	t.Parallel()
+
+	topology, err := helpers.DescribeVPCTopology(moduleVPC(true), vpcID)
+	require.NoError(t, err)
+
+	assert.Equal(t, "10.0.0.0/16", topology.CIDR)
+	assert.Equal(t, []string{privateSubnetID, publicSubnetID}, topology.SubnetIDs())
+	assert.Equal(t, igwID, topology.InternetGatewayID)
+	assert.True(t, topology.InternetGatewayAttached)
+
+	public := topology.Subnets[publicSubnetID]
+	assert.Equal(t, "10.0.1.0/24", public.CIDR)
+	assert.Equal(t, "ap-northeast-2a", public.AvailabilityZone)
+	assert.Equal(t, "rtb-public", public.RouteTableID)
+	assert.Equal(t, helpers.RouteTarget{Type: helpers.RouteTargetInternetGateway, ID: igwID, State: ec2.RouteStateActive}, public.DefaultRoute)
+
+	private := topology.Subnets[privateSubnetID]
+	assert.Equal(t, helpers.RouteTarget{Type: helpers.RouteTargetNATGateway, ID: natID, State: ec2.RouteStateActive}, private.DefaultRoute)
+	assert.Equal(t, []helpers.NATGatewayTopology{{
+		ID: natID, SubnetID: publicSubnetID, State: ec2.NatGatewayStateAvailable, AllocationID: "eipalloc-0123", PublicIP: "3.34.0.1",
+	}}, topology.NATGateways)
+
+	assert.Empty(t, helpers.CheckInternetGatewayAttached(topology))
+	assert.Empty(t, helpers.CheckPublicSubnet(topology, publicSubnetID))
+	assert.Empty(t, helpers.CheckPrivateSubnetEgressViaNAT(topology, privateSubnetID))
+	assert.Empty(t, helpers.CheckNATGateway(topology, true))
+	assert.NotEmpty(t, helpers.CheckNATGateway(topology, false))
+}
+
+func TestDescribeVPCTopologyWithoutNAT(t *testing.T) {
+	t.Parallel()
+
+	topology, err := helpers.DescribeVPCTopology(moduleVPC(false), vpcID)
+	require.NoError(t, err)
+
+	assert.Empty(t, topology.NATGateways)
+	assert.Equal(t, helpers.RouteTargetNone, topology.Subnets[privateSubnetID].DefaultRoute.Type)
+	assert.Empty(t, helpers.CheckNATGateway(topology, false))
+	assert.Equal(t, []string{"enable_nat_gateway가 true인데 VPC vpc-0123에 NAT 게이트웨이가 없습니다"}, helpers.CheckNATGateway(topology, true))
+	assert.Equal(t, []string{"프라이빗 서브넷 subnet-private의 0.0.0.0/0 경로가 NAT 게이트웨이가 아닙니다 (경로 없음)"},
+		helpers.CheckPrivateSubnetEgressViaNAT(topology, privateSubnetID))
+	assert.Empty(t, helpers.CheckPublicSubnet(topology, publicSubnetID))
+}
+
+func TestVPCTopologyChecksReportMisrouting(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		mutate  func(*fakeEC2)
+		check   func(*helpers.VPCTopology) []string
+		problem string
+	}{
+		{
+			name: "퍼블릭 서브넷이 NAT로 라우팅",
+			mutate: func(f *fakeEC2) {
+				f.routeTables[1].Routes[1] = route(helpers.DefaultRouteCIDR, func(r *ec2.Route) { r.NatGatewayId = aws.String(natID) })
+			},
+			check: func(topology *helpers.VPCTopology) []string {
+				return helpers.CheckPublicSubnet(topology, publicSubnetID)
+			},
+			problem: "퍼블릭 서브넷 subnet-public의 0.0.0.0/0 경로가 인터넷 게이트웨이가 아닙니다 (nat-gateway nat-0123)",
+		},
+		{
+			name: "연결 없는 서브넷은 main 라우트 테이블 사용",
+			mutate: func(f *fakeEC2) {
+				f.routeTables = f.routeTables[:2]
+			},
+			check: func(topology *helpers.VPCTopology) []string {
+				return helpers.CheckPrivateSubnetEgressViaNAT(topology, privateSubnetID)
+			},
+			problem: "프라이빗 서브넷 subnet-private의 0.0.0.0/0 경로가 NAT 게이트웨이가 아닙니다 (경로 없음)",
+		},
+		{
+			name: "인터넷 게이트웨이 분리됨",
+			mutate: func(f *fakeEC2) {
+				f.internetGateways[0].Attachments[0].State = aws.String("detached")
+			},
+			check:   helpers.CheckInternetGatewayAttached,
+			problem: "인터넷 게이트웨이 igw-0123가 VPC vpc-0123에 연결된 상태가 아닙니다",
+		},
+		{
+			name: "blackhole 경로",
+			mutate: func(f *fakeEC2) {
+				f.routeTables[2].Routes[1].State = aws.String(ec2.RouteStateBlackhole)
+				f.natGateways[0].State = aws.String(ec2.NatGatewayStateFailed)
+			},
+			check: func(topology *helpers.VPCTopology) []string {
+				return helpers.CheckPrivateSubnetEgressViaNAT(topology, privateSubnetID)
+			},
+			problem: "NAT 게이트웨이 nat-0123가 failed 상태입니다",
+		},
+		{
+			name: "NAT가 프라이빗 서브넷에 있음",
+			mutate: func(f *fakeEC2) {
+				f.natGateways[0].SubnetId = aws.String(privateSubnetID)
+			},
+			check: func(topology *helpers.VPCTopology) []string {
+				return helpers.CheckPrivateSubnetEgressViaNAT(topology, privateSubnetID)
+			},
+			problem: "NAT 게이트웨이 nat-0123의 서브넷 subnet-private가 인터넷 게이트웨이로 라우팅되지 않습니다",
+		},
+		{
+			name:   "다른 VPC의 서브넷",
+			mutate: func(f *fakeEC2) {},
+			check: func(topology *helpers.VPCTopology) []string {
+				return helpers.CheckPublicSubnet(topology, "subnet-other")
+			},
+			problem: "서브넷 subnet-other가 VPC vpc-0123에 없습니다",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			fake := moduleVPC(true)
+			tt.mutate(fake)
+			topology, err := helpers.DescribeVPCTopology(fake, vpcID)
+			require.NoError(t, err)
+			assert.Contains(t, tt.check(topology), tt.problem)
+		})
+	}
+}
+
+func TestDescribeVPCTopologyFallsBackToMainRouteTable(t *testing.T) {
+	t.Parallel()
+
+	fake := moduleVPC(true)
+	fake.routeTables = fake.routeTables[:2]
+	topology, err := helpers.DescribeVPCTopology(fake, vpcID)
+	require.NoError(t, err)
+
+	private := topology.Subnets[privateSubnetID]
+	assert.True(t, private.MainRouteTable, "명시적 연결이 없으면 main 라우트 테이블을 사용")
+	assert.Equal(t, "rtb-main", private.RouteTableID)
+	assert.False(t, topology.Subnets[publicSubnetID].MainRouteTable)
+}
+
+func TestDescribeVPCTopologyMissingVPC(t *testing.T) {
+	t.Parallel()
+
+	_, err := helpers.DescribeVPCTopology(&fakeEC2{}, vpcID)
+	assert.EqualError(t, err, "VPC vpc-0123를 찾을 수 없습니다")
+}
@@ -0,0 +1,127 @@
+package workdir
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/k8s-ec2-observability/test/helpers"
+	"github.com/k8s-ec2-observability/test/helpers/tfcontract"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeFiles 상대 경로 → 내용으로 디렉터리 구성
+func writeFiles(t *testing.T, root string, files map[string]string) {
+	t.Helper()
+	for path, content := range files {
+		full := filepath.Join(root, path)
+		require.NoError(t, os.MkdirAll(filepath.Dir(full), 0o755))
+		require.NoError(t, os.WriteFile(full, []byte(content), 0o644))
+	}
+}
+
+func TestCopyKMSExample(t *testing.T) {
+	t.Parallel()
+
+	dest := t.TempDir()
+	dir, err := helpers.CopyTerraformModuleE("../../../examples/kms", dest)
+	require.NoError(t, err)
+
+	assert.Equal(t, filepath.Join(dest, "examples", "kms"), dir)
+	assert.FileExists(t, filepath.Join(dest, "examples", "kms", "main.tf"))
+	assert.FileExists(t, filepath.Join(dest, "modules", "kms", "main.tf"))
+	assert.FileExists(t, filepath.Join(dest, "modules", "kms", "functions", "auto_recovery.py"), "archive_file이 쓰는 함수 소스도 복사해야 합니다")
+	assert.NoDirExists(t, filepath.Join(dest, "modules", "vpc"), "참조하지 않는 모듈은 복사하지 않아야 합니다")
+	assert.NoDirExists(t, filepath.Join(dest, "test"))
+
+	tree, err := tfcontract.LoadTree(dir)
+	require.NoError(t, err)
+	assert.Len(t, tree.Modules, 2, "복사본에서도 ../../modules/kms source가 풀려야 합니다")
+}
+
+func TestCopyFollowsNestedSources(t *testing.T) {
+	t.Parallel()
+
+	src := t.TempDir()
+	writeFiles(t, src, map[string]string{
+		"stacks/app/main.tf":                     `module "a" { source = "../../modules/a" }`,
+		"modules/a/main.tf":                      `module "b" { source = "../b" }`,
+		"modules/a/files/bootstrap.sh":           "#!/bin/sh\n",
+		"modules/a/nested/main.tf":               `resource "null_resource" "x" {}`,
+		"modules/a/.terraform.lock.hcl":          "# lock\n",
+		"modules/a/.terraform/modules/m.json":    "{}",
+		"modules/a/terraform.tfstate":            "{}",
+		"modules/a/terraform.tfstate.backup":     "{}",
+		"modules/a/terraform.tfstate.d/dev/x":    "{}",
+		"modules/a/.terraform.tfstate.lock.info": "{}",
+		"modules/b/main.tf":                      `resource "null_resource" "b" {}`,
+		"modules/unused/main.tf":                 `resource "null_resource" "u" {}`,
+	})
+	require.NoError(t, os.Chmod(filepath.Join(src, "modules/a/files/bootstrap.sh"), 0o755))
+
+	dest := t.TempDir()
+	dir, err := helpers.CopyTerraformModuleE(filepath.Join(src, "stacks", "app"), dest)
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(dest, "stacks", "app"), dir)
+
+	copied := func(path string) string { return filepath.Join(dest, path) }
+	assert.FileExists(t, copied("modules/a/main.tf"))
+	assert.FileExists(t, copied("modules/b/main.tf"), "참조한 모듈이 다시 참조하는 모듈도 복사해야 합니다")
+	assert.FileExists(t, copied("modules/a/.terraform.lock.hcl"), "잠금 파일은 유지해야 합니다")
+	assert.NoDirExists(t, copied("modules/unused"))
+	assert.NoDirExists(t, copied("modules/a/nested"), "참조하지 않는 하위 모듈은 복사하지 않아야 합니다")
+	assert.NoDirExists(t, copied("modules/a/.terraform"))
+	assert.NoDirExists(t, copied("modules/a/terraform.tfstate.d"))
+	assert.NoFileExists(t, copied("modules/a/terraform.tfstate"))
+	assert.NoFileExists(t, copied("modules/a/terraform.tfstate.backup"))
+	assert.NoFileExists(t, copied("modules/a/.terraform.tfstate.lock.info"))
+
+	info, err := os.Stat(copied("modules/a/files/bootstrap.sh"))
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o755), info.Mode().Perm(), "실행 권한을 유지해야 합니다")
+}
+
+func TestCopiesAreIndependent(t *testing.T) {
+	t.Parallel()
+
+	first := helpers.CopyTerraformModule(t, "../../../examples/kms")
+	second := helpers.CopyTerraformModule(t, "../../../examples/kms")
+	assert.NotEqual(t, first, second)
+
+	// 한 복사본의 실행 결과물이 다른 복사본에 보이지 않아야 함
+	require.NoError(t, os.WriteFile(filepath.Join(first, "terraform.tfstate"), []byte("{}"), 0o644))
+	assert.NoFileExists(t, filepath.Join(second, "terraform.tfstate"))
+}
+
+func TestCopyRejectsFilesOutsideModules(t *testing.T) {
+	t.Parallel()
+
+	// ec2-master는 ${path.root}/../../scripts의 스크립트를 읽으므로 복사본에서 실행할 수 없음
+	_, err := helpers.CopyTerraformModuleE("../../../modules/ec2-master", t.TempDir())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "system_settings.sh")
+
+	src := t.TempDir()
+	writeFiles(t, src, map[string]string{
+		"stacks/app/main.tf":   `module "a" { source = "../../modules/a" }`,
+		"modules/a/main.tf":    `locals { ok = file("${path.module}/files/x.sh") }` + "\n" + `locals { shared = "${path.root}/../../modules/a/files" }`,
+		"modules/a/files/x.sh": "#!/bin/sh\n",
+		"modules/b/main.tf":    `locals { bad = file("${path.module}/../../scripts/${var.name}.sh") }`,
+		"stacks/other/main.tf": `module "b" { source = "../../modules/b" }`,
+		"scripts/bootstrap.sh": "#!/bin/sh\n",
+	})
+	_, err = helpers.CopyTerraformModuleE(filepath.Join(src, "stacks", "app"), t.TempDir())
+	assert.NoError(t, err, "복사 대상 모듈 안의 파일 참조는 허용해야 합니다")
+
+	_, err = helpers.CopyTerraformModuleE(filepath.Join(src, "stacks", "other"), t.TempDir())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "${path.module}/../../scripts/")
+}
+
+func TestCopyMissingModule(t *testing.T) {
+	t.Parallel()
+
+	_, err := helpers.CopyTerraformModuleE(filepath.Join(t.TempDir(), "missing"), t.TempDir())
+	assert.Error(t, err)
+}
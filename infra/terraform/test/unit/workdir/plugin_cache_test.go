@@ -0,0 +1,57 @@
+package workdir
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/k8s-ec2-observability/test/helpers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var vpcVars = map[string]interface{}{
+	"project_name":      "k8s-ec2-observability",
+	"environment":       "test",
+	"vpc_cidr":          "10.0.0.0/16",
+	"availability_zone": "ap-northeast-2a",
+}
+
+// t.Setenv을 쓰므로 병렬로 실행하지 않음
+func TestPluginCacheDirFromEnv(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "plugins")
+	t.Setenv(helpers.EnvPluginCacheDir, dir)
+
+	cacheDir, err := helpers.TerraformPluginCacheDir()
+	require.NoError(t, err)
+	assert.Equal(t, dir, cacheDir)
+	assert.DirExists(t, dir, "캐시 디렉터리를 만들어야 합니다")
+}
+
+func TestIsolatedSetupUsesPluginCache(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "plugins")
+	t.Setenv(helpers.EnvPluginCacheDir, dir)
+
+	options := helpers.SetupTerraform(t, helpers.TerraformConfig{
+		ModulePath: "../../../modules/vpc",
+		Vars:       vpcVars,
+		Isolated:   true,
+	})
+	assert.NotEqual(t, "../../../modules/vpc", options.TerraformDir)
+	assert.FileExists(t, filepath.Join(options.TerraformDir, "main.tf"))
+	assert.Equal(t, dir, options.EnvVars[helpers.EnvPluginCacheDir])
+	assert.Equal(t, "true", options.EnvVars["TF_PLUGIN_CACHE_MAY_BREAK_DEPENDENCY_LOCK_FILE"])
+}
+
+func TestSetupRunsInPlaceByDefault(t *testing.T) {
+	t.Parallel()
+
+	options := helpers.SetupTerraform(t, helpers.TerraformConfig{
+		ModulePath: "../../../modules/vpc",
+		Vars:       vpcVars,
+	})
+	assert.Equal(t, "../../../modules/vpc", options.TerraformDir)
+	assert.NotContains(t, options.EnvVars, helpers.EnvPluginCacheDir)
+	// 재시도는 오류 분류기만 결정해야 terratest 재시도와 곱해지지 않습니다
+	assert.Empty(t, options.RetryableTerraformErrors)
+	assert.Zero(t, options.MaxRetries)
+}
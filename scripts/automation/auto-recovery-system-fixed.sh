@@ -8,9 +8,9 @@
 
 set -euo pipefail
 
-# 설정 변수
-MASTER_NODE="ip-10-0-1-34"
-LOG_FILE="/tmp/auto-recovery-$(date +%Y%m%d).log"
+# 설정 변수 (환경 변수로 재정의 가능)
+MASTER_NODE="${MASTER_NODE:-ip-10-0-1-34}"
+LOG_FILE="${LOG_FILE:-/tmp/auto-recovery-$(date +%Y%m%d).log}"
 CHECK_INTERVAL=30
 DRY_RUN=false
 
@@ -197,7 +197,8 @@ system_status_report() {
     running_pods=$(kubectl get pods -A --field-selector=status.phase=Running --no-headers | wc -l)
     
     local error_pods
-    error_pods=$(kubectl get pods -A | grep -c "Error\|CrashLoopBackOff\|ImagePullBackOff" || echo "0")
+    # grep -c는 일치가 없으면 0을 출력하고 1로 종료
+    error_pods=$(kubectl get pods -A | grep -c "Error\|CrashLoopBackOff\|ImagePullBackOff" || true)
     
     local pending_pods
     pending_pods=$(kubectl get pods -A --field-selector=status.phase=Pending --no-headers | wc -l)
@@ -207,7 +208,11 @@ system_status_report() {
     log "   실행 중: ${running_pods}개"
     log "   오류: ${error_pods}개"
     log "   대기 중: ${pending_pods}개"
-    log "   성공률: $(( running_pods * 100 / total_pods ))%"
+    if [[ ${total_pods} -gt 0 ]]; then
+        log "   성공률: $(( running_pods * 100 / total_pods ))%"
+    else
+        log "   성공률: N/A"
+    fi
 }
 
 # 메인 실행 함수
@@ -229,5 +234,7 @@ main() {
     fi
 }
 
-# 스크립트 실행
-main "$@" 
\ No newline at end of file
+# 스크립트 실행 (source로 불러온 경우 함수만 정의)
+if [[ "${BASH_SOURCE[0]}" == "${0}" ]]; then
+    main "$@"
+fi 
\ No newline at end of file
@@ -4,9 +4,15 @@
 # ---------------- 환경 변수 설정 섹션 ------------------
 #################################################################
 
+# 파일 경로 기준 루트 (테스트에서 격리된 디렉터리로 재정의 가능)
+readonly ROOT_DIR="${K8S_SETUP_ROOT:-}"
+readonly UBUNTU_HOME="${ROOT_DIR}/home/ubuntu"
+
 # 로그 설정
-readonly LOG_FILE="/home/ubuntu/combined_settings.log"
+readonly LOG_FILE="${UBUNTU_HOME}/combined_settings.log"
 readonly LOG_PREFIX="[K8S-SETUP]"
+# 시리얼 콘솔 (remote-exec로 실행되므로 stdout은 콘솔에 남지 않음, EC2 콘솔 출력으로 진행 상황을 보려고 함께 기록)
+readonly CONSOLE_DEVICE="${ROOT_DIR}/dev/console"
 readonly SCRIPT_DIR="$(cd "$(dirname "${BASH_SOURCE[0]}")" && pwd)"
 
 # AWS EC2 메타데이터
@@ -21,18 +27,23 @@ readonly DNS_DOMAIN="cluster.local"
 readonly CNI_VERSION="v3.28.0"
 readonly CNI_MANIFEST="https://raw.githubusercontent.com/projectcalico/calico/${CNI_VERSION}/manifests/tigera-operator.yaml"
 readonly CNI_MANIFEST_CUSTOM="https://raw.githubusercontent.com/projectcalico/calico/${CNI_VERSION}/manifests/custom-resources.yaml"
+readonly MODULES_LOAD_CONF="${ROOT_DIR}/etc/modules-load.d/k8s.conf"
+readonly SYSCTL_CONF="${ROOT_DIR}/etc/sysctl.d/k8s.conf"
 
 # containerd 설정
-readonly CONTAINERD_CONFIG="/etc/containerd/config.toml"
-readonly CONTAINERD_CONFIG_DIR="/etc/containerd"
+readonly CONTAINERD_CONFIG_DIR="${ROOT_DIR}/etc/containerd"
+readonly CONTAINERD_CONFIG="${CONTAINERD_CONFIG_DIR}/config.toml"
 
 # 쿠버네티스 설정
 readonly K8S_VERSION="1.31.0-1.1"
 readonly K8S_REPO="https://pkgs.k8s.io/core:/stable:/v1.31/deb/"
-readonly K8S_KEYRING="/etc/apt/keyrings/kubernetes-apt-keyring.gpg"
-readonly K8S_APT_SOURCE="/etc/apt/sources.list.d/kubernetes.list"
-readonly K8S_CONFIG_DIR="/etc/kubernetes"
-readonly KUBECONFIG="/home/ubuntu/.kube/config"
+readonly K8S_KEYRING="${ROOT_DIR}/etc/apt/keyrings/kubernetes-apt-keyring.gpg"
+readonly K8S_APT_SOURCE="${ROOT_DIR}/etc/apt/sources.list.d/kubernetes.list"
+readonly K8S_CONFIG_DIR="${ROOT_DIR}/etc/kubernetes"
+readonly KUBECONFIG="${UBUNTU_HOME}/.kube/config"
+readonly KUBEADM_CONFIG="${ROOT_DIR}/tmp/kubeadm-config.yaml"
+readonly KUBEADM_INIT_LOG="${ROOT_DIR}/var/log/kubeadm_init.log"
+readonly JOIN_COMMAND_FILE="${UBUNTU_HOME}/join_command"
 
 readonly PACKAGES=(
     "kubelet=${K8S_VERSION}"
@@ -68,7 +79,12 @@ readonly WAIT_INTERVAL=10
 
 log() {
     local message="$1"
-    echo "${LOG_PREFIX} $(date '+%Y-%m-%d %H:%M:%S') - ${message}" | tee -a "${LOG_FILE}"
+    local line="${LOG_PREFIX} $(date '+%Y-%m-%d %H:%M:%S') - ${message}"
+    echo "${line}" | tee -a "${LOG_FILE}"
+    # 콘솔이 없는 환경에서는 파일 로그만 남김
+    if [ -w "${CONSOLE_DEVICE}" ]; then
+        echo "${line}" >> "${CONSOLE_DEVICE}"
+    fi
 }
 
 check_error() {
@@ -134,12 +150,12 @@ setup_network() {
     # 커널 모듈 로드
     for module in "${KERNEL_MODULES[@]}"; do
         modprobe "${module}"
-        echo "${module}" >> /etc/modules-load.d/k8s.conf
+        echo "${module}" >> "${MODULES_LOAD_CONF}"
     done
 
     # sysctl 설정
     for setting in "${SYSCTL_SETTINGS[@]}"; do
-        echo "${setting}" >> /etc/sysctl.d/k8s.conf
+        echo "${setting}" >> "${SYSCTL_CONF}"
     done
     sysctl --system
     check_error "네트워크 설정 실패"
@@ -275,7 +291,7 @@ initialize_master() {
     log "마스터 노드 초기화 시작"
 
     # kubeadm 설정 생성
-    cat > /tmp/kubeadm-config.yaml <<EOF
+    cat > "${KUBEADM_CONFIG}" <<EOF
 apiVersion: kubeadm.k8s.io/v1beta3
 kind: InitConfiguration
 localAPIEndpoint:
@@ -294,9 +310,9 @@ EOF
 
     # API 버전 마이그레이션 시도
     log "kubeadm 구성 파일 마이그레이션 시도 중..."
-    if kubeadm config migrate --old-config /tmp/kubeadm-config.yaml --new-config /tmp/new-kubeadm-config.yaml; then
+    if kubeadm config migrate --old-config "${KUBEADM_CONFIG}" --new-config "${KUBEADM_CONFIG}.new"; then
         log "구성 파일 마이그레이션 성공, 새 구성 파일을 사용합니다."
-        mv /tmp/new-kubeadm-config.yaml /tmp/kubeadm-config.yaml
+        mv "${KUBEADM_CONFIG}.new" "${KUBEADM_CONFIG}"
     else
         log "구성 파일 마이그레이션 실패, 기존 구성 파일을 사용합니다."
     fi
@@ -310,7 +326,7 @@ EOF
         NODENAME=$(hostname -s)
         
         # kubeadm init 실행 (--ignore-preflight-errors=Swap 추가)
-        if kubeadm init --config=/tmp/kubeadm-config.yaml --upload-certs --ignore-preflight-errors=Swap > /var/log/kubeadm_init.log 2>&1; then
+        if kubeadm init --config="${KUBEADM_CONFIG}" --upload-certs --ignore-preflight-errors=Swap > "${KUBEADM_INIT_LOG}" 2>&1; then
             log "마스터 노드 초기화 성공"
             init_success=true
             break
@@ -318,7 +334,7 @@ EOF
         
         # 오류 로그 확인
         log "초기화 실패. 오류 로그:"
-        cat /var/log/kubeadm_init.log
+        cat "${KUBEADM_INIT_LOG}"
         
         retry_count=$((retry_count + 1))
         log "초기화 실패. ${RETRY_INTERVAL}초 후 재시도... (${retry_count}/${MAX_RETRIES})"
@@ -344,12 +360,12 @@ EOF
 
     # Join 명령어 생성
     log "Join 명령어 생성 중..."
-    kubeadm token create --print-join-command > /home/ubuntu/join_command
-    chown ubuntu:ubuntu /home/ubuntu/join_command
-    chmod 644 /home/ubuntu/join_command
+    kubeadm token create --print-join-command > "${JOIN_COMMAND_FILE}"
+    chown ubuntu:ubuntu "${JOIN_COMMAND_FILE}"
+    chmod 644 "${JOIN_COMMAND_FILE}"
     
     # Join 명령어 파일 생성 확인
-    if [ ! -f /home/ubuntu/join_command ]; then
+    if [ ! -f "${JOIN_COMMAND_FILE}" ]; then
         log "Join 명령어 파일 생성 실패"
         return 1
     fi
@@ -490,14 +506,18 @@ generate_join_command() {
     
     # join 명령어 생성
     local join_command=$(kubeadm token create --print-join-command)
-    echo "${join_command}" > /home/ubuntu/join_command
+    if [ -z "${join_command}" ]; then
+        log "Join 명령어 파일 생성 실패"
+        return 1
+    fi
+    echo "${join_command}" > "${JOIN_COMMAND_FILE}"
     
     # 파일 권한 설정
-    chown ubuntu:ubuntu /home/ubuntu/join_command
-    chmod 644 /home/ubuntu/join_command
+    chown ubuntu:ubuntu "${JOIN_COMMAND_FILE}"
+    chmod 644 "${JOIN_COMMAND_FILE}"
     
     # 파일 생성 확인
-    if [ ! -f /home/ubuntu/join_command ]; then
+    if [ ! -f "${JOIN_COMMAND_FILE}" ]; then
         log "Join 명령어 파일 생성 실패"
         return 1
     fi
@@ -525,5 +545,7 @@ main() {
     log "설치 스크립트 완료"
 }
 
-# 스크립트 실행
-main
\ No newline at end of file
+# 스크립트 실행 (source로 불러온 경우 함수만 정의)
+if [[ "${BASH_SOURCE[0]}" == "${0}" ]]; then
+    main
+fi
\ No newline at end of file
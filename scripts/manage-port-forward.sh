@@ -12,6 +12,17 @@ YELLOW='\033[1;33m'
 BLUE='\033[0;34m'
 NC='\033[0m' # No Color
 
+# 포트, 상태 파일 위치 (환경 변수로 재정의 가능)
+BOOKINFO_PORT="${BOOKINFO_PORT:-30080}"
+GRAFANA_PORT="${GRAFANA_PORT:-30300}"
+STATE_DIR="${PORT_FORWARD_STATE_DIR:-/tmp}"
+STARTUP_WAIT="${PORT_FORWARD_STARTUP_WAIT:-5}"
+
+BOOKINFO_PID_FILE="${STATE_DIR}/bookinfo-portforward.pid"
+BOOKINFO_LOG_FILE="${STATE_DIR}/bookinfo-portforward.log"
+GRAFANA_PID_FILE="${STATE_DIR}/grafana-portforward.pid"
+GRAFANA_LOG_FILE="${STATE_DIR}/grafana-portforward.log"
+
 function log_info() {
     echo -e "${GREEN}[정보]${NC} $1"
 }
@@ -24,6 +35,15 @@ function log_error() {
     echo -e "${RED}[오류]${NC} $1"
 }
 
+# 퍼블릭 IP (PUBLIC_IP가 있으면 외부 조회 생략)
+function public_ip() {
+    if [ -n "${PUBLIC_IP:-}" ]; then
+        echo "${PUBLIC_IP}"
+    else
+        curl -s ifconfig.me 2>/dev/null || echo "Your_Public_IP"
+    fi
+}
+
 function show_usage() {
     echo -e "${BLUE}k8s-ec2-observability Port-forward 관리${NC}"
     echo ""
@@ -37,8 +57,8 @@ function show_usage() {
     echo "  logs      - port-forward 로그 보기"
     echo ""
     echo "외부 접속 URL (port-forward 활성화 시):"
-    echo "  Bookinfo:  http://$(curl -s ifconfig.me 2>/dev/null || echo 'Your_Public_IP'):30080/productpage"
-    echo "  Grafana:   http://$(curl -s ifconfig.me 2>/dev/null || echo 'Your_Public_IP'):30300 (admin/admin)"
+    echo "  Bookinfo:  http://$(public_ip):${BOOKINFO_PORT}/productpage"
+    echo "  Grafana:   http://$(public_ip):${GRAFANA_PORT} (admin/admin)"
 }
 
 function start_port_forward() {
@@ -55,26 +75,26 @@ function start_port_forward() {
     }
     
     # Bookinfo port-forward 시작
-    log_info "Bookinfo port-forward를 시작하고 있습니다 (30080 -> 9080)..."
-    nohup kubectl port-forward -n bookinfo --address 0.0.0.0 svc/productpage 30080:9080 \
-        > /tmp/bookinfo-portforward.log 2>&1 &
-    echo $! > /tmp/bookinfo-portforward.pid
+    log_info "Bookinfo port-forward를 시작하고 있습니다 (${BOOKINFO_PORT} -> 9080)..."
+    nohup kubectl port-forward -n bookinfo --address 0.0.0.0 svc/productpage ${BOOKINFO_PORT}:9080 \
+        > "${BOOKINFO_LOG_FILE}" 2>&1 &
+    echo $! > "${BOOKINFO_PID_FILE}"
     
     # Grafana port-forward 시작 (NodePort로 이미 접속 가능하지 않은 경우)
-    if ! timeout 5 curl -s http://localhost:30300/login > /dev/null 2>&1; then
-        log_info "Grafana port-forward를 시작하고 있습니다 (30300 -> 80)..."
-        nohup kubectl port-forward -n monitoring --address 0.0.0.0 svc/prometheus-grafana 30300:80 \
-            > /tmp/grafana-portforward.log 2>&1 &
-        echo $! > /tmp/grafana-portforward.pid
+    if ! timeout 5 curl -s http://localhost:${GRAFANA_PORT}/login > /dev/null 2>&1; then
+        log_info "Grafana port-forward를 시작하고 있습니다 (${GRAFANA_PORT} -> 80)..."
+        nohup kubectl port-forward -n monitoring --address 0.0.0.0 svc/prometheus-grafana ${GRAFANA_PORT}:80 \
+            > "${GRAFANA_LOG_FILE}" 2>&1 &
+        echo $! > "${GRAFANA_PID_FILE}"
     else
         log_info "Grafana는 이미 NodePort를 통해 접속 가능합니다"
     fi
     
     # port-forward 시작 대기
-    sleep 5
+    sleep "${STARTUP_WAIT}"
     
     # port-forward 상태 확인
-    if ss -tulpn | grep -q ":30080" && ss -tulpn | grep -q ":30300"; then
+    if ss -tulpn | grep -q ":${BOOKINFO_PORT}" && ss -tulpn | grep -q ":${GRAFANA_PORT}"; then
         log_info "✅ port-forward 서비스가 성공적으로 시작되었습니다"
         show_access_info
     else
@@ -87,25 +107,25 @@ function stop_port_forward() {
     log_info "port-forward 서비스를 중지하고 있습니다..."
     
     # PID 파일로 중지
-    if [ -f /tmp/bookinfo-portforward.pid ]; then
-        if kill -0 $(cat /tmp/bookinfo-portforward.pid) 2>/dev/null; then
-            kill $(cat /tmp/bookinfo-portforward.pid)
+    if [ -f "${BOOKINFO_PID_FILE}" ]; then
+        if kill -0 $(cat "${BOOKINFO_PID_FILE}") 2>/dev/null; then
+            kill $(cat "${BOOKINFO_PID_FILE}")
             log_info "Bookinfo port-forward를 중지했습니다"
         fi
-        rm -f /tmp/bookinfo-portforward.pid
+        rm -f "${BOOKINFO_PID_FILE}"
     fi
     
-    if [ -f /tmp/grafana-portforward.pid ]; then
-        if kill -0 $(cat /tmp/grafana-portforward.pid) 2>/dev/null; then
-            kill $(cat /tmp/grafana-portforward.pid)
+    if [ -f "${GRAFANA_PID_FILE}" ]; then
+        if kill -0 $(cat "${GRAFANA_PID_FILE}") 2>/dev/null; then
+            kill $(cat "${GRAFANA_PID_FILE}")
             log_info "Grafana port-forward를 중지했습니다"
         fi
-        rm -f /tmp/grafana-portforward.pid
+        rm -f "${GRAFANA_PID_FILE}"
     fi
     
-    # 대체 방법: 프로세스 패턴으로 종료
-    pkill -f "kubectl.*port-forward.*30080" 2>/dev/null || true
-    pkill -f "kubectl.*port-forward.*30300" 2>/dev/null || true
+    # 대체 방법: 프로세스 패턴으로 종료 (로컬 포트가 정확히 일치하는 "LOCAL:REMOTE" 인자만)
+    pkill -f "kubectl.*port-forward .*[[:space:]]${BOOKINFO_PORT}:" 2>/dev/null || true
+    pkill -f "kubectl.*port-forward .*[[:space:]]${GRAFANA_PORT}:" 2>/dev/null || true
     
     log_info "✅ port-forward 서비스가 중지되었습니다"
 }
@@ -123,34 +143,35 @@ function show_status() {
     
     echo ""
     echo "포트 바인딩 상태:"
-    ss -tulpn | grep ":30080\|:30300" | while read line; do
+    ss -tulpn | grep ":${BOOKINFO_PORT}\|:${GRAFANA_PORT}" | while read line; do
         echo "  $line"
     done
     
     echo ""
     echo "서비스 접속 테스트:"
-    if timeout 3 curl -s http://localhost:30080/productpage > /dev/null 2>&1; then
-        echo -e "  Bookinfo (30080): ${GREEN}✅ 접속 가능${NC}"
+    if timeout 3 curl -s http://localhost:${BOOKINFO_PORT}/productpage > /dev/null 2>&1; then
+        echo -e "  Bookinfo (${BOOKINFO_PORT}): ${GREEN}✅ 접속 가능${NC}"
     else
-        echo -e "  Bookinfo (30080): ${RED}❌ 접속 불가${NC}"
+        echo -e "  Bookinfo (${BOOKINFO_PORT}): ${RED}❌ 접속 불가${NC}"
     fi
     
-    if timeout 3 curl -s http://localhost:30300/login > /dev/null 2>&1; then
-        echo -e "  Grafana (30300):  ${GREEN}✅ 접속 가능${NC}"
+    if timeout 3 curl -s http://localhost:${GRAFANA_PORT}/login > /dev/null 2>&1; then
+        echo -e "  Grafana (${GRAFANA_PORT}):  ${GREEN}✅ 접속 가능${NC}"
     else
-        echo -e "  Grafana (30300):  ${RED}❌ 접속 불가${NC}"
+        echo -e "  Grafana (${GRAFANA_PORT}):  ${RED}❌ 접속 불가${NC}"
     fi
 }
 
 function show_access_info() {
-    PUBLIC_IP=$(curl -s ifconfig.me 2>/dev/null || echo "Your_Public_IP")
+    local public_address
+    public_address=$(public_ip)
     
     echo ""
     echo -e "${BLUE}=== 외부 접속 URL ===${NC}"
-    echo -e "🔗 Bookinfo:  ${GREEN}http://${PUBLIC_IP}:30080/productpage${NC}"
-    echo -e "🔗 Grafana:   ${GREEN}http://${PUBLIC_IP}:30300${NC} (admin/admin)"
+    echo -e "🔗 Bookinfo:  ${GREEN}http://${public_address}:${BOOKINFO_PORT}/productpage${NC}"
+    echo -e "🔗 Grafana:   ${GREEN}http://${public_address}:${GRAFANA_PORT}${NC} (admin/admin)"
     echo ""
-    echo -e "${YELLOW}참고: AWS Security Group에서 포트 30080과 30300이 허용되어 있는지 확인하세요${NC}"
+    echo -e "${YELLOW}참고: AWS Security Group에서 포트 ${BOOKINFO_PORT}과 ${GRAFANA_PORT}이 허용되어 있는지 확인하세요${NC}"
 }
 
 function show_logs() {
@@ -158,16 +179,16 @@ function show_logs() {
     
     echo ""
     echo "Bookinfo port-forward 로그:"
-    if [ -f /tmp/bookinfo-portforward.log ]; then
-        tail -10 /tmp/bookinfo-portforward.log
+    if [ -f "${BOOKINFO_LOG_FILE}" ]; then
+        tail -10 "${BOOKINFO_LOG_FILE}"
     else
         echo "  로그 파일을 찾을 수 없습니다"
     fi
     
     echo ""
     echo "Grafana port-forward 로그:"
-    if [ -f /tmp/grafana-portforward.log ]; then
-        tail -10 /tmp/grafana-portforward.log
+    if [ -f "${GRAFANA_LOG_FILE}" ]; then
+        tail -10 "${GRAFANA_LOG_FILE}"
     else
         echo "  로그 파일을 찾을 수 없습니다"
     fi
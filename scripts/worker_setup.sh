@@ -7,7 +7,9 @@
 # 기본 변수 설정
 MASTER_IP="$1"
 NODE_INDEX="$2"
-SSH_KEY_PATH="/home/ubuntu/.ssh/k8s-key.pem"
+# 로컬 파일 경로 기준 루트 (테스트에서 격리된 디렉터리로 재정의 가능)
+UBUNTU_HOME="${K8S_SETUP_ROOT:-}/home/ubuntu"
+SSH_KEY_PATH="${UBUNTU_HOME}/.ssh/k8s-key.pem"
 
 #################################################################
 # ---------------- SSH 설정 섹션 ------------------
@@ -15,7 +17,7 @@ SSH_KEY_PATH="/home/ubuntu/.ssh/k8s-key.pem"
 
 # SSH 키 권한 설정
 sudo chmod 400 ${SSH_KEY_PATH}
-chmod +x /home/ubuntu/combined_settings.sh
+chmod +x ${UBUNTU_HOME}/combined_settings.sh
 
 #################################################################
 # ---------------- 환경 변수 설정 섹션 ------------------
@@ -30,17 +32,17 @@ export MASTER_PRIVATE_IP="${MASTER_IP}"
 #################################################################
 
 # 쿠버네티스 기본 설정 실행
-sudo -E /home/ubuntu/combined_settings.sh
+sudo -E ${UBUNTU_HOME}/combined_settings.sh
 
 #################################################################
 # ---------------- SSH 보안 설정 섹션 ------------------
 #################################################################
 
 # SSH 보안 설정
-touch /home/ubuntu/.ssh/known_hosts
-echo 'StrictHostKeyChecking no' > /home/ubuntu/.ssh/config
-chmod 600 /home/ubuntu/.ssh/config
-ssh-keyscan -p 22 ${MASTER_IP} 2>/dev/null >> /home/ubuntu/.ssh/known_hosts
+touch ${UBUNTU_HOME}/.ssh/known_hosts
+echo 'StrictHostKeyChecking no' > ${UBUNTU_HOME}/.ssh/config
+chmod 600 ${UBUNTU_HOME}/.ssh/config
+ssh-keyscan -p 22 ${MASTER_IP} 2>/dev/null >> ${UBUNTU_HOME}/.ssh/known_hosts
 
 #################################################################
 # ---------------- 클러스터 조인 섹션 ------------------
@@ -62,9 +64,9 @@ sudo $JOIN_CMD
 sleep 30
 
 # kubeconfig 설정
-mkdir -p /home/ubuntu/.kube
-ssh -i ${SSH_KEY_PATH} -o StrictHostKeyChecking=no ubuntu@${MASTER_IP} 'sudo cat /etc/kubernetes/admin.conf' > /home/ubuntu/.kube/config
-chmod 600 /home/ubuntu/.kube/config
+mkdir -p ${UBUNTU_HOME}/.kube
+ssh -i ${SSH_KEY_PATH} -o StrictHostKeyChecking=no ubuntu@${MASTER_IP} 'sudo cat /etc/kubernetes/admin.conf' > ${UBUNTU_HOME}/.kube/config
+chmod 600 ${UBUNTU_HOME}/.kube/config
 
 # 노드 레이블 설정
 NODE_IP=$(hostname -I | awk '{print $1}')